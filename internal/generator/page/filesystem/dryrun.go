@@ -0,0 +1,61 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// DryRunFileSystem reads from a base filesystem and keeps the writes in memory,
+// so that a build can be planned without touching the base filesystem.
+// Files written are read back from memory; Walk only lists the base filesystem.
+type DryRunFileSystem struct {
+	base    FileSystem
+	written *MemoryFileSystem
+}
+
+// NewDryRunFileSystem creates a filesystem reading from base and writing to memory
+func NewDryRunFileSystem(base FileSystem) *DryRunFileSystem {
+	return &DryRunFileSystem{base: base, written: NewMemoryFileSystem()}
+}
+
+func (d *DryRunFileSystem) ReadFile(path string) ([]byte, error) {
+	if data, ok := d.written.GetFile(path); ok {
+		return data, nil
+	}
+	return d.base.ReadFile(path)
+}
+
+func (d *DryRunFileSystem) WriteFile(path string, data []byte, perm os.FileMode) error {
+	return d.written.WriteFile(path, data, perm)
+}
+
+func (d *DryRunFileSystem) MkdirAll(path string, perm os.FileMode) error {
+	return d.written.MkdirAll(path, perm)
+}
+
+func (d *DryRunFileSystem) Stat(path string) (os.FileInfo, error) {
+	if info, err := d.written.Stat(path); err == nil {
+		return info, nil
+	}
+	return d.base.Stat(path)
+}
+
+func (d *DryRunFileSystem) Walk(root string, fn filepath.WalkFunc) error {
+	return d.base.Walk(root, fn)
+}
+
+// Written returns the sorted paths of the files written so far
+func (d *DryRunFileSystem) Written() []string {
+	paths := make([]string, 0, len(d.written.files))
+	for path := range d.written.files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Reset forgets the files and directories written so far
+func (d *DryRunFileSystem) Reset() {
+	d.written = NewMemoryFileSystem()
+}
@@ -0,0 +1,49 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestDryRunFileSystem(t *testing.T) {
+	dir := t.TempDir()
+	sourcePath := filepath.Join(dir, "source.md")
+	if err := os.WriteFile(sourcePath, []byte("# Source"), 0644); err != nil {
+		t.Fatalf("failed to write source: %v", err)
+	}
+	fs := NewDryRunFileSystem(NewOSFileSystem())
+
+	data, err := fs.ReadFile(sourcePath)
+	if err != nil || string(data) != "# Source" {
+		t.Fatalf("ReadFile() = %q, %v, want the base file", data, err)
+	}
+
+	outPath := filepath.Join(dir, "build", "page.html")
+	if err := fs.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		t.Fatalf("MkdirAll() unexpected error: %v", err)
+	}
+	if err := fs.WriteFile(outPath, []byte("<html></html>"), 0644); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(outPath)); !os.IsNotExist(err) {
+		t.Errorf("expected nothing written to the base filesystem, got %v", err)
+	}
+
+	data, err = fs.ReadFile(outPath)
+	if err != nil || string(data) != "<html></html>" {
+		t.Errorf("ReadFile() = %q, %v, want the written file", data, err)
+	}
+	if info, err := fs.Stat(outPath); err != nil || info.IsDir() {
+		t.Errorf("Stat() = %v, %v, want the written file", info, err)
+	}
+	if got, want := fs.Written(), []string{outPath}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Written() = %v, want %v", got, want)
+	}
+
+	fs.Reset()
+	if got := fs.Written(); len(got) != 0 {
+		t.Errorf("Written() after Reset() = %v, want none", got)
+	}
+}
@@ -5,6 +5,13 @@ import (
 	"path/filepath"
 )
 
+const (
+	// DefaultFileMode is the permissions of the files written by the generators.
+	DefaultFileMode os.FileMode = 0644
+	// DefaultDirMode is the permissions of the directories created by the generators.
+	DefaultDirMode os.FileMode = 0755
+)
+
 // FileSystem abstracts file operations for testing
 type FileSystem interface {
 	ReadFile(path string) ([]byte, error)
@@ -21,12 +28,36 @@ func (OSFileSystem) ReadFile(path string) ([]byte, error) {
 	return os.ReadFile(path)
 }
 
+// WriteFile writes data to path and sets its permissions to perm, regardless of the umask or an existing file.
 func (OSFileSystem) WriteFile(path string, data []byte, perm os.FileMode) error {
-	return os.WriteFile(path, data, perm)
+	if err := os.WriteFile(path, data, perm); err != nil {
+		return err
+	}
+	return os.Chmod(path, perm)
 }
 
+// MkdirAll creates path along with its missing parents, all of them with perm permissions regardless of the umask.
+// Existing directories are left untouched.
 func (OSFileSystem) MkdirAll(path string, perm os.FileMode) error {
-	return os.MkdirAll(path, perm)
+	var missing []string
+	for dir := filepath.Clean(path); ; dir = filepath.Dir(dir) {
+		if _, err := os.Stat(dir); err == nil {
+			break
+		}
+		missing = append(missing, dir)
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	if err := os.MkdirAll(path, perm); err != nil {
+		return err
+	}
+	for _, dir := range missing {
+		if err := os.Chmod(dir, perm); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (OSFileSystem) Stat(path string) (os.FileInfo, error) {
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 )
 
@@ -12,6 +13,7 @@ import (
 type MemoryFileSystem struct {
 	files        map[string][]byte
 	dirs         map[string]bool
+	modTimes     map[string]time.Time
 	MkdirAllErr  error
 	WriteFileErr error
 	StatErr      error
@@ -19,22 +21,24 @@ type MemoryFileSystem struct {
 
 // memFileInfo is a minimal os.FileInfo implementation for MemoryFileSystem
 type memFileInfo struct {
-	name  string
-	size  int64
-	isDir bool
+	name    string
+	size    int64
+	isDir   bool
+	modTime time.Time
 }
 
 func (fi memFileInfo) Name() string       { return fi.name }
 func (fi memFileInfo) Size() int64        { return fi.size }
 func (fi memFileInfo) Mode() os.FileMode  { return 0644 }
-func (fi memFileInfo) ModTime() time.Time { return time.Time{} }
+func (fi memFileInfo) ModTime() time.Time { return fi.modTime }
 func (fi memFileInfo) IsDir() bool        { return fi.isDir }
 func (fi memFileInfo) Sys() any           { return nil }
 
 func NewMemoryFileSystem() *MemoryFileSystem {
 	return &MemoryFileSystem{
-		files: make(map[string][]byte),
-		dirs:  make(map[string]bool),
+		files:    make(map[string][]byte),
+		dirs:     make(map[string]bool),
+		modTimes: make(map[string]time.Time),
 	}
 }
 
@@ -67,9 +71,9 @@ func (m *MemoryFileSystem) Stat(path string) (os.FileInfo, error) {
 		return nil, m.StatErr
 	}
 	if _, ok := m.files[path]; ok {
-		return memFileInfo{name: filepath.Base(path), size: int64(len(m.files[path])), isDir: false}, nil
+		return memFileInfo{name: filepath.Base(path), size: int64(len(m.files[path])), isDir: false, modTime: m.modTimes[path]}, nil
 	}
-	if m.dirs[path] {
+	if m.isDir(path) {
 		return memFileInfo{name: filepath.Base(path), isDir: true}, nil
 	}
 	return nil, fmt.Errorf("stat %s: no such file or directory", path)
@@ -82,6 +86,10 @@ func (m *MemoryFileSystem) Walk(root string, fn filepath.WalkFunc) error {
 	for path := range m.files {
 		if path == root || isUnder(path, root) {
 			paths = append(paths, path)
+			// Parent directories of files exist without being created
+			for dir := filepath.Dir(path); dir != root && dir != filepath.Dir(dir) && isUnder(dir, root); dir = filepath.Dir(dir) {
+				paths = append(paths, dir)
+			}
 		}
 	}
 	for path := range m.dirs {
@@ -100,7 +108,12 @@ func (m *MemoryFileSystem) Walk(root string, fn filepath.WalkFunc) error {
 	}
 	sort.Strings(unique)
 
+	// skipped is the directory whose remaining paths are skipped, following filepath.SkipDir
+	skipped := ""
 	for _, path := range unique {
+		if skipped != "" && isUnder(path, skipped) {
+			continue
+		}
 		info, err := m.Stat(path)
 		if err != nil {
 			if walkErr := fn(path, nil, err); walkErr != nil {
@@ -108,13 +121,36 @@ func (m *MemoryFileSystem) Walk(root string, fn filepath.WalkFunc) error {
 			}
 			continue
 		}
-		if err := fn(path, info, nil); err != nil {
+		err = fn(path, info, nil)
+		switch {
+		case err == filepath.SkipAll:
+			return nil
+		case err == filepath.SkipDir && path == root:
+			return nil
+		case err == filepath.SkipDir && info.IsDir():
+			skipped = path
+		case err == filepath.SkipDir:
+			skipped = filepath.Dir(path)
+		case err != nil:
 			return err
 		}
 	}
 	return nil
 }
 
+// isDir reports whether path is a directory created with MkdirAll or holding files
+func (m *MemoryFileSystem) isDir(path string) bool {
+	if m.dirs[path] {
+		return true
+	}
+	for file := range m.files {
+		if isUnder(file, path) && file != path {
+			return true
+		}
+	}
+	return false
+}
+
 // isUnder reports whether path is under root (i.e., root is a prefix component)
 func isUnder(path, root string) bool {
 	if root == "." || root == "" {
@@ -124,7 +160,7 @@ func isUnder(path, root string) bool {
 	if err != nil {
 		return false
 	}
-	return rel != ".." && len(rel) >= 2 || (len(rel) > 0 && rel[0] != '.')
+	return rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
 }
 
 // AddFile is a test helper to pre-populate files
@@ -137,3 +173,8 @@ func (m *MemoryFileSystem) GetFile(path string) ([]byte, bool) {
 	data, ok := m.files[path]
 	return data, ok
 }
+
+// SetModTime is a test helper to set the modification time reported by Stat
+func (m *MemoryFileSystem) SetModTime(path string, modTime time.Time) {
+	m.modTimes[path] = modTime
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestNewMemoryFileSystem(t *testing.T) {
@@ -111,6 +112,21 @@ func TestMemoryFileSystem_GetFile(t *testing.T) {
 	})
 }
 
+func TestMemoryFileSystem_SetModTime(t *testing.T) {
+	fs := NewMemoryFileSystem()
+	fs.AddFile("/test.md", []byte("# Title"))
+	modTime := time.Date(2026, 1, 24, 10, 0, 0, 0, time.UTC)
+	fs.SetModTime("/test.md", modTime)
+
+	info, err := fs.Stat("/test.md")
+	if err != nil {
+		t.Fatalf("Stat() unexpected error: %v", err)
+	}
+	if !info.ModTime().Equal(modTime) {
+		t.Errorf("ModTime() = %v, want %v", info.ModTime(), modTime)
+	}
+}
+
 func TestMemoryFileSystem_ImplementsFileSystem(t *testing.T) {
 	// Compile-time check that MemoryFileSystem implements FileSystem
 	var _ FileSystem = (*MemoryFileSystem)(nil)
@@ -161,6 +177,44 @@ func TestOSFileSystem_RoundTrip(t *testing.T) {
 	}
 }
 
+func TestOSFileSystem_Modes(t *testing.T) {
+	fs := NewOSFileSystem()
+	dir := t.TempDir()
+
+	subDir := filepath.Join(dir, "sub", "dir")
+	if err := fs.MkdirAll(subDir, 0775); err != nil {
+		t.Fatalf("MkdirAll() unexpected error: %v", err)
+	}
+	for _, d := range []string{filepath.Join(dir, "sub"), subDir} {
+		info, err := os.Stat(d)
+		if err != nil {
+			t.Fatalf("created directory does not exist: %v", err)
+		}
+		if info.Mode().Perm() != 0775 {
+			t.Errorf("MkdirAll() mode of %s = %v, want %v", d, info.Mode().Perm(), os.FileMode(0775))
+		}
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() == 0775 {
+		t.Error("MkdirAll() should not change the mode of existing directories")
+	}
+
+	filePath := filepath.Join(subDir, "test.txt")
+	if err := fs.WriteFile(filePath, []byte("hello"), 0664); err != nil {
+		t.Fatalf("WriteFile() unexpected error: %v", err)
+	}
+	info, err = os.Stat(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0664 {
+		t.Errorf("WriteFile() mode = %v, want %v", info.Mode().Perm(), os.FileMode(0664))
+	}
+}
+
 func TestOSFileSystem_ReadFile_NotFound(t *testing.T) {
 	fs := NewOSFileSystem()
 	_, err := fs.ReadFile("/nonexistent/path/file.txt")
@@ -168,3 +222,36 @@ func TestOSFileSystem_ReadFile_NotFound(t *testing.T) {
 		t.Error("ReadFile() expected error for nonexistent file, got nil")
 	}
 }
+
+func TestMemoryFileSystem_Walk(t *testing.T) {
+	fs := NewMemoryFileSystem()
+	fs.AddFile("/content/index.md", []byte("# Home"))
+	fs.AddFile("/content/posts/2024/first.md", []byte("# First"))
+	fs.AddFile("/other/page.md", []byte("# Other"))
+
+	var walked []string
+	err := fs.Walk("/content", func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			path += "/"
+		}
+		walked = append(walked, path)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Walk() unexpected error: %v", err)
+	}
+
+	want := []string{"/content/", "/content/index.md", "/content/posts/", "/content/posts/2024/", "/content/posts/2024/first.md"}
+	if len(walked) != len(want) {
+		t.Fatalf("Walk() visited %v, want %v", walked, want)
+	}
+	for i := range want {
+		if walked[i] != want[i] {
+			t.Errorf("Walk() visited %v, want %v", walked, want)
+			break
+		}
+	}
+}
@@ -4,18 +4,135 @@ import (
 	_ "embed"
 
 	"fmt"
+	"log/slog"
+	"os"
 	"path/filepath"
 
 	"github.com/tjnvr/blog/internal/generator/page/filesystem"
 	htmlsubstitution "github.com/tjnvr/blog/internal/generator/page/html/substitution"
 	"github.com/tjnvr/blog/internal/generator/page/html/validation"
 	"github.com/tjnvr/blog/internal/generator/page/markdown"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
 	mdsubstitution "github.com/tjnvr/blog/internal/generator/page/markdown/substitution"
 )
 
 //go:embed page.html
 var defaultTemplate string
 
+// Option configures a page Generator
+type Option func(*Generator)
+
+// WithTemplate returns an Option that projects the page in tmpl instead of the embedded template.
+// An empty tmpl keeps the embedded template.
+func WithTemplate(tmpl string) Option {
+	return func(g *Generator) {
+		if tmpl != "" {
+			g.htmlPageTemplate = tmpl
+		}
+	}
+}
+
+// WithSyntaxTheme returns an Option that sets the chroma style highlighting fenced code blocks.
+func WithSyntaxTheme(name string) Option {
+	return func(g *Generator) {
+		g.converterOptions = append(g.converterOptions, markdown.WithSyntaxTheme(name))
+	}
+}
+
+// WithMath returns an Option that renders the LaTeX math of the page for client-side renderers.
+func WithMath(enabled bool) Option {
+	return func(g *Generator) {
+		g.converterOptions = append(g.converterOptions, markdown.WithMath(enabled))
+	}
+}
+
+// WithElementAttributes returns an Option that sets attributes on the elements converted from markdown.
+func WithElementAttributes(attributes markdown.ElementAttributes) Option {
+	return func(g *Generator) {
+		g.converterOptions = append(g.converterOptions, markdown.WithElementAttributes(attributes))
+	}
+}
+
+// WithLazyImages returns an Option that defers loading and decoding the images of the page, unless set inline.
+func WithLazyImages(enabled bool) Option {
+	return func(g *Generator) {
+		g.converterOptions = append(g.converterOptions, markdown.WithLazyImages(enabled))
+	}
+}
+
+// WithExternalLinks returns an Option that sets rel="noopener noreferrer" on the links to other hosts than siteHost,
+// and target="_blank" when newTab is set.
+func WithExternalLinks(siteHost string, newTab bool) Option {
+	return func(g *Generator) {
+		g.converterOptions = append(g.converterOptions, markdown.WithExternalLinks(siteHost, newTab))
+	}
+}
+
+// WithMinify returns an Option that minifies the generated HTML before writing it.
+func WithMinify(enabled bool) Option {
+	return func(g *Generator) { g.minify = enabled }
+}
+
+// WithImageDimensions returns an Option that sets the width and height of the local images of the page
+// from their file in the build directory, to avoid layout shifts while they load.
+func WithImageDimensions(enabled bool) Option {
+	return func(g *Generator) { g.imageDimensions = enabled }
+}
+
+// WithConverter returns an Option that converts the markdown with converter, e.g. to share it between the pages,
+// instead of a converter built from the other options.
+func WithConverter(converter *markdown.Converter) Option {
+	return func(g *Generator) { g.converter = converter }
+}
+
+// WithRewrite returns an Option that rewrites the generated HTML with fn before writing it, e.g. to update URLs.
+// A nil fn is ignored.
+func WithRewrite(fn func(content string) string) Option {
+	return func(g *Generator) {
+		if fn != nil {
+			g.rewrites = append(g.rewrites, fn)
+		}
+	}
+}
+
+// WithSource returns an Option that builds the page from the given markdown instead of reading the markdown source,
+// e.g. for pages generated by the site. A nil source is ignored.
+func WithSource(source []byte) Option {
+	return func(g *Generator) {
+		if source != nil {
+			g.source = source
+		}
+	}
+}
+
+// WithLogger returns an Option that sets the logger reporting the generated page at debug level.
+// A nil logger is ignored.
+func WithLogger(logger *slog.Logger) Option {
+	return func(g *Generator) {
+		if logger != nil {
+			g.logger = logger
+		}
+	}
+}
+
+// WithFileMode returns an Option that sets the permissions of the written page. A zero mode is ignored.
+func WithFileMode(mode os.FileMode) Option {
+	return func(g *Generator) {
+		if mode != 0 {
+			g.fileMode = mode
+		}
+	}
+}
+
+// WithDirMode returns an Option that sets the permissions of the directories created for the page. A zero mode is ignored.
+func WithDirMode(mode os.FileMode) Option {
+	return func(g *Generator) {
+		if mode != 0 {
+			g.dirMode = mode
+		}
+	}
+}
+
 type Generator struct {
 	htmlPageTemplate      string
 	sourceMDPath          string
@@ -27,6 +144,15 @@ type Generator struct {
 	markdownSubstitutions *mdsubstitution.Registry
 	HTMLSubstitutions     *htmlsubstitution.Registry
 	validations           *validation.Registry
+	converterOptions      []markdown.Option
+	converter             *markdown.Converter
+	minify                bool
+	imageDimensions       bool
+	rewrites              []func(content string) string
+	source                []byte
+	logger                *slog.Logger
+	fileMode              os.FileMode
+	dirMode               os.FileMode
 }
 
 func NewGenerator(
@@ -38,8 +164,9 @@ func NewGenerator(
 	markdownSubstitutions *mdsubstitution.Registry,
 	HTMLSubstitutions *htmlsubstitution.Registry,
 	validations *validation.Registry,
+	opts ...Option,
 ) *Generator {
-	return &Generator{
+	g := &Generator{
 		htmlPageTemplate:      defaultTemplate,
 		sourceMDPath:          markdownSourcePath,
 		destinationHTMLPath:   htmlOutputPath,
@@ -49,15 +176,31 @@ func NewGenerator(
 		markdownSubstitutions: markdownSubstitutions,
 		HTMLSubstitutions:     HTMLSubstitutions,
 		validations:           validations,
+		logger:                slog.New(slog.DiscardHandler),
+		fileMode:              filesystem.DefaultFileMode,
+		dirMode:               filesystem.DefaultDirMode,
+	}
+	for _, opt := range opts {
+		opt(g)
 	}
+	return g
 }
 
 // Generate generates an html page by projecting the markdown file in the HTML template.
 func (g *Generator) Generate() error {
 	// Read markdown file
-	markdDownSourceContent, err := g.fs.ReadFile(g.sourceMDPath)
+	markdDownSourceContent := g.source
+	if markdDownSourceContent == nil {
+		var err error
+		if markdDownSourceContent, err = g.fs.ReadFile(g.sourceMDPath); err != nil {
+			return fmt.Errorf("reading %s: %w", g.sourceMDPath, err)
+		}
+	}
+
+	// Split front matter from the markdown to convert
+	fm, markdDownSourceContent, err := frontmatter.Parse(markdDownSourceContent)
 	if err != nil {
-		return fmt.Errorf("reading %s: %w", g.sourceMDPath, err)
+		return fmt.Errorf("parsing front matter of %s: %w", g.sourceMDPath, err)
 	}
 
 	// Apply needed substitutions and generation in mardkdown
@@ -67,29 +210,46 @@ func (g *Generator) Generate() error {
 	}
 
 	// Convert marddown to HTML
-	htmlContent, err := markdown.NewConverter().Convert([]byte(markdDownStringSourceContent))
+	converter := g.converter
+	if converter == nil {
+		converter = markdown.NewConverter(g.converterOptions...)
+	}
+	htmlContent, err := converter.Convert([]byte(markdDownStringSourceContent))
 	if err != nil {
 		return fmt.Errorf("failed to convert markdown content: %w", err)
 	}
 
 	// Project result inside the page template
-	htmlContent, err = g.HTMLSubstitutions.Apply(g.htmlPageTemplate, htmlContent)
+	htmlContent, err = g.HTMLSubstitutions.Apply(g.htmlPageTemplate, htmlContent, fm)
 	if err != nil {
 		return fmt.Errorf("failed to project content inside the page template: %w", err)
 	}
 
+	for _, rewrite := range g.rewrites {
+		htmlContent = rewrite(htmlContent)
+	}
+
+	// After the rewrites, images are referenced at their path in the build directory, e.g. fingerprinted
+	if g.imageDimensions {
+		htmlContent = g.addImageDimensions(htmlContent)
+	}
+
+	if g.minify {
+		htmlContent = minifyHTML(htmlContent)
+	}
+
 	// Ensure output directory exists
-	if err := g.fs.MkdirAll(filepath.Dir(g.destinationHTMLPath), 0755); err != nil {
+	if err := g.fs.MkdirAll(filepath.Dir(g.destinationHTMLPath), g.dirMode); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
 	// Write HTML file
 	htmlContentBytes := []byte(htmlContent)
-	if err := g.fs.WriteFile(g.destinationHTMLPath, htmlContentBytes, 0644); err != nil {
+	if err := g.fs.WriteFile(g.destinationHTMLPath, htmlContentBytes, g.fileMode); err != nil {
 		return fmt.Errorf("failed to write %s: %w", g.destinationHTMLPath, err)
 	}
 
-	fmt.Printf("Generated: %s -> %s\n", g.sourceMDPath, g.destinationHTMLPath)
+	g.logger.Debug("generated", "source", g.sourceMDPath, "output", g.destinationHTMLPath)
 	g.htmlContentBytes = htmlContentBytes
 	return nil
 }
@@ -97,3 +257,13 @@ func (g *Generator) Generate() error {
 func (g *Generator) Validate() error {
 	return g.validations.Validate(g.destinationHTMLPath, g.buildDir, g.htmlContentBytes)
 }
+
+// Load reads a previously generated page so that it can be validated without being generated again.
+func (g *Generator) Load() error {
+	htmlContentBytes, err := g.fs.ReadFile(g.destinationHTMLPath)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", g.destinationHTMLPath, err)
+	}
+	g.htmlContentBytes = htmlContentBytes
+	return nil
+}
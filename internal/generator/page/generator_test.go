@@ -1,7 +1,9 @@
 package page
 
 import (
+	"bytes"
 	"fmt"
+	"log/slog"
 	"strings"
 	"testing"
 
@@ -132,6 +134,189 @@ func TestGenerator_Generate(t *testing.T) {
 	})
 }
 
+func TestGenerator_Generate_Frontmatter(t *testing.T) {
+	t.Run("front matter is stripped from the converted content", func(t *testing.T) {
+		fs := filesystem.NewMemoryFileSystem()
+		fs.AddFile("/content/post.md", []byte("---\ntitle: Front Matter Title\ndate: 2026-01-24\ntags: [go]\n---\n# Heading\n\nBody text.\n"))
+
+		g := newTestGenerator(t, "/content/post.md", "/build/post.html", "/build", "", fs)
+		if err := g.Generate(); err != nil {
+			t.Fatalf("Generate() unexpected error: %v", err)
+		}
+
+		output, _ := fs.GetFile("/build/post.html")
+		html := string(output)
+		if strings.Contains(html, "tags:") || strings.Contains(html, "<hr>") {
+			t.Errorf("front matter should not leak into the page, got %q", html)
+		}
+		if !strings.Contains(html, "Body text.") {
+			t.Errorf("output should contain content, got %q", html)
+		}
+		if !strings.Contains(html, "<title>Front Matter Title</title>") {
+			t.Errorf("front matter title should be exposed to substitutions, got %q", html)
+		}
+	})
+
+	t.Run("unterminated front matter is an error", func(t *testing.T) {
+		fs := filesystem.NewMemoryFileSystem()
+		fs.AddFile("/content/post.md", []byte("---\ntitle: Oops\n# Heading\n"))
+
+		g := newTestGenerator(t, "/content/post.md", "/build/post.html", "/build", "", fs)
+		err := g.Generate()
+		if err == nil {
+			t.Fatal("Generate() expected error for unterminated front matter, got nil")
+		}
+		if !strings.Contains(err.Error(), "front matter") {
+			t.Errorf("error should mention front matter, got %q", err.Error())
+		}
+	})
+}
+
+func TestGenerator_Generate_WithTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		contains string
+	}{
+		{
+			name:     "custom template resolves placeholders",
+			template: `<article class="post">{{title}}|{{content}}</article>`,
+			contains: `<article class="post">Hello|<h1 id="hello">`,
+		},
+		{
+			name:     "empty template keeps the embedded one",
+			template: "",
+			contains: "<!DOCTYPE html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := filesystem.NewMemoryFileSystem()
+			fs.AddFile("/content/page.md", []byte("# Hello\n"))
+
+			g := NewGenerator("/content/page.md", "/build/page.html", "/build", "",
+				fs,
+				mdsubstitution.NewRegistry("/content/page.md"),
+				htmlsubstitution.NewRegistry("/build/page.html", "/content/page.md", nil, nil, nil, ""),
+				validation.NewRegistry(nil, false),
+				WithTemplate(tt.template),
+			)
+			if err := g.Generate(); err != nil {
+				t.Fatalf("Generate() unexpected error: %v", err)
+			}
+
+			output, _ := fs.GetFile("/build/page.html")
+			if !strings.Contains(string(output), tt.contains) {
+				t.Errorf("output should contain %q, got %q", tt.contains, string(output))
+			}
+		})
+	}
+}
+
+func TestGenerator_Generate_WithMinify(t *testing.T) {
+	const source = "# Hello\n\nSome text.\n\n```\nfunc main() {\n\tfmt.Println(\"hi\")\n}\n```\n"
+	const pre = "<pre><code>func main() {\n\tfmt.Println(&quot;hi&quot;)\n}\n</code></pre>"
+
+	generate := func(t *testing.T, minify bool) string {
+		t.Helper()
+		fs := filesystem.NewMemoryFileSystem()
+		fs.AddFile("/content/page.md", []byte(source))
+
+		g := NewGenerator("/content/page.md", "/build/page.html", "/build", "",
+			fs,
+			mdsubstitution.NewRegistry("/content/page.md"),
+			htmlsubstitution.NewRegistry("/build/page.html", "/content/page.md", nil, nil, nil, ""),
+			validation.NewRegistry(nil, false),
+			WithMinify(minify),
+		)
+		if err := g.Generate(); err != nil {
+			t.Fatalf("Generate() unexpected error: %v", err)
+		}
+		output, _ := fs.GetFile("/build/page.html")
+		return string(output)
+	}
+
+	plain := generate(t, false)
+	minified := generate(t, true)
+
+	if len(minified) >= len(plain) {
+		t.Errorf("minified output should be smaller, got %d bytes, non-minified %d bytes", len(minified), len(plain))
+	}
+	for name, output := range map[string]string{"plain": plain, "minified": minified} {
+		if !strings.Contains(output, pre) {
+			t.Errorf("%s output should keep the code block as is %q, got %q", name, pre, output)
+		}
+	}
+}
+
+func TestGenerator_Generate_WithRewrite(t *testing.T) {
+	fs := filesystem.NewMemoryFileSystem()
+	fs.AddFile("/content/page.md", []byte("# Hello\n"))
+
+	g := NewGenerator("/content/page.md", "/build/page.html", "/build", "",
+		fs,
+		mdsubstitution.NewRegistry("/content/page.md"),
+		htmlsubstitution.NewRegistry("/build/page.html", "/content/page.md", nil, nil, nil, ""),
+		validation.NewRegistry(nil, false),
+		WithTemplate("<main>{{content}}</main>"),
+		WithRewrite(func(content string) string { return strings.ReplaceAll(content, "main>", "section>") }),
+		WithRewrite(nil),
+	)
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+
+	output, _ := fs.GetFile("/build/page.html")
+	if !strings.HasPrefix(string(output), "<section>") || !strings.HasSuffix(string(output), "</section>") {
+		t.Errorf("output should be rewritten, got %q", string(output))
+	}
+}
+
+func TestGenerator_Generate_WithSource(t *testing.T) {
+	fs := filesystem.NewMemoryFileSystem()
+
+	g := NewGenerator("/content/tags/go.md", "/build/tags/go.html", "/build", "tags",
+		fs,
+		mdsubstitution.NewRegistry("/content/tags/go.md"),
+		htmlsubstitution.NewRegistry("/build/tags/go.html", "/content/tags/go.md", nil, nil, nil, "tags"),
+		validation.NewRegistry(nil, false),
+		WithTemplate("{{title}}|{{content}}"),
+		WithSource([]byte("# Go\n")),
+	)
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+
+	output, _ := fs.GetFile("/build/tags/go.html")
+	if !strings.HasPrefix(string(output), "Go|<h1") {
+		t.Errorf("output should be generated from the source, got %q", string(output))
+	}
+}
+
+func TestGenerator_Generate_WithLogger(t *testing.T) {
+	fs := filesystem.NewMemoryFileSystem()
+	fs.AddFile("/content/page.md", []byte("# Title\n"))
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	g := NewGenerator("/content/page.md", "/build/page.html", "/build", "",
+		fs,
+		mdsubstitution.NewRegistry("/content/page.md"),
+		htmlsubstitution.NewRegistry("/build/page.html", "/content/page.md", nil, nil, nil, ""),
+		validation.NewRegistry(nil, false),
+		WithLogger(logger),
+	)
+	if err := g.Generate(); err != nil {
+		t.Fatalf("Generate() unexpected error: %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "msg=generated source=/content/page.md output=/build/page.html") {
+		t.Errorf("generated page should be logged, got %q", buf.String())
+	}
+}
+
 func TestGenerator_Generate_MkdirAllError(t *testing.T) {
 	fs := filesystem.NewMemoryFileSystem()
 	fs.AddFile("/content/page.md", []byte("# Title\n\nContent."))
@@ -316,3 +501,26 @@ func TestGenerator_Validate(t *testing.T) {
 		}
 	})
 }
+
+func TestGenerator_Load(t *testing.T) {
+	t.Run("loads the previously generated page", func(t *testing.T) {
+		fs := filesystem.NewMemoryFileSystem()
+		fs.AddFile("/build/page.html", []byte("<html>previous</html>"))
+
+		g := newTestGenerator(t, "/content/page.md", "/build/page.html", "/build", "", fs)
+		if err := g.Load(); err != nil {
+			t.Fatalf("Load() unexpected error: %v", err)
+		}
+		if string(g.htmlContentBytes) != "<html>previous</html>" {
+			t.Errorf("htmlContentBytes = %q, want previous output", g.htmlContentBytes)
+		}
+	})
+
+	t.Run("returns error when the page was never generated", func(t *testing.T) {
+		fs := filesystem.NewMemoryFileSystem()
+		g := newTestGenerator(t, "/content/page.md", "/build/page.html", "/build", "", fs)
+		if err := g.Load(); err == nil {
+			t.Fatal("Load() expected error for missing output, got nil")
+		}
+	})
+}
@@ -0,0 +1,32 @@
+// Package bodyclass resolves the {{body_class}} placeholder with a class naming the section of the page.
+package bodyclass
+
+import "strings"
+
+// Substituter resolves the {{body_class}} placeholder with the class of the section of the page,
+// e.g. section-posts, to scope styles to a section, as in <body class="{{body_class}}">.
+type Substituter struct {
+	currentSection string
+}
+
+// NewSubstituer creates a substituter of the class of currentSection, the home section when empty.
+func NewSubstituer(currentSection string) Substituter {
+	return Substituter{currentSection: currentSection}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{body_class}}"
+}
+
+func (s Substituter) Resolve(_ string) (string, error) {
+	return Class(s.currentSection), nil
+}
+
+// Class returns the class of a section: section-home for the home section, section- followed by its directory
+// with the slashes of nested sections replaced with dashes otherwise, e.g. section-blog-2024 for blog/2024.
+func Class(section string) string {
+	if section == "" {
+		return "section-home"
+	}
+	return "section-" + strings.ReplaceAll(strings.Trim(section, "/"), "/", "-")
+}
@@ -0,0 +1,32 @@
+package bodyclass
+
+import "testing"
+
+func TestSubstituer_Placeholder(t *testing.T) {
+	if got := NewSubstituer("").Placeholder(); got != "{{body_class}}" {
+		t.Errorf("Placeholder() = %q, want %q", got, "{{body_class}}")
+	}
+}
+
+func TestSubstituer_Resolve(t *testing.T) {
+	tests := []struct {
+		section string
+		want    string
+	}{
+		{section: "", want: "section-home"},
+		{section: "posts", want: "section-posts"},
+		{section: "blog/2024", want: "section-blog-2024"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			got, err := NewSubstituer(tt.section).Resolve("")
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
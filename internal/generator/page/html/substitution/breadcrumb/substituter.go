@@ -0,0 +1,123 @@
+// Package breadcrumb resolves the {{breadcrumb}} placeholder with the links from the home page down to the current page.
+package breadcrumb
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/navigation"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/title"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+	"github.com/tjnvr/blog/internal/generator/section"
+)
+
+// Substituter resolves the {{breadcrumb}} placeholder with a <nav aria-label="breadcrumb"> linking to the home page
+// and to each section above the page, e.g. Home / Posts / My Article.
+// The home page has no breadcrumb.
+type Substituter struct {
+	sections       []section.Section
+	currentSection string
+	homeLabel      string
+	isIndex        bool
+	depth          int
+}
+
+// NewSubstituer creates a breadcrumb substituter for the page generated at htmlPath in currentSection.
+// Section labels are the display names of sections. homeLabel is shown for the home link;
+// when empty the home section display name is used.
+func NewSubstituer(htmlPath string, sections []section.Section, currentSection, homeLabel string) Substituter {
+	return Substituter{
+		sections:       sections,
+		currentSection: currentSection,
+		homeLabel:      homeLabel,
+		isIndex:        filepath.Base(htmlPath) == "index.html",
+	}
+}
+
+// WithDepth returns a copy of b for a page generated depth directories below its section directory,
+// e.g. a page output in a directory of its own, which is not a section index page then.
+func (b Substituter) WithDepth(depth int) Substituter {
+	b.depth = depth
+	b.isIndex = b.isIndex && depth == 0
+	return b
+}
+
+func (b Substituter) Placeholder() string {
+	return "{{breadcrumb}}"
+}
+
+func (b Substituter) Resolve(content string) (string, error) {
+	return b.ResolveFrontmatter(content, frontmatter.Frontmatter{})
+}
+
+// ResolveFrontmatter ends the breadcrumb with the page title, preferring the front matter title over the page <h1>.
+// Section index pages end with their section instead.
+func (b Substituter) ResolveFrontmatter(content string, fm frontmatter.Frontmatter) (string, error) {
+	if b.currentSection == "" && b.isIndex {
+		return "", nil
+	}
+
+	type crumb struct{ href, label string }
+	prefix := navigation.RelativePrefix(b.currentSection) + strings.Repeat("../", b.depth)
+	crumbs := []crumb{{prefix + "index.html", b.homeName()}}
+	if b.currentSection != "" {
+		parts := strings.Split(b.currentSection, "/")
+		for i := range parts {
+			dirName := strings.Join(parts[:i+1], "/")
+			crumbs = append(crumbs, crumb{prefix + dirName + "/index.html", b.sectionName(dirName)})
+		}
+	}
+
+	current := ""
+	if !b.isIndex {
+		current, _ = title.NewSubstituer().ResolveFrontmatter(content, fm)
+	}
+	if b.isIndex || current == "" {
+		current = crumbs[len(crumbs)-1].label
+		crumbs = crumbs[:len(crumbs)-1]
+	}
+
+	items := make([]string, 0, len(crumbs)+1)
+	for _, c := range crumbs {
+		items = append(items, fmt.Sprintf(`<li><a href="%s" class="hover:underline">%s</a></li>`, c.href, c.label))
+	}
+	items = append(items, fmt.Sprintf(`<li aria-current="page">%s</li>`, current))
+
+	separator := "\n    " + `<li aria-hidden="true">/</li>` + "\n    "
+	return fmt.Sprintf(`<nav aria-label="breadcrumb"><ol class="flex flex-wrap gap-2 list-none p-0 text-sm">%s</ol></nav>`, strings.Join(items, separator)), nil
+}
+
+// homeName returns the label of the home crumb: the home label when set, the home section display name otherwise.
+func (b Substituter) homeName() string {
+	if b.homeLabel != "" {
+		return b.homeLabel
+	}
+	if s, ok := find(b.sections, ""); ok {
+		return s.DisplayName
+	}
+	return "Home"
+}
+
+// sectionName returns the display name of the section at dirName,
+// or its capitalized directory name when it is not in the navigation.
+func (b Substituter) sectionName(dirName string) string {
+	if s, ok := find(b.sections, dirName); ok {
+		return s.DisplayName
+	}
+	name := filepath.Base(dirName)
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// find returns the section at dirName among sections and their nested sections.
+func find(sections []section.Section, dirName string) (section.Section, bool) {
+	for _, s := range sections {
+		if s.DirName == dirName {
+			return s, true
+		}
+		if found, ok := find(s.Children, dirName); ok {
+			return found, true
+		}
+	}
+	return section.Section{}, false
+}
@@ -0,0 +1,104 @@
+package breadcrumb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+	"github.com/tjnvr/blog/internal/generator/section"
+)
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	s := NewSubstituer("index.html", nil, "", "")
+	if s.Placeholder() != "{{breadcrumb}}" {
+		t.Errorf("Placeholder() = %q, want %q", s.Placeholder(), "{{breadcrumb}}")
+	}
+}
+
+func TestSubstituter_ResolveFrontmatter(t *testing.T) {
+	sections := []section.Section{
+		{DirName: "", DisplayName: "Accueil"},
+		{DirName: "posts", DisplayName: "Posts"},
+		{DirName: "blog", DisplayName: "Blog", Children: []section.Section{
+			{DirName: "blog/2024", DisplayName: "Year 2024"},
+		}},
+	}
+	content := `<h1 id="my-article">My Article</h1><p>Text.</p>`
+
+	tests := []struct {
+		name           string
+		htmlPath       string
+		currentSection string
+		homeLabel      string
+		fm             frontmatter.Frontmatter
+		want           []string
+	}{
+		{
+			name:     "home page has no breadcrumb",
+			htmlPath: "/build/index.html",
+		},
+		{
+			name:     "root page",
+			htmlPath: "/build/contact.html",
+			want: []string{
+				`<li><a href="index.html" class="hover:underline">Accueil</a></li>`,
+				`<li aria-current="page">My Article</li>`,
+			},
+		},
+		{
+			name:           "single section page",
+			htmlPath:       "/build/posts/my-article.html",
+			currentSection: "posts",
+			homeLabel:      "Home",
+			want: []string{
+				`<li><a href="../index.html" class="hover:underline">Home</a></li>`,
+				`<li><a href="../posts/index.html" class="hover:underline">Posts</a></li>`,
+				`<li aria-current="page">My Article</li>`,
+			},
+		},
+		{
+			name:           "section index page ends with its section",
+			htmlPath:       "/build/posts/index.html",
+			currentSection: "posts",
+			want: []string{
+				`<li><a href="../index.html" class="hover:underline">Accueil</a></li>`,
+				`<li aria-current="page">Posts</li>`,
+			},
+		},
+		{
+			name:           "nested section page",
+			htmlPath:       "/build/blog/2024/my-article.html",
+			currentSection: "blog/2024",
+			fm:             frontmatter.Frontmatter{Title: "Front Matter Title"},
+			want: []string{
+				`<li><a href="../../index.html" class="hover:underline">Accueil</a></li>`,
+				`<li><a href="../../blog/index.html" class="hover:underline">Blog</a></li>`,
+				`<li><a href="../../blog/2024/index.html" class="hover:underline">Year 2024</a></li>`,
+				`<li aria-current="page">Front Matter Title</li>`,
+			},
+		},
+		{
+			name:           "section missing from the navigation",
+			htmlPath:       "/build/legal/privacy.html",
+			currentSection: "legal",
+			want: []string{
+				`<li><a href="../legal/index.html" class="hover:underline">Legal</a></li>`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.htmlPath, sections, tt.currentSection, tt.homeLabel).ResolveFrontmatter(content, tt.fm)
+			assert.NoError(t, err)
+			if len(tt.want) == 0 {
+				assert.Empty(t, got)
+				return
+			}
+			assert.Contains(t, got, `<nav aria-label="breadcrumb">`)
+			for _, want := range tt.want {
+				assert.Contains(t, got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+// Package canonical resolves the {{canonical}} placeholder with the canonical link of the page.
+package canonical
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+)
+
+// Substituter resolves the {{canonical}} placeholder with a <link rel="canonical"> tag.
+// The tag is omitted when the page URL is not absolute, as a relative canonical URL would point at every mirror.
+type Substituter struct {
+	pageURL string
+}
+
+// NewSubstituer creates a canonical link substituter for the page served at pageURL,
+// e.g. "https://example.org/posts/" for the index page of the posts section.
+func NewSubstituer(pageURL string) Substituter {
+	return Substituter{pageURL: pageURL}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{canonical}}"
+}
+
+func (s Substituter) Resolve(string) (string, error) {
+	u, err := url.Parse(s.pageURL)
+	if err != nil || !u.IsAbs() {
+		return "", nil
+	}
+	return fmt.Sprintf(`<link rel="canonical" href="%s">`, html.EscapeString(s.pageURL)), nil
+}
@@ -0,0 +1,51 @@
+package canonical
+
+import "testing"
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	s := NewSubstituer("")
+	if s.Placeholder() != "{{canonical}}" {
+		t.Errorf("Placeholder() = %q, want %q", s.Placeholder(), "{{canonical}}")
+	}
+}
+
+func TestSubstituter_Resolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		pageURL string
+		want    string
+	}{
+		{
+			name:    "root page",
+			pageURL: "https://example.org/",
+			want:    `<link rel="canonical" href="https://example.org/">`,
+		},
+		{
+			name:    "nested page",
+			pageURL: "https://example.org/posts/first.html",
+			want:    `<link rel="canonical" href="https://example.org/posts/first.html">`,
+		},
+		{
+			name:    "relative page URL",
+			pageURL: "/posts/first.html",
+			want:    "",
+		},
+		{
+			name:    "no page URL",
+			pageURL: "",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.pageURL).Resolve("<p>content</p>")
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
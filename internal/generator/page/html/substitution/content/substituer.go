@@ -2,9 +2,13 @@ package content
 
 import (
 	"fmt"
+	"net/url"
 	"path/filepath"
 	"regexp"
 	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	"github.com/tjnvr/blog/internal/generator/page/markdown"
 )
 
 type (
@@ -12,35 +16,74 @@ type (
 		GetNewPath(oldPath, fromPath string) (string, error)
 	}
 
+	// RootPathTranslater is implemented by the links PathTranslater resolving the links written from the root
+	// of the content directory, e.g. /about.md, to the root-relative path of their output, e.g. /about.html.
+	RootPathTranslater interface {
+		GetRootPath(rootPath string) (string, error)
+	}
+
 	// Substituter resolves the {{content}} template placeholder
 	// it replaces links and assets with their real path in the build directory
+	// and drops the <!--more--> marker ending the page excerpt
+	// Links to directories, e.g. posts/, are replaced with links to their index page
 	Substituter struct {
 		filePath              string
 		markdownSourcePath    string
 		assetsPathsTranslater PathTranslater
 		linksPathTranslater   PathTranslater
+		fs                    filesystem.FileSystem
+		markdownExtensions    []string
 	}
 )
 
-func NewSubstituer(filePath, markdownSourcePath string, assetsPathsTranslater PathTranslater, linksPathTranslater PathTranslater) Substituter {
+// mdLinkRe matches the href attributes of the links to files with an extension, to find the ones to markdown sources.
+var mdLinkRe = regexp.MustCompile(`(href=")([^"]*\.[a-zA-Z]+)(")`)
+
+// dirLinkRe matches the href attributes of the links, to find the ones to directories.
+var dirLinkRe = regexp.MustCompile(`(href=")([^"]*)(")`)
+
+var (
+	// imgSrcRe matches the src attributes of the images
+	imgSrcRe = regexp.MustCompile(`(<img[^>]+src=")([^"]+)(")`)
+	// srcsetRe matches the srcset attributes, of img and source elements
+	srcsetRe = regexp.MustCompile(`(\ssrcset=")([^"]+)(")`)
+	// styleAttrRe matches the inline style attributes and styleURLRe the url() they reference, quoted or not
+	styleAttrRe = regexp.MustCompile(`\sstyle="[^"]*"`)
+	styleURLRe  = regexp.MustCompile(`(url\(\s*(?:&#39;|&quot;|')?)([^'")&]+)((?:&#39;|&quot;|')?\s*\))`)
+)
+
+// NewSubstituer creates a content substituer. fs tells the links to directories without a trailing slash, e.g. ../about,
+// only links ending with a slash are links to directories when it is nil.
+func NewSubstituer(filePath, markdownSourcePath string, assetsPathsTranslater PathTranslater, linksPathTranslater PathTranslater, fs filesystem.FileSystem) Substituter {
 	return Substituter{
 		filePath:              filePath,
 		markdownSourcePath:    markdownSourcePath,
 		assetsPathsTranslater: assetsPathsTranslater,
 		linksPathTranslater:   linksPathTranslater,
+		fs:                    fs,
 	}
 }
 
+// WithMarkdownExtensions returns a copy of s replacing the links to the files with one of extensions,
+// markdown.DefaultExtensions when empty, with links to their page.
+func (s Substituter) WithMarkdownExtensions(extensions ...string) Substituter {
+	s.markdownExtensions = extensions
+	return s
+}
+
 func (s Substituter) Placeholder() string {
 	return "{{content}}"
 }
 
 func (s Substituter) Resolve(htmlContent string) (string, error) {
+	htmlContent = strings.Replace(htmlContent, markdown.MoreMarker+"\n", "", 1)
+
 	var err error
 	htmlContent, err = s.convertMdLinksPath(htmlContent, s.filePath)
 	if err != nil {
 		return "", fmt.Errorf("s.convertMdLinksPath err: %w", err)
 	}
+	htmlContent = s.convertDirLinksPath(htmlContent, s.filePath)
 	htmlContent, err = s.convertAssetsPath(htmlContent, s.filePath)
 	if err != nil {
 		return "", fmt.Errorf("s.convertAssetsPath err: %w", err)
@@ -49,25 +92,41 @@ func (s Substituter) Resolve(htmlContent string) (string, error) {
 }
 
 func (s Substituter) convertMdLinksPath(html string, filePath string) (string, error) {
-	// Match href attributes pointing to .md files
-	re := regexp.MustCompile(`(href=")([^"]*\.md)(")`)
+	// Match href attributes pointing to markdown files
 	var firstErr error
-	result := re.ReplaceAllStringFunc(html, func(match string) string {
-		submatch := re.FindStringSubmatch(match)
-		if len(submatch) < 4 {
+	result := mdLinkRe.ReplaceAllStringFunc(html, func(match string) string {
+		submatch := mdLinkRe.FindStringSubmatch(match)
+		if len(submatch) < 4 || !markdown.IsSource(submatch[2], s.markdownExtensions) {
 			return match
 		}
 
 		prefix := submatch[1]
 		src := submatch[2]
 
-		// Skip external URLs and absolute paths
-		if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "/") {
+		// Skip external URLs
+		if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "//") {
 			return match
 		}
 
+		// From the content root, rewritten to the root-relative path of the page
+		if strings.HasPrefix(src, "/") {
+			translater, ok := s.linksPathTranslater.(RootPathTranslater)
+			if !ok {
+				return match
+			}
+			// The translater outputs the .md sources
+			newPath, err := translater.GetRootPath(strings.TrimSuffix(src, filepath.Ext(src)) + ".md")
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return match
+			}
+			return fmt.Sprintf(`%s%s"`, prefix, newPath)
+		}
+
 		// From root directory
-		fullOldPath := strings.TrimSuffix(filepath.Join(filepath.Dir(s.markdownSourcePath), src), ".md") + ".html"
+		fullOldPath := strings.TrimSuffix(filepath.Join(filepath.Dir(s.markdownSourcePath), src), filepath.Ext(src)) + ".html"
 
 		newPath, err := s.linksPathTranslater.GetNewPath(fullOldPath, filePath)
 		if err != nil {
@@ -82,35 +141,115 @@ func (s Substituter) convertMdLinksPath(html string, filePath string) (string, e
 	return result, firstErr
 }
 
-func (s Substituter) convertAssetsPath(html string, filePath string) (string, error) {
-	// Match img src attributes with relative paths
-	re := regexp.MustCompile(`(<img[^>]+src=")([^"]+)(")`)
-	var firstErr error
-	result := re.ReplaceAllStringFunc(html, func(match string) string {
-		submatch := re.FindStringSubmatch(match)
-		if len(submatch) < 4 {
+// convertDirLinksPath replaces the relative links to directories of the content with links to their index.html,
+// keeping the query and fragment, e.g. posts/#latest becomes posts/index.html#latest.
+// Links to directories which do not translate, e.g. outside the content directory, are left as they are.
+func (s Substituter) convertDirLinksPath(html string, filePath string) string {
+	return dirLinkRe.ReplaceAllStringFunc(html, func(match string) string {
+		submatch := dirLinkRe.FindStringSubmatch(match)
+		target, suffix := submatch[2], ""
+		if i := strings.IndexAny(target, "?#"); i >= 0 {
+			target, suffix = target[:i], target[i:]
+		}
+		if !s.isDirLink(target) {
 			return match
 		}
 
-		prefix := submatch[1]
-		src := submatch[2]
+		// From root directory
+		fullOldPath := filepath.Join(filepath.Dir(s.markdownSourcePath), target, "index.html")
 
-		// Skip external URLs and absolute paths
-		if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "/") {
+		newPath, err := s.linksPathTranslater.GetNewPath(fullOldPath, filePath)
+		if err != nil {
 			return match
 		}
+		return fmt.Sprintf(`%s%s%s"`, submatch[1], filepath.ToSlash(newPath), suffix)
+	})
+}
 
-		// From root directory
-		fullOldPath := filepath.Join(filepath.Dir(s.markdownSourcePath), src)
+// isDirLink reports whether target, without query or fragment, is a relative link to a directory.
+func (s Substituter) isDirLink(target string) bool {
+	if target == "" || strings.HasPrefix(target, "/") {
+		return false
+	}
+	if u, err := url.Parse(target); err != nil || u.Scheme != "" || u.Host != "" {
+		return false
+	}
+	if strings.HasSuffix(target, "/") || target == "." || target == ".." {
+		return true
+	}
+	if s.fs == nil || filepath.Ext(target) != "" {
+		return false
+	}
+	info, err := s.fs.Stat(filepath.Join(filepath.Dir(s.markdownSourcePath), target))
+	return err == nil && info.IsDir()
+}
 
-		newPath, err := s.assetsPathsTranslater.GetNewPath(fullOldPath, filePath)
+// convertAssetsPath replaces the relative paths of the assets referenced by the img src, the srcset
+// and the url() of the inline styles with their path from the page generated at filePath.
+func (s Substituter) convertAssetsPath(html string, filePath string) (string, error) {
+	var firstErr error
+	convert := func(src string) string {
+		newPath, err := s.convertAssetPath(src, filePath)
 		if err != nil {
 			if firstErr == nil {
 				firstErr = err
 			}
-			return match
+			return src
 		}
-		return fmt.Sprintf(`%s%s"`, prefix, newPath)
+		return newPath
+	}
+
+	// Match img src attributes with relative paths
+	result := imgSrcRe.ReplaceAllStringFunc(html, func(match string) string {
+		submatch := imgSrcRe.FindStringSubmatch(match)
+		return submatch[1] + convert(submatch[2]) + submatch[3]
+	})
+
+	// Each candidate of a srcset is a URL followed by an optional descriptor, e.g. 2x or 800w
+	result = srcsetRe.ReplaceAllStringFunc(result, func(match string) string {
+		submatch := srcsetRe.FindStringSubmatch(match)
+		candidates := strings.Split(submatch[2], ",")
+		for i, candidate := range candidates {
+			fields := strings.Fields(candidate)
+			if len(fields) == 0 {
+				continue
+			}
+			fields[0] = convert(fields[0])
+			candidates[i] = strings.Join(fields, " ")
+		}
+		return submatch[1] + strings.Join(candidates, ", ") + submatch[3]
+	})
+
+	// The url() of inline styles, e.g. style="background-image:url('../images/bg.png')"
+	result = styleAttrRe.ReplaceAllStringFunc(result, func(match string) string {
+		return styleURLRe.ReplaceAllStringFunc(match, func(u string) string {
+			submatch := styleURLRe.FindStringSubmatch(u)
+			return submatch[1] + convert(submatch[2]) + submatch[3]
+		})
 	})
 	return result, firstErr
 }
+
+// convertAssetPath returns the path of the asset referenced by src from the page generated at filePath.
+// External URLs, absolute paths, data URLs and fragments are returned as they are.
+func (s Substituter) convertAssetPath(src, filePath string) (string, error) {
+	if strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://") || strings.HasPrefix(src, "/") ||
+		strings.HasPrefix(src, "data:") || strings.HasPrefix(src, "#") {
+		return src, nil
+	}
+
+	// From root directory
+	fullOldPath := filepath.Join(filepath.Dir(s.markdownSourcePath), src)
+
+	newPath, err := s.assetsPathsTranslater.GetNewPath(fullOldPath, filePath)
+	if err != nil && s.linksPathTranslater != nil {
+		// Images next to the markdown source are copied along the page
+		if colocatedPath, colocatedErr := s.linksPathTranslater.GetNewPath(fullOldPath, filePath); colocatedErr == nil {
+			newPath, err = colocatedPath, nil
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	return newPath, nil
+}
@@ -1,7 +1,13 @@
 package content
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
 )
 
 type mockPathTranslater struct {
@@ -95,6 +101,126 @@ func TestConvertAssetsPath(t *testing.T) {
 	}
 }
 
+// mapPathTranslater translates the paths it maps and fails on the others.
+type mapPathTranslater map[string]string
+
+func (m mapPathTranslater) GetNewPath(oldPath, fromPath string) (string, error) {
+	newPath, ok := m[oldPath]
+	if !ok {
+		return "", fmt.Errorf("%s is not an asset", oldPath)
+	}
+	return newPath, nil
+}
+
+func TestConvertAssetsPath_NonImgReferences(t *testing.T) {
+	translater := mapPathTranslater{
+		"content/images/a.webp":    "../assets/images/a.webp",
+		"content/images/a@2x.webp": "../assets/images/a@2x.webp",
+		"content/images/bg.png":    "../assets/images/bg.png",
+	}
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "rewrites each srcset candidate keeping its descriptor",
+			html: `<picture><source srcset="../images/a.webp 1x, ../images/a@2x.webp 2x"></picture>`,
+			want: `<picture><source srcset="../assets/images/a.webp 1x, ../assets/images/a@2x.webp 2x"></picture>`,
+		},
+		{
+			name: "rewrites a srcset without descriptor",
+			html: `<img srcset="../images/a.webp" alt="">`,
+			want: `<img srcset="../assets/images/a.webp" alt="">`,
+		},
+		{
+			name: "rewrites a quoted style url",
+			html: `<div style="background-image:url('../images/bg.png')"></div>`,
+			want: `<div style="background-image:url('../assets/images/bg.png')"></div>`,
+		},
+		{
+			name: "rewrites an escaped quoted style url",
+			html: `<div style="background-image: url(&#39;../images/bg.png&#39;)"></div>`,
+			want: `<div style="background-image: url(&#39;../assets/images/bg.png&#39;)"></div>`,
+		},
+		{
+			name: "rewrites an unquoted style url",
+			html: `<div style="background:url(../images/bg.png) no-repeat"></div>`,
+			want: `<div style="background:url(../assets/images/bg.png) no-repeat"></div>`,
+		},
+		{
+			name: "skips external, absolute and data urls",
+			html: `<img srcset="https://example.com/a.png 2x, /a.png 3x"><div style="background:url(data:image/png;base64,AAA)"></div>`,
+			want: `<img srcset="https://example.com/a.png 2x, /a.png 3x"><div style="background:url(data:image/png;base64,AAA)"></div>`,
+		},
+		{
+			name: "leaves url text outside of style attributes",
+			html: `<p>url(../images/bg.png)</p>`,
+			want: `<p>url(../images/bg.png)</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Substituter{
+				markdownSourcePath:    "content/posts/page.md",
+				assetsPathsTranslater: translater,
+			}
+			got, err := s.convertAssetsPath(tt.html, "build/posts/page.html")
+			if err != nil {
+				t.Fatalf("convertAssetsPath() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("convertAssetsPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("reports an unknown srcset asset", func(t *testing.T) {
+		s := Substituter{
+			markdownSourcePath:    "content/posts/page.md",
+			assetsPathsTranslater: translater,
+		}
+		if _, err := s.convertAssetsPath(`<source srcset="../images/missing.webp 2x">`, "build/posts/page.html"); err == nil {
+			t.Error("convertAssetsPath() expected an error")
+		}
+	})
+}
+
+type failingPathTranslater struct{}
+
+func (failingPathTranslater) GetNewPath(oldPath, fromPath string) (string, error) {
+	return "", fmt.Errorf("%s is not an asset", oldPath)
+}
+
+func TestConvertAssetsPath_Colocated(t *testing.T) {
+	t.Run("falls back to the content path", func(t *testing.T) {
+		s := Substituter{
+			markdownSourcePath:    "content/posts/page.md",
+			assetsPathsTranslater: failingPathTranslater{},
+			linksPathTranslater:   mockPathTranslater{newPath: "photo.png"},
+		}
+		got, err := s.convertAssetsPath(`<img src="photo.png">`, "build/posts/page.html")
+		if err != nil {
+			t.Fatalf("convertAssetsPath() unexpected error: %v", err)
+		}
+		if got != `<img src="photo.png">` {
+			t.Errorf("convertAssetsPath() = %q, want %q", got, `<img src="photo.png">`)
+		}
+	})
+
+	t.Run("reports the asset error when both fail", func(t *testing.T) {
+		s := Substituter{
+			markdownSourcePath:    "content/posts/page.md",
+			assetsPathsTranslater: failingPathTranslater{},
+			linksPathTranslater:   failingPathTranslater{},
+		}
+		if _, err := s.convertAssetsPath(`<img src="photo.png">`, "build/posts/page.html"); err == nil {
+			t.Error("convertAssetsPath() expected an error")
+		}
+	})
+}
+
 func TestConvertMdLinksPath(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -132,7 +258,7 @@ func TestConvertMdLinksPath(t *testing.T) {
 			want:     `<a href="https://example.com/page.md">External</a>`,
 		},
 		{
-			name:     "skips absolute path",
+			name:     "skips absolute path without root path translater",
 			html:     `<a href="/pages/about.md">About</a>`,
 			filePath: "index.md",
 			newPath:  "should-not-be-used",
@@ -184,3 +310,134 @@ func TestConvertMdLinksPath(t *testing.T) {
 		})
 	}
 }
+
+func TestResolve_DropsMoreMarker(t *testing.T) {
+	s := NewSubstituer("index.md", "index.md", mockPathTranslater{}, mockPathTranslater{}, nil)
+	got, err := s.Resolve("<p>Intro.</p>\n<!--more-->\n<p>Rest.</p>\n")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if want := "<p>Intro.</p>\n<p>Rest.</p>\n"; got != want {
+		t.Errorf("Resolve() = %q, want %q", got, want)
+	}
+}
+
+// relativePathTranslater outputs the files at the same path, relative to the file at fromPath.
+type relativePathTranslater struct{}
+
+func (relativePathTranslater) GetNewPath(oldPath, fromPath string) (string, error) {
+	return filepath.Rel(filepath.Dir(fromPath), oldPath)
+}
+
+func TestConvertDirLinksPath(t *testing.T) {
+	contentDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(contentDir, "about"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		html     string
+		filePath string
+		want     string
+	}{
+		{
+			name:     "section directory",
+			html:     `<a href="posts/">Posts</a>`,
+			filePath: "index.md",
+			want:     `<a href="posts/index.html">Posts</a>`,
+		},
+		{
+			name:     "parent relative directory",
+			html:     `<a href="../about/">About</a>`,
+			filePath: "posts/hello.md",
+			want:     `<a href="../about/index.html">About</a>`,
+		},
+		{
+			name:     "bare directory",
+			html:     `<a href="../about">About</a>`,
+			filePath: "posts/hello.md",
+			want:     `<a href="../about/index.html">About</a>`,
+		},
+		{
+			name:     "parent directory",
+			html:     `<a href="..">Home</a>`,
+			filePath: "posts/hello.md",
+			want:     `<a href="../index.html">Home</a>`,
+		},
+		{
+			name:     "keeps the fragment",
+			html:     `<a href="posts/#latest">Latest</a>`,
+			filePath: "index.md",
+			want:     `<a href="posts/index.html#latest">Latest</a>`,
+		},
+		{
+			name:     "skips bare names which are not directories",
+			html:     `<a href="contact">Contact</a>`,
+			filePath: "index.md",
+			want:     `<a href="contact">Contact</a>`,
+		},
+		{
+			name:     "skips files",
+			html:     `<a href="posts/hello.html">Hello</a>`,
+			filePath: "index.md",
+			want:     `<a href="posts/hello.html">Hello</a>`,
+		},
+		{
+			name:     "skips external URL",
+			html:     `<a href="https://example.com/posts/">External</a>`,
+			filePath: "index.md",
+			want:     `<a href="https://example.com/posts/">External</a>`,
+		},
+		{
+			name:     "skips absolute path",
+			html:     `<a href="/posts/">Posts</a>`,
+			filePath: "index.md",
+			want:     `<a href="/posts/">Posts</a>`,
+		},
+		{
+			name:     "skips fragment only",
+			html:     `<a href="#top">Top</a>`,
+			filePath: "index.md",
+			want:     `<a href="#top">Top</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sourcePath := filepath.Join(contentDir, tt.filePath)
+			s := Substituter{
+				markdownSourcePath:  sourcePath,
+				linksPathTranslater: relativePathTranslater{},
+				fs:                  filesystem.NewOSFileSystem(),
+			}
+			if got := s.convertDirLinksPath(tt.html, sourcePath); got != tt.want {
+				t.Errorf("convertDirLinksPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// rootPathTranslater outputs the files at the same path, with the .html extension for the root paths.
+type rootPathTranslater struct {
+	mockPathTranslater
+}
+
+func (rootPathTranslater) GetRootPath(rootPath string) (string, error) {
+	return strings.TrimSuffix(rootPath, ".md") + ".html", nil
+}
+
+func TestConvertMdLinksPath_RootPaths(t *testing.T) {
+	s := Substituter{
+		markdownSourcePath:  "content/posts/hello.md",
+		linksPathTranslater: rootPathTranslater{mockPathTranslater{newPath: "sibling.html"}},
+	}
+	got, err := s.convertMdLinksPath(`<a href="/about.md">About</a> <a href="sibling.md">Sibling</a> <a href="//cdn.example.org/x.md">CDN</a>`, "build/posts/hello.html")
+	if err != nil {
+		t.Fatalf("convertMdLinksPath() unexpected error: %v", err)
+	}
+	want := `<a href="/about.html">About</a> <a href="sibling.html">Sibling</a> <a href="//cdn.example.org/x.md">CDN</a>`
+	if got != want {
+		t.Errorf("convertMdLinksPath() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,32 @@
+// Package copyright resolves the {{copyright_year}} placeholder with the years of the copyright notice.
+package copyright
+
+import (
+	"fmt"
+	"time"
+)
+
+// Substituter resolves the {{copyright_year}} placeholder with the current year, or with the range from the start year
+// to the current year, e.g. 2019–2025, as in <footer>&copy; {{copyright_year}}</footer>.
+type Substituter struct {
+	startYear int
+	now       time.Time
+}
+
+// NewSubstituer creates a substituter of the years from startYear to the year of now.
+// Only the current year is resolved when startYear is 0 or not before it.
+func NewSubstituer(startYear int, now time.Time) Substituter {
+	return Substituter{startYear: startYear, now: now}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{copyright_year}}"
+}
+
+func (s Substituter) Resolve(_ string) (string, error) {
+	year := s.now.Year()
+	if s.startYear <= 0 || s.startYear >= year {
+		return fmt.Sprint(year), nil
+	}
+	return fmt.Sprintf("%d–%d", s.startYear, year), nil
+}
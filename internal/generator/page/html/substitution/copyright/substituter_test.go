@@ -0,0 +1,39 @@
+package copyright
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSubstituer_Placeholder(t *testing.T) {
+	if got := NewSubstituer(0, time.Now()).Placeholder(); got != "{{copyright_year}}" {
+		t.Errorf("Placeholder() = %q, want %q", got, "{{copyright_year}}")
+	}
+}
+
+func TestSubstituer_Resolve(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		startYear int
+		want      string
+	}{
+		{name: "range", startYear: 2019, want: "2019–2025"},
+		{name: "no start year", startYear: 0, want: "2025"},
+		{name: "start year is the current year", startYear: 2025, want: "2025"},
+		{name: "start year in the future", startYear: 2030, want: "2025"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.startYear, now).Resolve("")
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package date
+
+import (
+	"fmt"
+
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+)
+
+// DefaultLayout is the date layout used when none is configured.
+const DefaultLayout = "2006-01-02"
+
+// Substituter resolves the {{date}} placeholder with the page publication date.
+// The front matter date is preferred, the markdown file modification time is used otherwise.
+type Substituter struct {
+	markdownSourcePath string
+	layout             string
+	fs                 filesystem.FileSystem
+}
+
+func NewSubstituer(markdownSourcePath, layout string, fs filesystem.FileSystem) Substituter {
+	if layout == "" {
+		layout = DefaultLayout
+	}
+	return Substituter{
+		markdownSourcePath: markdownSourcePath,
+		layout:             layout,
+		fs:                 fs,
+	}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{date}}"
+}
+
+func (s Substituter) Resolve(content string) (string, error) {
+	return s.ResolveFrontmatter(content, frontmatter.Frontmatter{})
+}
+
+func (s Substituter) ResolveFrontmatter(_ string, fm frontmatter.Frontmatter) (string, error) {
+	if !fm.Date.IsZero() {
+		return fm.Date.Format(s.layout), nil
+	}
+
+	info, err := s.fs.Stat(s.markdownSourcePath)
+	if err != nil {
+		return "", fmt.Errorf("cannot read modification time of %s: %w", s.markdownSourcePath, err)
+	}
+	return info.ModTime().Format(s.layout), nil
+}
@@ -0,0 +1,73 @@
+package date
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+)
+
+func TestSubstituer_Placeholder(t *testing.T) {
+	s := NewSubstituer("post.md", "", filesystem.NewMemoryFileSystem())
+	if got := s.Placeholder(); got != "{{date}}" {
+		t.Errorf("Placeholder() = %q, want %q", got, "{{date}}")
+	}
+}
+
+func TestSubstituer_ResolveFrontmatter(t *testing.T) {
+	fs := filesystem.NewMemoryFileSystem()
+	fs.AddFile("/content/post.md", []byte("# Post"))
+	fs.SetModTime("/content/post.md", time.Date(2026, 3, 15, 8, 30, 0, 0, time.UTC))
+
+	tests := []struct {
+		name       string
+		sourcePath string
+		layout     string
+		fm         frontmatter.Frontmatter
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "front matter date",
+			sourcePath: "/content/post.md",
+			fm:         frontmatter.Frontmatter{Date: time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC)},
+			want:       "2026-01-24",
+		},
+		{
+			name:       "falls back to file modification time",
+			sourcePath: "/content/post.md",
+			want:       "2026-03-15",
+		},
+		{
+			name:       "custom layout",
+			sourcePath: "/content/post.md",
+			layout:     time.RFC1123,
+			fm:         frontmatter.Frontmatter{Date: time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC)},
+			want:       "Sat, 24 Jan 2026 00:00:00 UTC",
+		},
+		{
+			name:       "missing source file without front matter date",
+			sourcePath: "/content/missing.md",
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.sourcePath, tt.layout, fs).ResolveFrontmatter("", tt.fm)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("ResolveFrontmatter() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveFrontmatter() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveFrontmatter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
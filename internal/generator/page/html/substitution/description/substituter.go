@@ -0,0 +1,43 @@
+// Package description resolves the {{description}} placeholder with the page description meta tag.
+package description
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/htmltext"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+)
+
+// MaxLength is the number of characters kept from the first paragraph when used as description.
+const MaxLength = 160
+
+// Substituter resolves the {{description}} placeholder with a <meta name="description"> tag.
+// The description comes from the front matter or the first paragraph of the page,
+// the tag is omitted when there is neither.
+type Substituter struct{}
+
+func NewSubstituer() Substituter {
+	return Substituter{}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{description}}"
+}
+
+func (s Substituter) Resolve(content string) (string, error) {
+	return s.ResolveFrontmatter(content, frontmatter.Frontmatter{})
+}
+
+// ResolveFrontmatter prefers the front matter description over the first paragraph of the page.
+func (s Substituter) ResolveFrontmatter(content string, fm frontmatter.Frontmatter) (string, error) {
+	description := strings.TrimSpace(fm.Description)
+	if description == "" {
+		description = htmltext.FirstParagraph(content, MaxLength)
+	}
+	if description == "" {
+		return "", nil
+	}
+	return fmt.Sprintf(`<meta name="description" content="%s">`, html.EscapeString(description)), nil
+}
@@ -0,0 +1,60 @@
+package description
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+)
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	s := NewSubstituer()
+	if s.Placeholder() != "{{description}}" {
+		t.Errorf("Placeholder() = %q, want %q", s.Placeholder(), "{{description}}")
+	}
+}
+
+func TestSubstituter_ResolveFrontmatter(t *testing.T) {
+	long := strings.Repeat("a", 200)
+
+	tests := []struct {
+		name    string
+		content string
+		fm      frontmatter.Frontmatter
+		want    string
+	}{
+		{
+			name:    "front matter description",
+			content: "<h1>Title</h1><p>First paragraph.</p>",
+			fm:      frontmatter.Frontmatter{Description: `A "quoted" description & more`},
+			want:    `<meta name="description" content="A &#34;quoted&#34; description &amp; more">`,
+		},
+		{
+			name:    "first paragraph",
+			content: "<h1>Title</h1><p>{{summary}}</p><p>First <em>real</em>\nparagraph.</p><p>Second.</p>",
+			want:    `<meta name="description" content="First real paragraph.">`,
+		},
+		{
+			name:    "long first paragraph is truncated",
+			content: "<p>" + long + "</p>",
+			want:    `<meta name="description" content="` + strings.Repeat("a", MaxLength) + `…">`,
+		},
+		{
+			name:    "no description",
+			content: "<h1>Title</h1><ul><li>item</li></ul>",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer().ResolveFrontmatter(tt.content, tt.fm)
+			if err != nil {
+				t.Fatalf("ResolveFrontmatter() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveFrontmatter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package excerpt resolves the {{excerpt}} placeholder with the beginning of the page.
+package excerpt
+
+import "github.com/tjnvr/blog/internal/generator/page/htmltext"
+
+type (
+	// Resolver converts the links and assets paths of the excerpt, e.g. the {{content}} substituter.
+	Resolver interface {
+		Resolve(content string) (string, error)
+	}
+
+	// Substituter resolves the {{excerpt}} placeholder with the content before the <!--more--> marker,
+	// or the first paragraph of pages without marker.
+	Substituter struct {
+		resolver Resolver
+	}
+)
+
+func NewSubstituer(resolver Resolver) Substituter {
+	return Substituter{resolver: resolver}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{excerpt}}"
+}
+
+func (s Substituter) Resolve(content string) (string, error) {
+	excerpt := htmltext.Excerpt(content)
+	if excerpt == "" || s.resolver == nil {
+		return excerpt, nil
+	}
+	return s.resolver.Resolve(excerpt)
+}
@@ -0,0 +1,29 @@
+package excerpt
+
+import (
+	"strings"
+	"testing"
+)
+
+type upperResolver struct{}
+
+func (upperResolver) Resolve(content string) (string, error) {
+	return strings.ToUpper(content), nil
+}
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	s := NewSubstituer(nil)
+	if s.Placeholder() != "{{excerpt}}" {
+		t.Errorf("Placeholder() = %q, want %q", s.Placeholder(), "{{excerpt}}")
+	}
+}
+
+func TestSubstituter_Resolve(t *testing.T) {
+	got, err := NewSubstituer(upperResolver{}).Resolve("<p>First.</p>\n<!--more-->\n<p>Rest.</p>\n")
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if want := "<P>FIRST.</P>"; got != want {
+		t.Errorf("Resolve() = %q, want %q", got, want)
+	}
+}
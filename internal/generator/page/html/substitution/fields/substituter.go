@@ -0,0 +1,88 @@
+// Package fields resolves the {{fm.name}} placeholders with the fields of the page front matter, e.g. {{fm.author}}.
+package fields
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+)
+
+// placeholderRe matches the {{fm.name}} placeholders, capturing the field name.
+var placeholderRe = regexp.MustCompile(`\{\{fm\.([A-Za-z0-9_.-]+)\}\}`)
+
+// Substituter resolves the {{fm.name}} placeholders with the HTML-escaped value of the name field of the front matter.
+// The fields of nested objects are named with dots, e.g. {{fm.social.mastodon}}. Placeholders of missing fields are removed.
+type Substituter struct{}
+
+// NewSubstituer creates a front matter fields substituter.
+func NewSubstituer() Substituter {
+	return Substituter{}
+}
+
+// Placeholder returns the prefix shared by the placeholders of the fields.
+func (s Substituter) Placeholder() string {
+	return "{{fm."
+}
+
+// Resolve resolves no single placeholder, the fields are resolved by ResolveFrontmatterTemplate.
+func (s Substituter) Resolve(_ string) (string, error) {
+	return "", nil
+}
+
+// ResolveFrontmatterTemplate replaces the {{fm.name}} placeholders of template with the fields of fm.
+func (s Substituter) ResolveFrontmatterTemplate(template string, fm frontmatter.Frontmatter) (string, error) {
+	return placeholderRe.ReplaceAllStringFunc(template, func(placeholder string) string {
+		value, ok := lookup(fm.Fields, placeholderRe.FindStringSubmatch(placeholder)[1])
+		if !ok {
+			return ""
+		}
+		return html.EscapeString(format(value))
+	}), nil
+}
+
+// lookup returns the value of the field named name in fields, following the dots into nested objects.
+// A field whose name has a dot, e.g. og.image, is found before the nested one.
+func lookup(fields map[string]any, name string) (any, bool) {
+	if value, ok := fields[name]; ok {
+		return value, true
+	}
+	for i, c := range name {
+		if c != '.' {
+			continue
+		}
+		if nested, ok := fields[name[:i]].(map[string]any); ok {
+			if value, ok := lookup(nested, name[i+1:]); ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// format renders a value as decoded from YAML: dates without time as 2006-01-02, lists joined with commas,
+// null as an empty string and the other values as printed by fmt, e.g. true or 4.5.
+func format(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return v.Format(time.DateOnly)
+		}
+		return v.Format(time.RFC3339)
+	case []any:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			items = append(items, format(item))
+		}
+		return strings.Join(items, ", ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,85 @@
+package fields
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+)
+
+func TestSubstituter_ResolveFrontmatterTemplate(t *testing.T) {
+	fm := frontmatter.Frontmatter{Fields: map[string]any{
+		"author":   "Tom & Jerry",
+		"featured": true,
+		"rating":   4.5,
+		"views":    1200,
+		"date":     time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC),
+		"updated":  time.Date(2026, 1, 24, 10, 30, 0, 0, time.UTC),
+		"tags":     []any{"go", "blog"},
+		"social":   map[string]any{"mastodon": "@tim"},
+		"og.image": "cover.png",
+		"empty":    nil,
+	}}
+
+	tests := []struct {
+		name     string
+		fm       frontmatter.Frontmatter
+		template string
+		want     string
+	}{
+		{
+			name:     "string escaped",
+			fm:       fm,
+			template: "<p>{{fm.author}}</p>",
+			want:     "<p>Tom &amp; Jerry</p>",
+		},
+		{
+			name:     "bool and numbers",
+			fm:       fm,
+			template: "{{fm.featured}} {{fm.rating}} {{fm.views}}",
+			want:     "true 4.5 1200",
+		},
+		{
+			name:     "dates",
+			fm:       fm,
+			template: "{{fm.date}} {{fm.updated}}",
+			want:     "2026-01-24 2026-01-24T10:30:00Z",
+		},
+		{
+			name:     "list joined",
+			fm:       fm,
+			template: "{{fm.tags}}",
+			want:     "go, blog",
+		},
+		{
+			name:     "nested and dotted fields",
+			fm:       fm,
+			template: "{{fm.social.mastodon}} {{fm.og.image}}",
+			want:     "@tim cover.png",
+		},
+		{
+			name:     "missing and null fields removed",
+			fm:       fm,
+			template: "<p>{{fm.missing}}{{fm.empty}}{{fm.social.missing}}</p>",
+			want:     "<p></p>",
+		},
+		{
+			name:     "no front matter",
+			fm:       frontmatter.Frontmatter{},
+			template: "<p>{{fm.author}}</p>",
+			want:     "<p></p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer().ResolveFrontmatterTemplate(tt.template, tt.fm)
+			if err != nil {
+				t.Fatalf("ResolveFrontmatterTemplate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveFrontmatterTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
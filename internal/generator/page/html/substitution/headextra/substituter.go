@@ -0,0 +1,31 @@
+// Package headextra resolves the {{head_extra}} placeholder with the extra <head> content of the page.
+package headextra
+
+import "github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+
+// Substituter resolves the {{head_extra}} placeholder with the head_extra front matter field of the page,
+// e.g. preload hints, or with the site default for pages without it. The HTML is inserted as is.
+type Substituter struct {
+	defaultHead string
+}
+
+// NewSubstituer creates a substituter inserting defaultHead in the pages without head_extra; it may be empty.
+func NewSubstituer(defaultHead string) Substituter {
+	return Substituter{defaultHead: defaultHead}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{head_extra}}"
+}
+
+func (s Substituter) Resolve(content string) (string, error) {
+	return s.ResolveFrontmatter(content, frontmatter.Frontmatter{})
+}
+
+// ResolveFrontmatter prefers the front matter head_extra over the site default.
+func (s Substituter) ResolveFrontmatter(_ string, fm frontmatter.Frontmatter) (string, error) {
+	if fm.HeadExtra != "" {
+		return fm.HeadExtra, nil
+	}
+	return s.defaultHead, nil
+}
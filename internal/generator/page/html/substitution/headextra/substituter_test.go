@@ -0,0 +1,61 @@
+package headextra
+
+import (
+	"testing"
+
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+)
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	s := NewSubstituer("")
+	if s.Placeholder() != "{{head_extra}}" {
+		t.Errorf("Placeholder() = %q, want %q", s.Placeholder(), "{{head_extra}}")
+	}
+}
+
+func TestSubstituter_ResolveFrontmatter(t *testing.T) {
+	const (
+		siteHead = `<script defer src="/scripts/analytics.js"></script>`
+		pageHead = `<link rel="preload" href="/assets/hero.webp" as="image">`
+	)
+
+	tests := []struct {
+		name        string
+		defaultHead string
+		fm          frontmatter.Frontmatter
+		want        string
+	}{
+		{
+			name:        "page head",
+			defaultHead: siteHead,
+			fm:          frontmatter.Frontmatter{HeadExtra: pageHead},
+			want:        pageHead,
+		},
+		{
+			name:        "site default",
+			defaultHead: siteHead,
+			want:        siteHead,
+		},
+		{
+			name: "page head without site default",
+			fm:   frontmatter.Frontmatter{HeadExtra: pageHead},
+			want: pageHead,
+		},
+		{
+			name: "nothing",
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.defaultHead).ResolveFrontmatter("", tt.fm)
+			if err != nil {
+				t.Fatalf("ResolveFrontmatter() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveFrontmatter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
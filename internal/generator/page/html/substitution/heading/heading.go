@@ -0,0 +1,28 @@
+// Package heading collects the headings of a converted HTML page.
+package heading
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var headingRe = regexp.MustCompile(`<h([2-6])[^>]*id="([^"]+)"[^>]*>([^<]+)(?:<a[^>]*>[^<]*</a>)?</h[2-6]>`)
+
+// Heading is a h2-h6 heading carrying an id attribute.
+type Heading struct {
+	Level int
+	ID    string
+	Text  string
+}
+
+// Collect returns the h2-h6 headings with an id found in content, in document order.
+func Collect(content string) []Heading {
+	matches := headingRe.FindAllStringSubmatch(content, -1)
+	headings := make([]Heading, len(matches))
+	for i, m := range matches {
+		level, _ := strconv.Atoi(m[1])
+		headings[i] = Heading{Level: level, ID: m[2], Text: strings.TrimSpace(m[3])}
+	}
+	return headings
+}
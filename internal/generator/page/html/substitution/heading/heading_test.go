@@ -0,0 +1,48 @@
+package heading
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCollect(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Heading
+	}{
+		{
+			name:    "no headings",
+			content: `<p>Some paragraph text.</p>`,
+			want:    []Heading{},
+		},
+		{
+			name:    "h1 is ignored",
+			content: `<h1 id="title">Title<a href="#title" class="heading-anchor">#</a></h1>`,
+			want:    []Heading{},
+		},
+		{
+			name: "headings collected in document order",
+			content: `<h2 id="intro">Introduction<a href="#intro" class="heading-anchor">#</a></h2>` +
+				`<h3 id="details"> Details <a href="#details" class="heading-anchor">#</a></h3>`,
+			want: []Heading{
+				{Level: 2, ID: "intro", Text: "Introduction"},
+				{Level: 3, ID: "details", Text: "Details"},
+			},
+		},
+		{
+			name:    "heading without id is ignored",
+			content: `<h2>No id</h2>`,
+			want:    []Heading{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Collect(tt.content)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Collect() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
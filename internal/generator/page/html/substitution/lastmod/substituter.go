@@ -0,0 +1,95 @@
+// Package lastmod resolves the {{lastmod}} placeholder with the date the page was last updated.
+package lastmod
+
+import (
+	"fmt"
+	"html"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+)
+
+// DefaultLayout is the date layout used when none is configured.
+const DefaultLayout = "2006-01-02"
+
+type (
+	// Source tells when the markdown file at path was last changed, ok is false when it does not know.
+	Source interface {
+		LastModified(path string) (t time.Time, ok bool)
+	}
+
+	// GitSource reads the date of the last commit changing the file from git.
+	// It does not know the files outside a repository, untracked files, or any file when git is not installed.
+	GitSource struct{}
+
+	// Substituter resolves the {{lastmod}} placeholder with the date the markdown file was last changed.
+	// The date comes from the source, the file modification time is used otherwise.
+	// The placeholder is resolved to an empty string for pages without markdown file, such as tag pages.
+	Substituter struct {
+		markdownSourcePath string
+		layout             string
+		source             Source
+		fs                 filesystem.FileSystem
+	}
+)
+
+// LastModified runs git log -1 --format=%cI -- path in the directory of path.
+func (GitSource) LastModified(path string) (time.Time, bool) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	cmd := exec.Command("git", "log", "-1", "--format=%cI", "--", absPath)
+	cmd.Dir = filepath.Dir(absPath)
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, strings.TrimSpace(string(out)))
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// NewSubstituer creates a substituter rendering the last change of the markdown file at markdownSourcePath with layout.
+// A nil source only uses the file modification time.
+func NewSubstituer(markdownSourcePath, layout string, source Source, fs filesystem.FileSystem) Substituter {
+	if layout == "" {
+		layout = DefaultLayout
+	}
+	return Substituter{
+		markdownSourcePath: markdownSourcePath,
+		layout:             layout,
+		source:             source,
+		fs:                 fs,
+	}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{lastmod}}"
+}
+
+func (s Substituter) Resolve(_ string) (string, error) {
+	t, ok := s.lastModified()
+	if !ok {
+		return "", nil
+	}
+	return fmt.Sprintf(`Last updated <time datetime="%s">%s</time>`, t.Format(time.RFC3339), html.EscapeString(t.Format(s.layout))), nil
+}
+
+func (s Substituter) lastModified() (time.Time, bool) {
+	if s.source != nil {
+		if t, ok := s.source.LastModified(s.markdownSourcePath); ok {
+			return t, true
+		}
+	}
+	info, err := s.fs.Stat(s.markdownSourcePath)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
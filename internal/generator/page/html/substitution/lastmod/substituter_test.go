@@ -0,0 +1,96 @@
+package lastmod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+)
+
+type mockSource map[string]time.Time
+
+func (m mockSource) LastModified(path string) (time.Time, bool) {
+	t, ok := m[path]
+	return t, ok
+}
+
+func TestSubstituer_Placeholder(t *testing.T) {
+	s := NewSubstituer("post.md", "", nil, filesystem.NewMemoryFileSystem())
+	if got := s.Placeholder(); got != "{{lastmod}}" {
+		t.Errorf("Placeholder() = %q, want %q", got, "{{lastmod}}")
+	}
+}
+
+func TestSubstituer_Resolve(t *testing.T) {
+	fs := filesystem.NewMemoryFileSystem()
+	fs.AddFile("/content/committed.md", []byte("# Committed"))
+	fs.SetModTime("/content/committed.md", time.Date(2026, 3, 15, 8, 30, 0, 0, time.UTC))
+	fs.AddFile("/content/untracked.md", []byte("# Untracked"))
+	fs.SetModTime("/content/untracked.md", time.Date(2026, 3, 15, 8, 30, 0, 0, time.UTC))
+
+	source := mockSource{"/content/committed.md": time.Date(2026, 1, 24, 18, 5, 0, 0, time.UTC)}
+
+	tests := []struct {
+		name       string
+		sourcePath string
+		layout     string
+		source     Source
+		want       string
+	}{
+		{
+			name:       "date from the source",
+			sourcePath: "/content/committed.md",
+			source:     source,
+			want:       `Last updated <time datetime="2026-01-24T18:05:00Z">2026-01-24</time>`,
+		},
+		{
+			name:       "falls back to file modification time",
+			sourcePath: "/content/untracked.md",
+			source:     source,
+			want:       `Last updated <time datetime="2026-03-15T08:30:00Z">2026-03-15</time>`,
+		},
+		{
+			name:       "no source",
+			sourcePath: "/content/committed.md",
+			want:       `Last updated <time datetime="2026-03-15T08:30:00Z">2026-03-15</time>`,
+		},
+		{
+			name:       "custom layout",
+			sourcePath: "/content/committed.md",
+			layout:     "January 2, 2006",
+			source:     source,
+			want:       `Last updated <time datetime="2026-01-24T18:05:00Z">January 24, 2026</time>`,
+		},
+		{
+			name:       "page without markdown file",
+			sourcePath: "/content/tags/go.md",
+			source:     source,
+			want:       "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.sourcePath, tt.layout, tt.source, fs).Resolve("")
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitSource_OutsideRepository(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "post.md")
+	if err := os.WriteFile(path, []byte("# Post"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got, ok := (GitSource{}).LastModified(path); ok {
+		t.Errorf("LastModified() = %v, want no date outside a repository", got)
+	}
+}
@@ -0,0 +1,37 @@
+// Package math resolves the {{math}} placeholder with the client-side math renderer of pages containing math.
+package math
+
+import "strings"
+
+// DefaultHead loads KaTeX and renders the math of the page once loaded.
+const DefaultHead = `<link rel="stylesheet" href="https://cdn.jsdelivr.net/npm/katex@0.16.11/dist/katex.min.css">` +
+	`<script defer src="https://cdn.jsdelivr.net/npm/katex@0.16.11/dist/katex.min.js"></script>` +
+	`<script defer src="https://cdn.jsdelivr.net/npm/katex@0.16.11/dist/contrib/auto-render.min.js" onload="renderMathInElement(document.body)"></script>`
+
+// mathClass is the class prefix of the elements holding math rendered by the markdown converter.
+const mathClass = `class="math `
+
+// Substituter resolves the {{math}} placeholder with Head when the page contains math, and with nothing otherwise,
+// so that pages without math do not load the renderer.
+type Substituter struct {
+	Head string
+}
+
+// NewSubstituer creates a math substituter injecting head, or DefaultHead when head is empty.
+func NewSubstituer(head string) Substituter {
+	if head == "" {
+		head = DefaultHead
+	}
+	return Substituter{Head: head}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{math}}"
+}
+
+func (s Substituter) Resolve(content string) (string, error) {
+	if !strings.Contains(content, mathClass) {
+		return "", nil
+	}
+	return s.Head, nil
+}
@@ -0,0 +1,36 @@
+package math
+
+import "testing"
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	s := NewSubstituer("")
+	if s.Placeholder() != "{{math}}" {
+		t.Errorf("Placeholder() = %q, want %q", s.Placeholder(), "{{math}}")
+	}
+}
+
+func TestSubstituter_Resolve(t *testing.T) {
+	tests := []struct {
+		name    string
+		head    string
+		content string
+		want    string
+	}{
+		{name: "inline math", content: `<p><span class="math inline">\(x\)</span></p>`, want: DefaultHead},
+		{name: "display math", content: `<div class="math display">\[x\]</div>`, want: DefaultHead},
+		{name: "no math", content: `<p>$5 and $10</p>`, want: ""},
+		{name: "custom head", head: `<script src="/scripts/math.js"></script>`, content: `<span class="math inline">\(x\)</span>`, want: `<script src="/scripts/math.js"></script>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.head).Resolve(tt.content)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,22 +2,57 @@ package navigation
 
 import (
 	"fmt"
+	"html"
+	"path/filepath"
 	"strings"
 
 	"github.com/tjnvr/blog/internal/generator/section"
 )
 
+const (
+	// Label is the accessible name of the site navigation landmark.
+	Label = "Main"
+	// DefaultActiveClass is the class of the link to the current section.
+	DefaultActiveClass = "font-semibold underline"
+)
+
 // Substituter resolves {{navigation}} placeholder with an auto-generated nav bar
 type Substituter struct {
 	sections       []section.Section
 	currentSection string
+	homeLabel      string
+	isIndex        bool
+	depth          int
+	activeClass    string
 }
 
-func NewSubstituer(sections []section.Section, currentSection string) Substituter {
+// NewSubstituer creates a navigation substituter linking to sections, for the page generated at htmlPath
+// in currentSection. homeLabel is shown for the home link; when empty the home section display name is used.
+func NewSubstituer(htmlPath string, sections []section.Section, currentSection, homeLabel string) Substituter {
 	return Substituter{
 		sections:       sections,
 		currentSection: currentSection,
+		homeLabel:      homeLabel,
+		isIndex:        filepath.Base(htmlPath) == "index.html",
+		activeClass:    DefaultActiveClass,
+	}
+}
+
+// WithDepth returns a copy of n for a page generated depth directories below its section directory,
+// e.g. a page output in a directory of its own, which is not a section index page then.
+func (n Substituter) WithDepth(depth int) Substituter {
+	n.depth = depth
+	n.isIndex = n.isIndex && depth == 0
+	return n
+}
+
+// WithActiveClass returns a copy of n marking the link to the current section with class instead of DefaultActiveClass.
+// An empty class keeps the current one.
+func (n Substituter) WithActiveClass(class string) Substituter {
+	if class != "" {
+		n.activeClass = class
 	}
+	return n
 }
 
 func (n Substituter) Placeholder() string {
@@ -25,31 +60,52 @@ func (n Substituter) Placeholder() string {
 }
 
 func (n Substituter) Resolve(_ string) (string, error) {
-	prefix := relativePrefix(n.currentSection)
+	return fmt.Sprintf(`<nav role="navigation" aria-label="%s" class="flex flex-col sm:flex-row gap-4">%s</nav>`, Label, n.links(n.sections, RelativePrefix(n.currentSection)+strings.Repeat("../", n.depth))), nil
+}
 
+// links renders the links to sections, nesting the links to their children in an indented list.
+// The link to the current section is marked with the active class, and with aria-current="page" on the section index
+// page, aria-current="true" on the other pages of the section.
+func (n Substituter) links(sections []section.Section, prefix string) string {
 	var links []string
-	for _, s := range n.sections {
+	for _, s := range sections {
 		var href string
 		if s.DirName == "" {
 			href = prefix + "index.html"
 		} else {
 			href = prefix + s.DirName + "/index.html"
 		}
-		class := "hover:underline"
+		attributes := `class="hover:underline"`
 		if s.DirName == n.currentSection {
-			class = "font-semibold underline"
+			current := "true"
+			if n.isIndex {
+				current = "page"
+			}
+			attributes = fmt.Sprintf(`aria-current="%s" class="%s"`, current, html.EscapeString(n.activeClass))
+		}
+		link := fmt.Sprintf(`<a href="%s" %s>%s</a>`, href, attributes, n.label(s))
+		if len(s.Children) > 0 {
+			link = fmt.Sprintf(`<div class="flex flex-col">%s<div class="flex flex-col pl-4 text-sm">%s</div></div>`, link, n.links(s.Children, prefix))
 		}
-		links = append(links, fmt.Sprintf(`<a href="%s" class="%s">%s</a>`, href, class, s.DisplayName))
+		links = append(links, link)
 	}
-
-	return fmt.Sprintf(`<nav class="flex flex-col sm:flex-row gap-4">%s</nav>`, strings.Join(links, "\n    ")), nil
+	return strings.Join(links, "\n    ")
 }
 
-// relativePrefix returns the "../" prefix needed to reach the site root from the current section.
-func relativePrefix(currentSection string) string {
+// RelativePrefix returns the "../" prefix needed to reach the site root from the current section.
+func RelativePrefix(currentSection string) string {
 	if currentSection == "" {
 		return ""
 	}
 	depth := strings.Count(currentSection, "/") + 1
 	return strings.Repeat("../", depth)
 }
+
+// label returns the navigation label of s: the home label for the home section when set,
+// the section display name otherwise.
+func (n Substituter) label(s section.Section) string {
+	if s.DirName == "" && n.homeLabel != "" {
+		return n.homeLabel
+	}
+	return s.DisplayName
+}
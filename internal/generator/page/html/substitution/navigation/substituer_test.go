@@ -8,7 +8,7 @@ import (
 )
 
 func TestSubstituer_Placeholder(t *testing.T) {
-	s := NewSubstituer(nil, "")
+	s := NewSubstituer("index.html", nil, "", "")
 	if got := s.Placeholder(); got != "{{navigation}}" {
 		t.Errorf("Placeholder() = %q, want %q", got, "{{navigation}}")
 	}
@@ -26,7 +26,7 @@ func TestSubstituer_Resolve(t *testing.T) {
 			name:           "from root with no sections",
 			sections:       []section.Section{{DirName: "", DisplayName: "Accueil"}},
 			currentSection: "",
-			wantContains:   []string{`href="index.html"`, "Accueil", "<nav"},
+			wantContains:   []string{`href="index.html"`, "Accueil", `<nav role="navigation" aria-label="Main"`},
 		},
 		{
 			name: "from root with sections",
@@ -91,6 +91,20 @@ func TestSubstituer_Resolve(t *testing.T) {
 				`href="../../posts/index.html"`,
 			},
 		},
+		{
+			name: "nested sections from a nested section",
+			sections: []section.Section{
+				{DirName: "", DisplayName: "Accueil"},
+				{DirName: "blog", DisplayName: "Blog", Children: []section.Section{
+					{DirName: "blog/2024", DisplayName: "2024"},
+				}},
+			},
+			currentSection: "blog/2024",
+			wantContains: []string{
+				`<a href="../../blog/index.html" class="hover:underline">Blog</a><div class="flex flex-col pl-4 text-sm">`,
+				`<a href="../../blog/2024/index.html" aria-current="page" class="font-semibold underline">2024</a></div>`,
+			},
+		},
 		{
 			name: "home display name comes from root index.md title",
 			sections: []section.Section{
@@ -109,7 +123,7 @@ func TestSubstituer_Resolve(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewSubstituer(tt.sections, tt.currentSection)
+			s := NewSubstituer("index.html", tt.sections, tt.currentSection, "")
 			got, err := s.Resolve("")
 			if err != nil {
 				t.Fatalf("Resolve() unexpected error: %v", err)
@@ -141,18 +155,18 @@ func TestRelativePrefix(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.section, func(t *testing.T) {
-			if got := relativePrefix(tt.section); got != tt.want {
-				t.Errorf("relativePrefix(%q) = %q, want %q", tt.section, got, tt.want)
+			if got := RelativePrefix(tt.section); got != tt.want {
+				t.Errorf("RelativePrefix(%q) = %q, want %q", tt.section, got, tt.want)
 			}
 		})
 	}
 }
 
 func TestSubstituer_Resolve_DisplayNameFromSection(t *testing.T) {
-	s := NewSubstituer([]section.Section{
+	s := NewSubstituer("index.html", []section.Section{
 		{DirName: "", DisplayName: "Accueil"},
 		{DirName: "posts", DisplayName: "My Blog Posts"},
-	}, "")
+	}, "", "")
 	got, err := s.Resolve("")
 	if err != nil {
 		t.Fatalf("Resolve() unexpected error: %v", err)
@@ -184,7 +198,7 @@ func TestSubstituer_Resolve_ActiveSection(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			s := NewSubstituer(sections, tt.currentSection)
+			s := NewSubstituer("index.html", sections, tt.currentSection, "")
 			got, err := s.Resolve("")
 			if err != nil {
 				t.Fatalf("Resolve() unexpected error: %v", err)
@@ -203,3 +217,106 @@ func TestSubstituer_Resolve_ActiveSection(t *testing.T) {
 		})
 	}
 }
+
+func TestSubstituer_Resolve_HomeLabel(t *testing.T) {
+	sections := []section.Section{
+		{DirName: "", DisplayName: "Accueil"},
+		{DirName: "posts", DisplayName: "Posts"},
+	}
+
+	t.Run("custom home label", func(t *testing.T) {
+		got, err := NewSubstituer("index.html", sections, "posts", "Home").Resolve("")
+		if err != nil {
+			t.Fatalf("Resolve() unexpected error: %v", err)
+		}
+		if !strings.Contains(got, `<a href="../index.html" class="hover:underline">Home</a>`) || strings.Contains(got, "Accueil") {
+			t.Errorf("expected the home link to be labeled Home, got %q", got)
+		}
+		if !strings.Contains(got, ">Posts</a>") {
+			t.Errorf("other sections should keep their display name, got %q", got)
+		}
+	})
+
+	t.Run("empty home label keeps the home display name", func(t *testing.T) {
+		got, err := NewSubstituer("index.html", sections, "", "").Resolve("")
+		if err != nil {
+			t.Fatalf("Resolve() unexpected error: %v", err)
+		}
+		if !strings.Contains(got, ">Accueil</a>") {
+			t.Errorf("expected the home link to be labeled Accueil, got %q", got)
+		}
+	})
+}
+
+func TestSubstituer_Resolve_ActiveClass(t *testing.T) {
+	sections := []section.Section{
+		{DirName: "", DisplayName: "Accueil"},
+		{DirName: "posts", DisplayName: "Posts"},
+	}
+
+	tests := []struct {
+		name           string
+		htmlPath       string
+		currentSection string
+		depth          int
+		want           string
+		wantInactive   string
+	}{
+		{
+			name:           "from root",
+			htmlPath:       "index.html",
+			currentSection: "",
+			want:           `<a href="index.html" aria-current="page" class="active">Accueil</a>`,
+			wantInactive:   `<a href="posts/index.html" class="hover:underline">Posts</a>`,
+		},
+		{
+			name:           "from section",
+			htmlPath:       "posts/index.html",
+			currentSection: "posts",
+			want:           `<a href="../posts/index.html" aria-current="page" class="active">Posts</a>`,
+			wantInactive:   `<a href="../index.html" class="hover:underline">Accueil</a>`,
+		},
+		{
+			name:           "from a post of the section",
+			htmlPath:       "posts/first.html",
+			currentSection: "posts",
+			want:           `<a href="../posts/index.html" aria-current="true" class="active">Posts</a>`,
+			wantInactive:   `<a href="../index.html" class="hover:underline">Accueil</a>`,
+		},
+		{
+			name:           "from a post output in a directory of its own",
+			htmlPath:       "posts/first/index.html",
+			currentSection: "posts",
+			depth:          1,
+			want:           `<a href="../../posts/index.html" aria-current="true" class="active">Posts</a>`,
+			wantInactive:   `<a href="../../index.html" class="hover:underline">Accueil</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.htmlPath, sections, tt.currentSection, "").WithDepth(tt.depth).WithActiveClass("active").Resolve("")
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if !strings.Contains(got, tt.want) || !strings.Contains(got, tt.wantInactive) {
+				t.Errorf("Resolve() should contain %q and %q, got:\n%s", tt.want, tt.wantInactive, got)
+			}
+			if strings.Count(got, "aria-current") != 1 {
+				t.Errorf("only the current section should be marked, got:\n%s", got)
+			}
+		})
+	}
+}
+
+func TestSubstituer_Resolve_EscapesActiveClass(t *testing.T) {
+	sections := []section.Section{{DirName: "", DisplayName: "Accueil"}}
+
+	got, err := NewSubstituer("index.html", sections, "", "").WithActiveClass(`active" onclick="alert(1)`).Resolve("")
+	if err != nil {
+		t.Fatalf("Resolve() unexpected error: %v", err)
+	}
+	if want := `class="active&#34; onclick=&#34;alert(1)"`; !strings.Contains(got, want) {
+		t.Errorf("Resolve() should contain %q, got:\n%s", want, got)
+	}
+}
@@ -0,0 +1,47 @@
+package noscript
+
+import "strings"
+
+type (
+	// Fallback is the markup rendered when JavaScript is disabled for a script dependent feature.
+	Fallback struct {
+		Feature string
+		HTML    string
+	}
+
+	// Substituter resolves the {{noscript}} placeholder with the configured fallbacks.
+	Substituter struct {
+		fallbacks []Fallback
+	}
+)
+
+// DarkMode hides the theme toggle, which needs dark-mode.js, and keeps the default light theme.
+var DarkMode = Fallback{
+	Feature: "dark-mode",
+	HTML:    `<style>.theme-toggle{display:none}</style>`,
+}
+
+func NewSubstituer(fallbacks ...Fallback) Substituter {
+	return Substituter{
+		fallbacks: fallbacks,
+	}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{noscript}}"
+}
+
+// Resolve returns a <noscript> element holding every fallback, or nothing when no fallback is configured.
+func (s Substituter) Resolve(_ string) (string, error) {
+	if len(s.fallbacks) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString("<noscript>")
+	for _, f := range s.fallbacks {
+		sb.WriteString(f.HTML)
+	}
+	sb.WriteString("</noscript>")
+	return sb.String(), nil
+}
@@ -0,0 +1,50 @@
+package noscript
+
+import (
+	"testing"
+)
+
+func TestSubstituer_Placeholder(t *testing.T) {
+	s := NewSubstituer()
+	if got := s.Placeholder(); got != "{{noscript}}" {
+		t.Errorf("Placeholder() = %q, want %q", got, "{{noscript}}")
+	}
+}
+
+func TestSubstituer_Resolve(t *testing.T) {
+	tests := []struct {
+		name      string
+		fallbacks []Fallback
+		want      string
+	}{
+		{
+			name: "no fallback configured",
+			want: "",
+		},
+		{
+			name:      "dark mode fallback",
+			fallbacks: []Fallback{DarkMode},
+			want:      `<noscript><style>.theme-toggle{display:none}</style></noscript>`,
+		},
+		{
+			name: "several fallbacks in one noscript element",
+			fallbacks: []Fallback{
+				DarkMode,
+				{Feature: "search", HTML: `<style>.search{display:none}</style>`},
+			},
+			want: `<noscript><style>.theme-toggle{display:none}</style><style>.search{display:none}</style></noscript>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.fallbacks...).Resolve("")
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package og resolves the {{og_meta}} placeholder with Open Graph and Twitter Card meta tags.
+package og
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/htmltext"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+)
+
+// maxDescriptionLength is the number of characters kept from the first paragraph when used as description.
+const maxDescriptionLength = 200
+
+var (
+	h1Re     = regexp.MustCompile(`<h1[^>]*>([^<]+)(?:<a[^>]*>[^<]*</a>)?</h1>`)
+	imgSrcRe = regexp.MustCompile(`<img[^>]+src="([^"]+)"`)
+)
+
+// Substituter resolves the {{og_meta}} placeholder with link preview meta tags.
+// The title comes from the front matter or the <h1>, the description from the front matter or the first paragraph,
+// and the image from the first <img> of the page or the default image.
+type Substituter struct {
+	pageURL      string
+	defaultImage string
+}
+
+// NewSubstituer creates an Open Graph substituter for the page served at pageURL.
+// pageURL must be absolute for og:url and og:image to be emitted, as both require absolute URLs.
+// defaultImage, absolute or relative to pageURL, is used for pages without image; it may be empty.
+func NewSubstituer(pageURL, defaultImage string) Substituter {
+	return Substituter{
+		pageURL:      pageURL,
+		defaultImage: defaultImage,
+	}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{og_meta}}"
+}
+
+func (s Substituter) Resolve(content string) (string, error) {
+	return s.ResolveFrontmatter(content, frontmatter.Frontmatter{})
+}
+
+// ResolveFrontmatter prefers the front matter title and description over the ones found in the page.
+func (s Substituter) ResolveFrontmatter(content string, fm frontmatter.Frontmatter) (string, error) {
+	title := fm.Title
+	if title == "" {
+		if m := h1Re.FindStringSubmatch(content); m != nil {
+			title = html.UnescapeString(strings.TrimSpace(m[1]))
+		}
+	}
+
+	pageDescription := fm.Description
+	if pageDescription == "" {
+		pageDescription = htmltext.FirstParagraph(content, maxDescriptionLength)
+	}
+
+	image := s.image(content)
+
+	var tags []string
+	if title != "" {
+		tags = append(tags, property("og:title", title))
+	}
+	if pageDescription != "" {
+		tags = append(tags, property("og:description", pageDescription))
+	}
+	if s.isAbsolute() {
+		tags = append(tags, property("og:url", s.pageURL))
+	}
+	if image != "" {
+		tags = append(tags, property("og:image", image))
+	}
+
+	card := "summary"
+	if image != "" {
+		card = "summary_large_image"
+	}
+	tags = append(tags, fmt.Sprintf(`<meta name="twitter:card" content="%s">`, card))
+
+	return strings.Join(tags, "\n    "), nil
+}
+
+// image returns the absolute URL of the first image of the page, or of the default image.
+// It returns "" when no absolute URL can be built.
+func (s Substituter) image(content string) string {
+	src := s.defaultImage
+	if m := imgSrcRe.FindStringSubmatch(content); m != nil {
+		src = html.UnescapeString(m[1])
+	}
+	if src == "" {
+		return ""
+	}
+
+	ref, err := url.Parse(src)
+	if err != nil {
+		return ""
+	}
+	if ref.IsAbs() {
+		return ref.String()
+	}
+	if !s.isAbsolute() {
+		return ""
+	}
+	base, _ := url.Parse(s.pageURL)
+	return base.ResolveReference(ref).String()
+}
+
+func (s Substituter) isAbsolute() bool {
+	u, err := url.Parse(s.pageURL)
+	return err == nil && u.IsAbs()
+}
+
+func property(name, value string) string {
+	return fmt.Sprintf(`<meta property="%s" content="%s">`, name, html.EscapeString(value))
+}
@@ -0,0 +1,116 @@
+package og
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+)
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	s := NewSubstituer("", "")
+	if s.Placeholder() != "{{og_meta}}" {
+		t.Errorf("Placeholder() = %q, want %q", s.Placeholder(), "{{og_meta}}")
+	}
+}
+
+func TestSubstituter_ResolveFrontmatter(t *testing.T) {
+	const pageURL = "https://example.org/posts/first.html"
+
+	tests := []struct {
+		name         string
+		pageURL      string
+		defaultImage string
+		content      string
+		fm           frontmatter.Frontmatter
+		contains     []string
+		notContains  []string
+	}{
+		{
+			name:    "title and description from the page",
+			pageURL: pageURL,
+			content: `<h1 id="first">First &amp; foremost<a href="#first" class="heading-anchor">#</a></h1><p>Hello <strong>world</strong>.</p><p>Second.</p>`,
+			contains: []string{
+				`<meta property="og:title" content="First &amp; foremost">`,
+				`<meta property="og:description" content="Hello world.">`,
+				`<meta property="og:url" content="https://example.org/posts/first.html">`,
+				`<meta name="twitter:card" content="summary">`,
+			},
+			notContains: []string{"og:image"},
+		},
+		{
+			name:    "front matter title and description win",
+			pageURL: pageURL,
+			content: `<h1>Heading</h1><p>Paragraph.</p>`,
+			fm:      frontmatter.Frontmatter{Title: `Front "matter"`, Description: "Configured description"},
+			contains: []string{
+				`<meta property="og:title" content="Front &#34;matter&#34;">`,
+				`<meta property="og:description" content="Configured description">`,
+			},
+			notContains: []string{"Heading", "Paragraph."},
+		},
+		{
+			name:    "relative image is made absolute",
+			pageURL: pageURL,
+			content: `<h1>Title</h1><p><img src="../assets/images/cover.png" alt="Cover"></p>`,
+			contains: []string{
+				`<meta property="og:image" content="https://example.org/assets/images/cover.png">`,
+				`<meta name="twitter:card" content="summary_large_image">`,
+			},
+		},
+		{
+			name:     "external image is kept",
+			pageURL:  pageURL,
+			content:  `<h1>Title</h1><img src="https://cdn.example.com/a.png">`,
+			contains: []string{`<meta property="og:image" content="https://cdn.example.com/a.png">`},
+		},
+		{
+			name:         "default image for pages without image",
+			pageURL:      pageURL,
+			defaultImage: "/assets/images/logo.svg",
+			content:      `<h1>Title</h1>`,
+			contains:     []string{`<meta property="og:image" content="https://example.org/assets/images/logo.svg">`},
+		},
+		{
+			name:         "no absolute url without base url",
+			pageURL:      "/posts/first.html",
+			defaultImage: "/assets/images/logo.svg",
+			content:      `<h1>Title</h1><img src="cover.png">`,
+			contains:     []string{`<meta property="og:title" content="Title">`, `<meta name="twitter:card" content="summary">`},
+			notContains:  []string{"og:url", "og:image"},
+		},
+		{
+			name:        "placeholder paragraphs are not descriptions",
+			pageURL:     pageURL,
+			content:     `<h1>Title</h1><p>{{summary}}</p><p>Real text.</p>`,
+			contains:    []string{`<meta property="og:description" content="Real text.">`},
+			notContains: []string{"{{summary}}"},
+		},
+		{
+			name:     "long descriptions are shortened",
+			pageURL:  pageURL,
+			content:  `<h1>Title</h1><p>` + strings.Repeat("word ", 100) + `</p>`,
+			contains: []string{`…">`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.pageURL, tt.defaultImage).ResolveFrontmatter(tt.content, tt.fm)
+			assert.NoError(t, err)
+			for _, c := range tt.contains {
+				assert.Contains(t, got, c)
+			}
+			for _, c := range tt.notContains {
+				assert.NotContains(t, got, c)
+			}
+		})
+	}
+}
+
+func TestSubstituter_Resolve(t *testing.T) {
+	got, err := NewSubstituer("https://example.org/", "").Resolve(`<h1>Home</h1>`)
+	assert.NoError(t, err)
+	assert.Contains(t, got, `<meta property="og:title" content="Home">`)
+}
@@ -0,0 +1,49 @@
+package outline
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/heading"
+)
+
+// minHeadings is the number of h2/h3 headings below which a page is considered too short for an outline.
+const minHeadings = 2
+
+// Substituter resolves the {{page_outline}} placeholder with a compact "On this page" sidebar fragment.
+// Each entry carries data attributes so a scroll-spy script can highlight the heading being read.
+type Substituter struct{}
+
+func NewSubstituer() Substituter {
+	return Substituter{}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{page_outline}}"
+}
+
+func (s Substituter) Resolve(content string) (string, error) {
+	items := make([]heading.Heading, 0)
+	for _, h := range heading.Collect(content) {
+		if h.Level <= 3 {
+			items = append(items, h)
+		}
+	}
+	if len(items) < minHeadings {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<aside class="page-outline text-sm" aria-label="On this page" data-page-outline>`)
+	sb.WriteString(`<p class="font-semibold">On this page</p><ul>`)
+	for _, item := range items {
+		class := "page-outline-item"
+		if item.Level == 3 {
+			class += " pl-4"
+		}
+		fmt.Fprintf(&sb, `<li class="%s" data-outline-level="%d"><a href="#%s" data-outline-target="%s">%s</a></li>`, class, item.Level, item.ID, item.ID, item.Text)
+	}
+	sb.WriteString("</ul></aside>")
+
+	return sb.String(), nil
+}
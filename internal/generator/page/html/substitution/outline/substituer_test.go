@@ -0,0 +1,77 @@
+package outline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	s := NewSubstituer()
+	if s.Placeholder() != "{{page_outline}}" {
+		t.Errorf("Placeholder() = %q, want %q", s.Placeholder(), "{{page_outline}}")
+	}
+}
+
+func TestSubstituter_Resolve(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		contains    []string
+		notContains []string
+		empty       bool
+	}{
+		{
+			name: "h2 and h3 headings produce scroll-spy friendly entries",
+			content: `<h1 id="title">Title<a href="#title" class="heading-anchor">#</a></h1>` +
+				`<h2 id="intro">Introduction<a href="#intro" class="heading-anchor">#</a></h2>` +
+				`<h3 id="details">Details<a href="#details" class="heading-anchor">#</a></h3>`,
+			contains: []string{
+				`<aside class="page-outline text-sm" aria-label="On this page" data-page-outline>`,
+				`<li class="page-outline-item" data-outline-level="2"><a href="#intro" data-outline-target="intro">Introduction</a></li>`,
+				`<li class="page-outline-item pl-4" data-outline-level="3"><a href="#details" data-outline-target="details">Details</a></li>`,
+			},
+			notContains: []string{`href="#title"`},
+		},
+		{
+			name: "headings deeper than h3 are left out",
+			content: `<h2 id="a">A<a href="#a" class="heading-anchor">#</a></h2>` +
+				`<h2 id="b">B<a href="#b" class="heading-anchor">#</a></h2>` +
+				`<h4 id="c">C<a href="#c" class="heading-anchor">#</a></h4>`,
+			contains:    []string{`href="#a"`, `href="#b"`},
+			notContains: []string{`href="#c"`},
+		},
+		{
+			name:    "one heading page renders nothing",
+			content: `<h2 id="only">Only<a href="#only" class="heading-anchor">#</a></h2><p>Short page.</p>`,
+			empty:   true,
+		},
+		{
+			name:    "no headings renders nothing",
+			content: `<p>Some paragraph text.</p>`,
+			empty:   true,
+		},
+	}
+
+	s := NewSubstituer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := s.Resolve(tt.content)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+
+			if tt.empty {
+				assert.Empty(t, result)
+				return
+			}
+
+			for _, substr := range tt.contains {
+				assert.Contains(t, result, substr)
+			}
+			for _, substr := range tt.notContains {
+				assert.NotContains(t, result, substr)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Package pager resolves the {{pager}} placeholder with the links to the newer and older posts of the page section.
+package pager
+
+import (
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Link is an adjacent post of the page, linked relatively to the page.
+// The zero Link stands for no post.
+type Link struct {
+	Href  string
+	Title string
+}
+
+// Substituter resolves the {{pager}} placeholder with a <nav> linking to the newer and older posts,
+// e.g. ← Newer post · Older post →. Pages without adjacent posts, such as section indexes, have no pager.
+type Substituter struct {
+	newer Link
+	older Link
+}
+
+// NewSubstituer creates a pager substituter linking to the newer and older posts, left out when zero.
+func NewSubstituer(newer, older Link) Substituter {
+	return Substituter{newer: newer, older: older}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{pager}}"
+}
+
+func (s Substituter) Resolve(_ string) (string, error) {
+	links := make([]string, 0, 2)
+	if s.newer.Href != "" {
+		links = append(links, fmt.Sprintf(`<a href="%s" rel="next" class="hover:underline">← %s</a>`, s.newer.Href, html.EscapeString(s.newer.Title)))
+	}
+	if s.older.Href != "" {
+		links = append(links, fmt.Sprintf(`<a href="%s" rel="prev" class="ml-auto hover:underline">%s →</a>`, s.older.Href, html.EscapeString(s.older.Title)))
+	}
+	if len(links) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf(`<nav aria-label="posts" class="flex justify-between gap-4 text-sm">%s</nav>`, strings.Join(links, "\n    ")), nil
+}
@@ -0,0 +1,67 @@
+package pager
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	if got := NewSubstituer(Link{}, Link{}).Placeholder(); got != "{{pager}}" {
+		t.Errorf("Placeholder() = %q, want %q", got, "{{pager}}")
+	}
+}
+
+func TestSubstituter_Resolve(t *testing.T) {
+	newer := Link{Href: "third.html", Title: "Third & last"}
+	older := Link{Href: "first.html", Title: "First"}
+
+	tests := []struct {
+		name         string
+		newer, older Link
+		want         []string
+		notWant      []string
+	}{
+		{
+			name:    "first post links to the older post only",
+			older:   older,
+			want:    []string{`<a href="first.html" rel="prev" class="ml-auto hover:underline">First →</a>`},
+			notWant: []string{`rel="next"`, `href=""`},
+		},
+		{
+			name:  "middle post links to both posts",
+			newer: newer,
+			older: older,
+			want: []string{
+				`<a href="third.html" rel="next" class="hover:underline">← Third &amp; last</a>`,
+				`<a href="first.html" rel="prev" class="ml-auto hover:underline">First →</a>`,
+			},
+		},
+		{
+			name:    "last post links to the newer post only",
+			newer:   newer,
+			want:    []string{`<a href="third.html" rel="next" class="hover:underline">← Third &amp; last</a>`},
+			notWant: []string{`rel="prev"`, `href=""`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.newer, tt.older).Resolve("")
+			assert.NoError(t, err)
+			assert.Contains(t, got, `<nav aria-label="posts"`)
+			for _, want := range tt.want {
+				assert.Contains(t, got, want)
+			}
+			for _, unwanted := range tt.notWant {
+				assert.NotContains(t, got, unwanted)
+			}
+		})
+	}
+}
+
+func TestSubstituter_ResolveWithoutPosts(t *testing.T) {
+	got, err := NewSubstituer(Link{}, Link{}).Resolve("")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
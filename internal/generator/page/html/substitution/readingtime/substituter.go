@@ -0,0 +1,47 @@
+// Package readingtime resolves the {{readingtime}} placeholder with an estimate of the time needed to read the page.
+package readingtime
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/htmltext"
+)
+
+// DefaultWordsPerMinute is the reading speed used when none is configured.
+const DefaultWordsPerMinute = 200
+
+var codeBlockRe = regexp.MustCompile(`(?s)<pre[^>]*>.*?</pre>`)
+
+// Substituter resolves the {{readingtime}} placeholder with the page word count divided by WordsPerMinute,
+// rounded up to whole minutes, e.g. "5 min". Pages always take at least one minute to read.
+// Code blocks are not counted unless IncludeCode is set.
+type Substituter struct {
+	WordsPerMinute int
+	IncludeCode    bool
+}
+
+// NewSubstituer creates a reading time substituter, falling back to DefaultWordsPerMinute when wordsPerMinute is not positive.
+func NewSubstituer(wordsPerMinute int, includeCode bool) Substituter {
+	if wordsPerMinute <= 0 {
+		wordsPerMinute = DefaultWordsPerMinute
+	}
+	return Substituter{WordsPerMinute: wordsPerMinute, IncludeCode: includeCode}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{readingtime}}"
+}
+
+func (s Substituter) Resolve(content string) (string, error) {
+	minutes := (s.countWords(content) + s.WordsPerMinute - 1) / s.WordsPerMinute
+	return fmt.Sprintf("%d min", max(minutes, 1)), nil
+}
+
+func (s Substituter) countWords(content string) int {
+	if !s.IncludeCode {
+		content = codeBlockRe.ReplaceAllString(content, " ")
+	}
+	return len(strings.Fields(htmltext.StripTags(content, " ")))
+}
@@ -0,0 +1,57 @@
+package readingtime
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	s := NewSubstituer(0, false)
+	if s.Placeholder() != "{{readingtime}}" {
+		t.Errorf("Placeholder() = %q, want %q", s.Placeholder(), "{{readingtime}}")
+	}
+}
+
+func TestNewSubstituer_DefaultWordsPerMinute(t *testing.T) {
+	if s := NewSubstituer(0, false); s.WordsPerMinute != DefaultWordsPerMinute {
+		t.Errorf("WordsPerMinute = %d, want %d", s.WordsPerMinute, DefaultWordsPerMinute)
+	}
+}
+
+func TestSubstituter_Resolve(t *testing.T) {
+	words := func(n int) string {
+		return strings.TrimSpace(strings.Repeat("word ", n))
+	}
+	code := "<pre><code>" + words(300) + "</code></pre>"
+
+	tests := []struct {
+		name        string
+		wpm         int
+		includeCode bool
+		content     string
+		want        string
+	}{
+		{name: "empty page", content: "", want: "1 min"},
+		{name: "near-empty page", content: "<h1>Title</h1>", want: "1 min"},
+		{name: "exactly one minute", content: "<p>" + words(200) + "</p>", want: "1 min"},
+		{name: "rounds up", content: "<p>" + words(201) + "</p>", want: "2 min"},
+		{name: "tags do not count as words", content: `<p><a href="x.html">` + words(200) + `</a></p><img src="a.png" alt="a b c">`, want: "1 min"},
+		{name: "tags separate words", content: "<p>" + words(200) + "</p><p>" + words(1) + "</p>", want: "2 min"},
+		{name: "custom speed", wpm: 100, content: "<p>" + words(250) + "</p>", want: "3 min"},
+		{name: "code blocks skipped by default", content: "<p>" + words(10) + "</p>" + code, want: "1 min"},
+		{name: "highlighted code blocks skipped", content: `<pre tabindex="0" style="color:#000"><code class="language-go"><span>` + words(300) + "</span></code></pre>", want: "1 min"},
+		{name: "code blocks included on demand", includeCode: true, content: "<p>" + words(10) + "</p>" + code, want: "2 min"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(tt.wpm, tt.includeCode).Resolve(tt.content)
+			if err != nil {
+				t.Fatalf("Resolve() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
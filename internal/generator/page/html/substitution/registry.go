@@ -3,27 +3,215 @@ package substitution
 import (
 	"fmt"
 	"strings"
+	"time"
 
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/bodyclass"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/breadcrumb"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/canonical"
 	"github.com/tjnvr/blog/internal/generator/page/html/substitution/content"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/copyright"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/date"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/description"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/excerpt"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/fields"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/headextra"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/lastmod"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/math"
 	"github.com/tjnvr/blog/internal/generator/page/html/substitution/navigation"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/noscript"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/og"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/outline"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/pager"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/readingtime"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/sitevars"
 	"github.com/tjnvr/blog/internal/generator/page/html/substitution/summary"
 	"github.com/tjnvr/blog/internal/generator/page/html/substitution/title"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/toc"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
 	"github.com/tjnvr/blog/internal/generator/section"
 )
 
-// Registry manages substitutions and applies them to templates
-type Registry struct {
-	substitutions []Substituer
+type (
+	// Registry manages substitutions and applies them to templates
+	Registry struct {
+		substitutions []Substituer
+	}
+
+	// Option configures the default substituters created by NewRegistry
+	Option func(*options)
+
+	options struct {
+		noscriptFallbacks []noscript.Fallback
+		dateLayout        string
+		fs                filesystem.FileSystem
+		tocMinLevel       int
+		tocMaxLevel       int
+		homeLabel         string
+		pageURL           string
+		defaultImage      string
+		wordsPerMinute    int
+		readingTimeCode   bool
+		mathHead          string
+		newerPost         pager.Link
+		olderPost         pager.Link
+		lastModLayout     string
+		lastModSource     lastmod.Source
+		headExtra         string
+		pageDepth         int
+		navActiveClass    string
+		copyrightStart    int
+		substituters      []Substituer
+		mdExtensions      []string
+		siteVariables     map[string]string
+		strictSiteVars    bool
+	}
+)
+
+// WithNoscriptFallbacks returns an Option that renders the given fallbacks in place of {{noscript}}.
+func WithNoscriptFallbacks(fallbacks ...noscript.Fallback) Option {
+	return func(o *options) { o.noscriptFallbacks = append(o.noscriptFallbacks, fallbacks...) }
+}
+
+// WithDateLayout returns an Option that sets the layout used to render {{date}}.
+func WithDateLayout(layout string) Option {
+	return func(o *options) { o.dateLayout = layout }
+}
+
+// WithFileSystem returns an Option that sets the filesystem substituers read page sources from.
+func WithFileSystem(fs filesystem.FileSystem) Option {
+	return func(o *options) { o.fs = fs }
+}
+
+// WithTOCLevels returns an Option that sets the heading levels listed by {{toc}}.
+func WithTOCLevels(minLevel, maxLevel int) Option {
+	return func(o *options) { o.tocMinLevel, o.tocMaxLevel = minLevel, maxLevel }
+}
+
+// WithHomeLabel returns an Option that sets the label of the home link in {{navigation}} and {{breadcrumb}}.
+func WithHomeLabel(label string) Option {
+	return func(o *options) { o.homeLabel = label }
+}
+
+// WithPageURL returns an Option that sets the URL the page is served at, used by {{og_meta}} and {{canonical}}.
+func WithPageURL(url string) Option {
+	return func(o *options) { o.pageURL = url }
+}
+
+// WithDefaultImage returns an Option that sets the preview image of pages without image in {{og_meta}}.
+func WithDefaultImage(src string) Option {
+	return func(o *options) { o.defaultImage = src }
+}
+
+// WithReadingSpeed returns an Option that sets the words per minute used to estimate {{readingtime}}.
+func WithReadingSpeed(wordsPerMinute int) Option {
+	return func(o *options) { o.wordsPerMinute = wordsPerMinute }
+}
+
+// WithReadingTimeIncludeCode returns an Option that counts code blocks toward {{readingtime}}.
+func WithReadingTimeIncludeCode(include bool) Option {
+	return func(o *options) { o.readingTimeCode = include }
+}
+
+// WithMathHead returns an Option that sets the tags loading the math renderer of pages with math in {{math}}.
+func WithMathHead(head string) Option {
+	return func(o *options) { o.mathHead = head }
+}
+
+// WithAdjacentPosts returns an Option that sets the newer and older posts linked by {{pager}}, left out when zero.
+func WithAdjacentPosts(newer, older pager.Link) Option {
+	return func(o *options) { o.newerPost, o.olderPost = newer, older }
+}
+
+// WithLastModLayout returns an Option that sets the layout used to render {{lastmod}}.
+func WithLastModLayout(layout string) Option {
+	return func(o *options) { o.lastModLayout = layout }
+}
+
+// WithLastModSource returns an Option that sets where {{lastmod}} reads the last change of the page from,
+// git by default. A nil source only uses the file modification time.
+func WithLastModSource(source lastmod.Source) Option {
+	return func(o *options) { o.lastModSource = source }
+}
+
+// WithHeadExtra returns an Option that sets the {{head_extra}} HTML of the pages without head_extra front matter.
+func WithHeadExtra(head string) Option {
+	return func(o *options) { o.headExtra = head }
+}
+
+// WithPageDepth returns an Option that sets the number of directories between the page and its section directory,
+// for the navigation and breadcrumb links of pages output in a directory of their own.
+func WithPageDepth(depth int) Option {
+	return func(o *options) { o.pageDepth = depth }
+}
+
+// WithNavigationActiveClass returns an Option that sets the class of the navigation link to the current section,
+// navigation.DefaultActiveClass when empty.
+func WithNavigationActiveClass(class string) Option {
+	return func(o *options) { o.navActiveClass = class }
+}
+
+// WithCopyrightStartYear returns an Option that resolves {{copyright_year}} with the range from year to the current year,
+// e.g. 2019–2025, instead of the current year.
+func WithCopyrightStartYear(year int) Option {
+	return func(o *options) { o.copyrightStart = year }
+}
+
+// WithMarkdownExtensions returns an Option that replaces the links to the files with one of extensions,
+// markdown.DefaultExtensions when empty, with links to their page.
+func WithMarkdownExtensions(extensions ...string) Option {
+	return func(o *options) { o.mdExtensions = extensions }
+}
+
+// WithSiteVariables returns an Option that resolves the {{site.name}} placeholders with vars,
+// failing on the placeholders of unknown variables when strict is set, removing them otherwise.
+func WithSiteVariables(vars map[string]string, strict bool) Option {
+	return func(o *options) { o.siteVariables, o.strictSiteVars = vars, strict }
+}
+
+// WithSubstituters returns an Option that registers subs after the default substituters, in order.
+func WithSubstituters(subs ...Substituer) Option {
+	return func(o *options) { o.substituters = append(o.substituters, subs...) }
 }
 
 // NewRegistry creates a new substitution registry with default substituters
-func NewRegistry(filePath, markdownSourcePath string, assetsPathTranslater, markdownPathTranslater content.PathTranslater, sections []section.Section, currentSection string) *Registry {
-	return NewRegistryWithSubstituters(
-		content.NewSubstituer(filePath, markdownSourcePath, assetsPathTranslater, markdownPathTranslater),
+func NewRegistry(filePath, markdownSourcePath string, assetsPathTranslater, markdownPathTranslater content.PathTranslater, sections []section.Section, currentSection string, opts ...Option) *Registry {
+	o := options{
+		fs:            filesystem.NewOSFileSystem(),
+		lastModSource: lastmod.GitSource{},
+		tocMinLevel:   toc.DefaultMinLevel,
+		tocMaxLevel:   toc.DefaultMaxLevel,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	contentSubstituer := content.NewSubstituer(filePath, markdownSourcePath, assetsPathTranslater, markdownPathTranslater, o.fs).
+		WithMarkdownExtensions(o.mdExtensions...)
+	return NewRegistryWithSubstituters(append([]Substituer{
+		contentSubstituer,
+		excerpt.NewSubstituer(contentSubstituer),
 		summary.NewSubstituer(),
+		outline.NewSubstituer(),
+		toc.NewSubstituer(o.tocMinLevel, o.tocMaxLevel),
 		title.NewSubstituer(),
-		navigation.NewSubstituer(sections, currentSection),
-	)
+		navigation.NewSubstituer(filePath, sections, currentSection, o.homeLabel).WithDepth(o.pageDepth).WithActiveClass(o.navActiveClass),
+		breadcrumb.NewSubstituer(filePath, sections, currentSection, o.homeLabel).WithDepth(o.pageDepth),
+		bodyclass.NewSubstituer(currentSection),
+		noscript.NewSubstituer(o.noscriptFallbacks...),
+		date.NewSubstituer(markdownSourcePath, o.dateLayout, o.fs),
+		lastmod.NewSubstituer(markdownSourcePath, o.lastModLayout, o.lastModSource, o.fs),
+		description.NewSubstituer(),
+		og.NewSubstituer(o.pageURL, o.defaultImage),
+		canonical.NewSubstituer(o.pageURL),
+		readingtime.NewSubstituer(o.wordsPerMinute, o.readingTimeCode),
+		math.NewSubstituer(o.mathHead),
+		headextra.NewSubstituer(o.headExtra),
+		copyright.NewSubstituer(o.copyrightStart, time.Now()),
+		sitevars.NewSubstituer(o.siteVariables, o.strictSiteVars),
+		fields.NewSubstituer(),
+		pager.NewSubstituer(o.newerPost, o.olderPost),
+	}, o.substituters...)...)
 }
 
 // NewRegistryWithSubstituters creates a registry with custom substituters
@@ -34,10 +222,41 @@ func NewRegistryWithSubstituters(subs ...Substituer) *Registry {
 }
 
 // Apply applies all registered substitutions in the template at placeholder with content value resolved
-func (r Registry) Apply(template, content string) (string, error) {
+// The page front matter is handed to the substituers depending on it.
+// Substituers whose placeholder is absent from the template are not resolved.
+func (r Registry) Apply(template, content string, fm frontmatter.Frontmatter) (string, error) {
 	result := template
 	for _, s := range r.substitutions {
-		resolution, err := s.Resolve(content)
+		if !strings.Contains(result, s.Placeholder()) {
+			continue
+		}
+
+		if fts, ok := s.(FrontmatterTemplateSubstituer); ok {
+			resolved, err := fts.ResolveFrontmatterTemplate(result, fm)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve substitution: %w", err)
+			}
+			result = resolved
+			continue
+		}
+		if ts, ok := s.(TemplateSubstituer); ok {
+			resolved, err := ts.ResolveTemplate(result)
+			if err != nil {
+				return "", fmt.Errorf("failed to resolve substitution: %w", err)
+			}
+			result = resolved
+			continue
+		}
+
+		var (
+			resolution string
+			err        error
+		)
+		if fs, ok := s.(FrontmatterSubstituer); ok {
+			resolution, err = fs.ResolveFrontmatter(content, fm)
+		} else {
+			resolution, err = s.Resolve(content)
+		}
 		if err != nil {
 			return "", fmt.Errorf("failed to resolve substitution: %w", err)
 		}
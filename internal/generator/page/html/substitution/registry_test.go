@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/noscript"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
 	"github.com/tjnvr/blog/internal/generator/section"
 )
 
@@ -30,8 +34,20 @@ func TestNewRegistry(t *testing.T) {
 		t.Fatal("NewRegistry() returned nil")
 		return
 	}
-	if len(r.substitutions) != 4 {
-		t.Errorf("NewRegistry() should have 4 default substituters, got %d", len(r.substitutions))
+	if len(r.substitutions) != 22 {
+		t.Errorf("NewRegistry() should have 22 default substituters, got %d", len(r.substitutions))
+	}
+}
+
+func TestNewRegistry_WithSubstituters(t *testing.T) {
+	s1 := fakeSubstituter{placeholder: "{{a}}", resolveFunc: func(string) (string, error) { return "A", nil }}
+	s2 := fakeSubstituter{placeholder: "{{b}}", resolveFunc: func(string) (string, error) { return "B", nil }}
+	r := NewRegistry("output.html", "source.md", nil, nil, nil, "", WithSubstituters(s1), WithSubstituters(s2))
+	if len(r.substitutions) != 24 {
+		t.Fatalf("expected 22 default and 2 custom substituters, got %d", len(r.substitutions))
+	}
+	if r.substitutions[22].Placeholder() != "{{a}}" || r.substitutions[23].Placeholder() != "{{b}}" {
+		t.Errorf("custom substituters should follow the defaults in registration order")
 	}
 }
 
@@ -142,7 +158,7 @@ func TestRegistry_Apply(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := NewRegistryWithSubstituters(tt.subs...)
-			got, err := r.Apply(tt.template, tt.content)
+			got, err := r.Apply(tt.template, tt.content, frontmatter.Frontmatter{})
 			if tt.wantErr {
 				if err == nil {
 					t.Error("Apply() expected error, got nil")
@@ -164,7 +180,7 @@ func TestRegistry_Apply_WithDefaultSubstituters(t *testing.T) {
 	template := `<title>{{title}}</title><div>{{navigation}}</div><body>{{content}}</body>`
 	content := `<h1>Test Title</h1><p>Hello world</p>`
 
-	result, err := r.Apply(template, content)
+	result, err := r.Apply(template, content, frontmatter.Frontmatter{})
 	if err != nil {
 		t.Fatalf("Apply() unexpected error: %v", err)
 	}
@@ -188,7 +204,7 @@ func TestRegistry_Apply_SummarySubstitution(t *testing.T) {
 		`<h2 id="intro"><a href="#intro" class="heading-anchor">#</a>Introduction</h2>` +
 		`<h3 id="details"><a href="#details" class="heading-anchor">#</a>Details</h3>`
 
-	result, err := r.Apply(template, content)
+	result, err := r.Apply(template, content, frontmatter.Frontmatter{})
 	if err != nil {
 		t.Fatalf("Apply() unexpected error: %v", err)
 	}
@@ -203,3 +219,150 @@ func TestRegistry_Apply_SummarySubstitution(t *testing.T) {
 		t.Errorf("expected summary link to #details, got %q", result)
 	}
 }
+
+func TestRegistry_Apply_NoscriptFallbacks(t *testing.T) {
+	template := `<head>{{noscript}}</head><body>{{content}}</body>`
+	content := `<h1>Title</h1>`
+
+	t.Run("no fallback by default", func(t *testing.T) {
+		r := NewRegistry("output.html", "source.md", nil, nil, nil, "")
+		result, err := r.Apply(template, content, frontmatter.Frontmatter{})
+		if err != nil {
+			t.Fatalf("Apply() unexpected error: %v", err)
+		}
+		if strings.Contains(result, "<noscript>") || strings.Contains(result, "{{noscript}}") {
+			t.Errorf("expected no noscript element, got %q", result)
+		}
+	})
+
+	t.Run("dark mode fallback", func(t *testing.T) {
+		r := NewRegistry("output.html", "source.md", nil, nil, nil, "", WithNoscriptFallbacks(noscript.DarkMode))
+		result, err := r.Apply(template, content, frontmatter.Frontmatter{})
+		if err != nil {
+			t.Fatalf("Apply() unexpected error: %v", err)
+		}
+		if !strings.Contains(result, "<head><noscript><style>") {
+			t.Errorf("expected dark mode noscript fallback in head, got %q", result)
+		}
+	})
+}
+
+func TestRegistry_Apply_TOC(t *testing.T) {
+	template := `<aside>{{toc}}</aside><body>{{content}}</body>`
+	content := `<h2 id="intro">Intro<a href="#intro" class="heading-anchor">#</a></h2>` +
+		`<h3 id="details">Details<a href="#details" class="heading-anchor">#</a></h3>` +
+		`<h4 id="deep">Deep<a href="#deep" class="heading-anchor">#</a></h4>`
+
+	t.Run("h2 and h3 by default", func(t *testing.T) {
+		r := NewRegistry("output.html", "source.md", nil, nil, nil, "")
+		result, err := r.Apply(template, content, frontmatter.Frontmatter{})
+		if err != nil {
+			t.Fatalf("Apply() unexpected error: %v", err)
+		}
+		if !strings.Contains(result, `<a href="#details">`) || strings.Contains(result, `<a href="#deep">`) {
+			t.Errorf("expected a toc of h2 and h3 headings, got %q", result)
+		}
+	})
+
+	t.Run("configured levels", func(t *testing.T) {
+		r := NewRegistry("output.html", "source.md", nil, nil, nil, "", WithTOCLevels(2, 4))
+		result, err := r.Apply(template, content, frontmatter.Frontmatter{})
+		if err != nil {
+			t.Fatalf("Apply() unexpected error: %v", err)
+		}
+		if !strings.Contains(result, `<a href="#deep">`) {
+			t.Errorf("expected h4 headings in the toc, got %q", result)
+		}
+	})
+}
+
+func TestRegistry_Apply_ReadingTime(t *testing.T) {
+	template := `<span>{{readingtime}}</span><body>{{content}}</body>`
+	content := "<p>" + strings.Repeat("word ", 150) + "</p><pre><code>" + strings.Repeat("code ", 100) + "</code></pre>"
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{name: "default speed without code", want: "<span>1 min</span>"},
+		{name: "configured speed", opts: []Option{WithReadingSpeed(100)}, want: "<span>2 min</span>"},
+		{name: "code included", opts: []Option{WithReadingTimeIncludeCode(true)}, want: "<span>2 min</span>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRegistry("output.html", "source.md", nil, nil, nil, "", tt.opts...)
+			result, err := r.Apply(template, content, frontmatter.Frontmatter{})
+			if err != nil {
+				t.Fatalf("Apply() unexpected error: %v", err)
+			}
+			if !strings.HasPrefix(result, tt.want) {
+				t.Errorf("Apply() = %q, want prefix %q", result, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegistry_Apply_Math(t *testing.T) {
+	template := `<head>{{math}}</head><body>{{content}}</body>`
+	content := `<p><span class="math inline">\(x\)</span></p>`
+
+	r := NewRegistry("output.html", "source.md", nil, nil, nil, "", WithMathHead(`<script src="/scripts/katex.js"></script>`))
+	result, err := r.Apply(template, content, frontmatter.Frontmatter{})
+	if err != nil {
+		t.Fatalf("Apply() unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(result, `<head><script src="/scripts/katex.js"></script></head>`) {
+		t.Errorf("expected the configured math head, got %q", result)
+	}
+}
+
+func TestRegistry_Apply_Date(t *testing.T) {
+	fs := filesystem.NewMemoryFileSystem()
+	fs.AddFile("source.md", []byte("# Title"))
+	fs.SetModTime("source.md", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC))
+	template := `<time>{{date}}</time><body>{{content}}</body>`
+	content := `<h1>Title</h1>`
+
+	t.Run("front matter date", func(t *testing.T) {
+		r := NewRegistry("output.html", "source.md", nil, nil, nil, "", WithFileSystem(fs), WithDateLayout("02/01/2006"))
+		result, err := r.Apply(template, content, frontmatter.Frontmatter{Date: time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC)})
+		if err != nil {
+			t.Fatalf("Apply() unexpected error: %v", err)
+		}
+		if !strings.Contains(result, "<time>24/01/2026</time>") {
+			t.Errorf("expected front matter date, got %q", result)
+		}
+	})
+
+	t.Run("modification time fallback", func(t *testing.T) {
+		r := NewRegistry("output.html", "source.md", nil, nil, nil, "", WithFileSystem(fs))
+		result, err := r.Apply(template, content, frontmatter.Frontmatter{})
+		if err != nil {
+			t.Fatalf("Apply() unexpected error: %v", err)
+		}
+		if !strings.Contains(result, "<time>2026-03-15</time>") {
+			t.Errorf("expected modification date, got %q", result)
+		}
+	})
+}
+
+func TestRegistry_Apply_SkipsAbsentPlaceholders(t *testing.T) {
+	called := false
+	r := NewRegistryWithSubstituters(fakeSubstituter{
+		placeholder: "{{absent}}",
+		resolveFunc: func(string) (string, error) {
+			called = true
+			return "", fmt.Errorf("should not be resolved")
+		},
+	})
+
+	result, err := r.Apply("no placeholder", "content", frontmatter.Frontmatter{})
+	if err != nil {
+		t.Fatalf("Apply() unexpected error: %v", err)
+	}
+	if called || result != "no placeholder" {
+		t.Errorf("substituer with absent placeholder should not be resolved, got %q", result)
+	}
+}
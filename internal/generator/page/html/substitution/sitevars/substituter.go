@@ -0,0 +1,60 @@
+// Package sitevars resolves the {{site.name}} placeholders with the site-wide variables, e.g. {{site.author}}.
+package sitevars
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+)
+
+// placeholderRe matches the {{site.name}} placeholders, capturing the variable name.
+var placeholderRe = regexp.MustCompile(`\{\{site\.([A-Za-z0-9_.-]+)\}\}`)
+
+// Substituter resolves the {{site.name}} placeholders with the HTML-escaped value of the name variable.
+// Placeholders of unknown variables are removed, or fail the page when the substituter is strict.
+type Substituter struct {
+	vars   map[string]string
+	strict bool
+}
+
+// NewSubstituer creates a substituter of the vars variables, failing on unknown variables when strict is set.
+func NewSubstituer(vars map[string]string, strict bool) Substituter {
+	return Substituter{vars: vars, strict: strict}
+}
+
+// Placeholder returns the prefix shared by the placeholders of the variables.
+func (s Substituter) Placeholder() string {
+	return "{{site."
+}
+
+// Resolve resolves no single placeholder, the variables are resolved by ResolveTemplate.
+func (s Substituter) Resolve(_ string) (string, error) {
+	return "", nil
+}
+
+// ResolveTemplate replaces the {{site.name}} placeholders of template with their variable.
+func (s Substituter) ResolveTemplate(template string) (string, error) {
+	var err error
+	result := placeholderRe.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := placeholderRe.FindStringSubmatch(placeholder)[1]
+		value, ok := s.vars[name]
+		if !ok && s.strict && err == nil {
+			err = fmt.Errorf("unknown site variable %q", name)
+		}
+		return html.EscapeString(value)
+	})
+	if err != nil {
+		return "", err
+	}
+	return result, nil
+}
+
+// Parse reads the variables of a JSON object of strings, e.g. {"title": "My blog", "author": "Me"}.
+func Parse(data []byte) (map[string]string, error) {
+	var vars map[string]string
+	if err := json.Unmarshal(data, &vars); err != nil {
+		return nil, fmt.Errorf("parsing site variables: %w", err)
+	}
+	return vars, nil
+}
@@ -0,0 +1,73 @@
+package sitevars
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSubstituter_ResolveTemplate(t *testing.T) {
+	vars := map[string]string{"title": "Tom & Jerry", "author": "Tim", "social.mastodon": "@tim"}
+
+	tests := []struct {
+		name     string
+		strict   bool
+		template string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "known variables",
+			template: "<title>{{site.title}}</title><p>{{site.author}} {{site.social.mastodon}} {{site.author}}</p>",
+			want:     "<title>Tom &amp; Jerry</title><p>Tim @tim Tim</p>",
+		},
+		{
+			name:     "unknown variable removed",
+			template: "<p>{{site.author}}{{site.missing}}</p>",
+			want:     "<p>Tim</p>",
+		},
+		{
+			name:     "unknown variable fails when strict",
+			strict:   true,
+			template: "<p>{{site.missing}}</p>",
+			wantErr:  `unknown site variable "missing"`,
+		},
+		{
+			name:     "other placeholders left",
+			strict:   true,
+			template: "{{content}} {{site}}",
+			want:     "{{content}} {{site}}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSubstituer(vars, tt.strict).ResolveTemplate(tt.template)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ResolveTemplate() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ResolveTemplate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveTemplate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	vars, err := Parse([]byte(`{"title": "My blog", "author": "Tim"}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if vars["title"] != "My blog" || vars["author"] != "Tim" {
+		t.Errorf("Parse() = %v", vars)
+	}
+
+	if _, err := Parse([]byte(`{"year": 2026}`)); err == nil {
+		t.Error("Parse() of a non-string value should fail")
+	}
+}
@@ -1,9 +1,38 @@
 package substitution
 
-type Substituer interface {
-	// The pattern to be replaced by the Resolve return
-	Placeholder() string
+import "github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
 
-	// Returns the new content with substitutions made
-	Resolve(content string) (string, error)
-}
+type (
+	Substituer interface {
+		// The pattern to be replaced by the Resolve return
+		Placeholder() string
+
+		// Returns the new content with substitutions made
+		Resolve(content string) (string, error)
+	}
+
+	// FrontmatterSubstituer is implemented by substituers whose resolution depends on the page front matter.
+	// The registry calls ResolveFrontmatter instead of Resolve for them.
+	FrontmatterSubstituer interface {
+		Substituer
+
+		ResolveFrontmatter(content string, fm frontmatter.Frontmatter) (string, error)
+	}
+
+	// TemplateSubstituer is implemented by substituers resolving several placeholders starting with their Placeholder,
+	// e.g. {{site.title}} and {{site.author}}. The registry calls ResolveTemplate with the whole page instead of Resolve.
+	TemplateSubstituer interface {
+		Substituer
+
+		ResolveTemplate(template string) (string, error)
+	}
+
+	// FrontmatterTemplateSubstituer is implemented by substituers resolving several placeholders starting with their
+	// Placeholder from the page front matter, e.g. {{fm.author}} and {{fm.featured}}.
+	// The registry calls ResolveFrontmatterTemplate with the whole page instead of Resolve.
+	FrontmatterTemplateSubstituer interface {
+		Substituer
+
+		ResolveFrontmatterTemplate(template string, fm frontmatter.Frontmatter) (string, error)
+	}
+)
@@ -2,12 +2,10 @@ package summary
 
 import (
 	"fmt"
-	"regexp"
-	"strconv"
 	"strings"
-)
 
-var headingRe = regexp.MustCompile(`<h([2-6])[^>]*id="([^"]+)"[^>]*>([^<]+)(?:<a[^>]*>[^<]*</a>)?</h[2-6]>`)
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/heading"
+)
 
 func textSizeClass(depth int) string {
 	switch depth {
@@ -30,48 +28,36 @@ func (s Substituter) Placeholder() string {
 }
 
 func (s Substituter) Resolve(content string) (string, error) {
-	matches := headingRe.FindAllStringSubmatch(content, -1)
-	if len(matches) == 0 {
+	items := heading.Collect(content)
+	if len(items) == 0 {
 		return "", nil
 	}
 
-	type heading struct {
-		level int
-		id    string
-		text  string
-	}
-
-	items := make([]heading, len(matches))
-	for i, m := range matches {
-		level, _ := strconv.Atoi(m[1])
-		items[i] = heading{level: level, id: m[2], text: strings.TrimSpace(m[3])}
-	}
-
 	var sb strings.Builder
 	sb.WriteString(`<hr class="border-gray-200 dark:border-gray-700">`)
 	sb.WriteString("<nav>")
 
-	prevLevel := items[0].level - 1
+	prevLevel := items[0].Level - 1
 	depth := 0
 
 	for _, item := range items {
 		switch {
-		case item.level > prevLevel:
-			for i := prevLevel; i < item.level; i++ {
+		case item.Level > prevLevel:
+			for i := prevLevel; i < item.Level; i++ {
 				sb.WriteString(`<ul>`)
 				depth++
 			}
-		case item.level == prevLevel:
+		case item.Level == prevLevel:
 			sb.WriteString("</li>")
 		default:
 			sb.WriteString("</li>")
-			for i := item.level; i < prevLevel; i++ {
+			for i := item.Level; i < prevLevel; i++ {
 				sb.WriteString("</ul></li>")
 				depth--
 			}
 		}
-		fmt.Fprintf(&sb, `<li><a href="#%s" class="%s">%s</a>`, item.id, textSizeClass(depth), item.text)
-		prevLevel = item.level
+		fmt.Fprintf(&sb, `<li><a href="#%s" class="%s">%s</a>`, item.ID, textSizeClass(depth), item.Text)
+		prevLevel = item.Level
 	}
 
 	sb.WriteString("</li>")
@@ -2,10 +2,14 @@ package title
 
 import (
 	"fmt"
+	"html"
 	"regexp"
+
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
 )
 
-// Substituter resolves {{title}} placeholder
+// Substituter resolves {{title}} placeholder.
+// The title is HTML-escaped, so that characters such as < or & in a heading keep the <title> element well formed.
 type Substituter struct {
 }
 
@@ -22,8 +26,17 @@ func (t Substituter) Resolve(content string) (string, error) {
 	re := regexp.MustCompile(`<h1[^>]*>([^<]+)(?:<a[^>]*>[^<]*</a>)?</h1>`)
 	match := re.FindSubmatch([]byte(content))
 	if len(match) >= 2 {
-		return string(match[1]), nil
+		// The converted heading is escaped already, except for raw HTML: unescape first to not escape twice
+		return html.EscapeString(html.UnescapeString(string(match[1]))), nil
 	}
 
 	return "", fmt.Errorf("could not find a page title")
 }
+
+// ResolveFrontmatter prefers the front matter title over the page <h1>.
+func (t Substituter) ResolveFrontmatter(content string, fm frontmatter.Frontmatter) (string, error) {
+	if fm.Title != "" {
+		return html.EscapeString(fm.Title), nil
+	}
+	return t.Resolve(content)
+}
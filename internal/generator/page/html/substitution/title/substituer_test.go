@@ -2,6 +2,8 @@ package title
 
 import (
 	"testing"
+
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
 )
 
 func TestSubstituer_Placeholder(t *testing.T) {
@@ -38,6 +40,16 @@ func TestSubstituer_Resolve(t *testing.T) {
 			content: `<h1>First</h1><h1>Second</h1>`,
 			want:    "First",
 		},
+		{
+			name:    "keeps the escaped characters of the converted heading",
+			content: `<h1 id="a-b-c">A &lt; B &amp; C</h1>`,
+			want:    "A &lt; B &amp; C",
+		},
+		{
+			name:    "escapes raw characters of the heading",
+			content: `<h1>Fish & "Chips"</h1>`,
+			want:    "Fish &amp; &#34;Chips&#34;",
+		},
 		{
 			name:    "returns error when no h1 found",
 			content: `<h2>Not a title</h2><p>Content</p>`,
@@ -74,3 +86,37 @@ func TestSubstituer_Resolve(t *testing.T) {
 		})
 	}
 }
+
+func TestSubstituer_ResolveFrontmatter(t *testing.T) {
+	s := NewSubstituer()
+
+	t.Run("front matter title wins over h1", func(t *testing.T) {
+		got, err := s.ResolveFrontmatter(`<h1>Heading</h1>`, frontmatter.Frontmatter{Title: "Front Matter Title"})
+		if err != nil {
+			t.Fatalf("ResolveFrontmatter() unexpected error: %v", err)
+		}
+		if got != "Front Matter Title" {
+			t.Errorf("ResolveFrontmatter() = %q, want %q", got, "Front Matter Title")
+		}
+	})
+
+	t.Run("falls back to h1 without front matter title", func(t *testing.T) {
+		got, err := s.ResolveFrontmatter(`<h1>Heading</h1>`, frontmatter.Frontmatter{})
+		if err != nil {
+			t.Fatalf("ResolveFrontmatter() unexpected error: %v", err)
+		}
+		if got != "Heading" {
+			t.Errorf("ResolveFrontmatter() = %q, want %q", got, "Heading")
+		}
+	})
+}
+
+func TestSubstituer_ResolveFrontmatterEscapesTitle(t *testing.T) {
+	got, err := NewSubstituer().ResolveFrontmatter(`<h1>Heading</h1>`, frontmatter.Frontmatter{Title: `A < B & "C"`})
+	if err != nil {
+		t.Fatalf("ResolveFrontmatter() unexpected error: %v", err)
+	}
+	if want := "A &lt; B &amp; &#34;C&#34;"; got != want {
+		t.Errorf("ResolveFrontmatter() = %q, want %q", got, want)
+	}
+}
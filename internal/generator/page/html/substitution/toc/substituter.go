@@ -0,0 +1,75 @@
+// Package toc resolves the {{toc}} placeholder with a nested list of in-page anchor links.
+package toc
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/heading"
+)
+
+const (
+	// DefaultMinLevel is the shallowest heading level listed by default.
+	DefaultMinLevel = 2
+	// DefaultMaxLevel is the deepest heading level listed by default.
+	DefaultMaxLevel = 3
+)
+
+// Substituter resolves the {{toc}} placeholder with a table of contents of the headings
+// between MinLevel and MaxLevel included.
+type Substituter struct {
+	MinLevel int
+	MaxLevel int
+}
+
+func NewSubstituer(minLevel, maxLevel int) Substituter {
+	return Substituter{MinLevel: minLevel, MaxLevel: maxLevel}
+}
+
+func (s Substituter) Placeholder() string {
+	return "{{toc}}"
+}
+
+func (s Substituter) Resolve(content string) (string, error) {
+	items := make([]heading.Heading, 0)
+	for _, h := range heading.Collect(content) {
+		if h.Level >= s.MinLevel && h.Level <= s.MaxLevel {
+			items = append(items, h)
+		}
+	}
+	if len(items) == 0 {
+		return "", nil
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`<nav class="toc" aria-label="Table of contents">`)
+
+	// levels holds the heading level of each open <ul>
+	levels := make([]int, 0)
+	for i, item := range items {
+		switch {
+		case i == 0:
+			sb.WriteString("<ul>")
+			levels = append(levels, item.Level)
+		case item.Level > levels[len(levels)-1]:
+			sb.WriteString("<ul>")
+			levels = append(levels, item.Level)
+		default:
+			sb.WriteString("</li>")
+			for len(levels) > 1 && item.Level < levels[len(levels)-1] {
+				sb.WriteString("</ul></li>")
+				levels = levels[:len(levels)-1]
+			}
+		}
+		fmt.Fprintf(&sb, `<li><a href="#%s">%s</a>`, item.ID, item.Text)
+	}
+
+	sb.WriteString("</li>")
+	for len(levels) > 1 {
+		sb.WriteString("</ul></li>")
+		levels = levels[:len(levels)-1]
+	}
+	sb.WriteString("</ul></nav>")
+
+	return sb.String(), nil
+}
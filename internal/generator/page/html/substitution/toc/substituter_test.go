@@ -0,0 +1,83 @@
+package toc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func h(level, id, text string) string {
+	return `<h` + level + ` id="` + id + `">` + text + `<a href="#` + id + `" class="heading-anchor">#</a></h` + level + `>`
+}
+
+func TestSubstituter_Placeholder(t *testing.T) {
+	s := NewSubstituer(DefaultMinLevel, DefaultMaxLevel)
+	if s.Placeholder() != "{{toc}}" {
+		t.Errorf("Placeholder() = %q, want %q", s.Placeholder(), "{{toc}}")
+	}
+}
+
+func TestSubstituter_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		minLevel int
+		maxLevel int
+		content  string
+		expected string
+	}{
+		{
+			name:     "flat list of h2",
+			minLevel: 2, maxLevel: 3,
+			content:  h("2", "a", "A") + h("2", "b", "B"),
+			expected: `<nav class="toc" aria-label="Table of contents"><ul><li><a href="#a">A</a></li><li><a href="#b">B</a></li></ul></nav>`,
+		},
+		{
+			name:     "h3 nested under h2",
+			minLevel: 2, maxLevel: 3,
+			content: h("2", "a", "A") + h("3", "a1", "A1") + h("3", "a2", "A2") + h("2", "b", "B"),
+			expected: `<nav class="toc" aria-label="Table of contents"><ul>` +
+				`<li><a href="#a">A</a><ul><li><a href="#a1">A1</a></li><li><a href="#a2">A2</a></li></ul></li>` +
+				`<li><a href="#b">B</a></li></ul></nav>`,
+		},
+		{
+			name:     "page ending on a nested heading closes every list",
+			minLevel: 2, maxLevel: 3,
+			content: h("2", "a", "A") + h("3", "a1", "A1"),
+			expected: `<nav class="toc" aria-label="Table of contents"><ul>` +
+				`<li><a href="#a">A</a><ul><li><a href="#a1">A1</a></li></ul></li></ul></nav>`,
+		},
+		{
+			name:     "headings outside the levels are left out",
+			minLevel: 2, maxLevel: 2,
+			content:  h("2", "a", "A") + h("3", "a1", "A1") + h("4", "a11", "A11"),
+			expected: `<nav class="toc" aria-label="Table of contents"><ul><li><a href="#a">A</a></li></ul></nav>`,
+		},
+		{
+			name:     "custom levels",
+			minLevel: 3, maxLevel: 4,
+			content: h("2", "a", "A") + h("3", "a1", "A1") + h("4", "a11", "A11"),
+			expected: `<nav class="toc" aria-label="Table of contents"><ul>` +
+				`<li><a href="#a1">A1</a><ul><li><a href="#a11">A11</a></li></ul></li></ul></nav>`,
+		},
+		{
+			name:     "no headings resolves to an empty string",
+			minLevel: 2, maxLevel: 3,
+			content:  `<p>No headings here.</p>`,
+			expected: "",
+		},
+		{
+			name:     "no heading within the levels resolves to an empty string",
+			minLevel: 2, maxLevel: 3,
+			content:  h("4", "deep", "Deep"),
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewSubstituer(tt.minLevel, tt.maxLevel).Resolve(tt.content)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
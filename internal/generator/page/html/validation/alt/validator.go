@@ -0,0 +1,51 @@
+package alt
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Validator checks that all images in HTML carry an alternative text
+type Validator struct {
+	// AllowEmptyAlt accepts alt="" for images intentionally marked as decorative
+	AllowEmptyAlt bool
+	imgRegex      *regexp.Regexp
+	altRegex      *regexp.Regexp
+	srcRegex      *regexp.Regexp
+}
+
+// NewValidator creates a new alt text validator rejecting empty alt attributes
+func NewValidator() *Validator {
+	return &Validator{
+		AllowEmptyAlt: false,
+		imgRegex:      regexp.MustCompile(`<img\b[^>]*>`),
+		altRegex:      regexp.MustCompile(`\salt(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'>/]+)))?`),
+		srcRegex:      regexp.MustCompile(`\ssrc\s*=\s*"([^"]*)"`),
+	}
+}
+
+// Validate checks the alt attribute of every img tag in the HTML content
+func (v *Validator) Validate(htmlPath, _ string, content []byte) []error {
+	var errs []error
+
+	for _, img := range v.imgRegex.FindAll(content, -1) {
+		src := "unknown source"
+		if m := v.srcRegex.FindSubmatch(img); m != nil {
+			src = string(m[1])
+		}
+
+		m := v.altRegex.FindSubmatch(img)
+		if m == nil {
+			errs = append(errs, fmt.Errorf("%s: image without alt text: %s", htmlPath, src))
+			continue
+		}
+
+		text := string(m[1]) + string(m[2]) + string(m[3])
+		if strings.TrimSpace(text) == "" && !v.AllowEmptyAlt {
+			errs = append(errs, fmt.Errorf("%s: image with empty alt text: %s", htmlPath, src))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,94 @@
+package alt
+
+import (
+	"testing"
+)
+
+func TestNewValidator(t *testing.T) {
+	v := NewValidator()
+	if v == nil {
+		t.Fatal("NewValidator returned nil")
+		return
+	}
+	if v.AllowEmptyAlt {
+		t.Error("AllowEmptyAlt should be false by default")
+	}
+}
+
+func TestValidator_Validate(t *testing.T) {
+	tests := []struct {
+		name          string
+		html          string
+		allowEmptyAlt bool
+		wantErrors    []string
+	}{
+		{
+			name: "image with alt text",
+			html: `<img src="/assets/cat.png" alt="A cat">`,
+		},
+		{
+			name:       "image without alt attribute",
+			html:       `<img src="/assets/cat.png">`,
+			wantErrors: []string{"page.html: image without alt text: /assets/cat.png"},
+		},
+		{
+			name:       "image with empty alt",
+			html:       `<img src="/assets/line.svg" alt="">`,
+			wantErrors: []string{"page.html: image with empty alt text: /assets/line.svg"},
+		},
+		{
+			name:       "image with blank alt",
+			html:       `<img alt="  " src="/assets/line.svg" />`,
+			wantErrors: []string{"page.html: image with empty alt text: /assets/line.svg"},
+		},
+		{
+			name:          "decorative image allowed",
+			html:          `<img src="/assets/line.svg" alt="">`,
+			allowEmptyAlt: true,
+		},
+		{
+			name:          "missing alt still rejected when empty alt is allowed",
+			html:          `<img src="/assets/cat.png">`,
+			allowEmptyAlt: true,
+			wantErrors:    []string{"page.html: image without alt text: /assets/cat.png"},
+		},
+		{
+			name: "single quoted and unquoted alt",
+			html: `<img src="/a.png" alt='A'><img src="/b.png" alt=B>`,
+		},
+		{
+			name:       "data-alt is not an alt attribute",
+			html:       `<img src="/a.png" data-alt="A">`,
+			wantErrors: []string{"page.html: image without alt text: /a.png"},
+		},
+		{
+			name: "multiple images",
+			html: `<img src="/a.png" alt="A"><img src="/b.png"><img src="/c.png" alt="">`,
+			wantErrors: []string{
+				"page.html: image without alt text: /b.png",
+				"page.html: image with empty alt text: /c.png",
+			},
+		},
+		{
+			name: "no images",
+			html: `<p>No images here</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator()
+			v.AllowEmptyAlt = tt.allowEmptyAlt
+
+			errs := v.Validate("page.html", "/build", []byte(tt.html))
+			if len(errs) != len(tt.wantErrors) {
+				t.Fatalf("Validate() errors = %v, want %v", errs, tt.wantErrors)
+			}
+			for i, err := range errs {
+				if err.Error() != tt.wantErrors[i] {
+					t.Errorf("Validate() error[%d] = %q, want %q", i, err.Error(), tt.wantErrors[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package class
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Validator checks that the classes of the HTML elements are known, e.g. to catch Tailwind utility typos such as text-4xs
+type Validator struct {
+	allowed    map[string]bool
+	classRegex *regexp.Regexp
+}
+
+// NewValidator creates a class validator accepting the allowed class names.
+// A class with variants, e.g. dark:hover:bg-gray-700, is also accepted when its utility, bg-gray-700, is allowed.
+func NewValidator(allowed []string) *Validator {
+	v := &Validator{
+		allowed:    make(map[string]bool, len(allowed)),
+		classRegex: regexp.MustCompile(`\sclass="([^"]*)"`),
+	}
+	for _, name := range allowed {
+		v.allowed[name] = true
+	}
+	return v
+}
+
+// ParseAllowlist returns the class names of a safelist file, separated by white space.
+// Lines starting with # are comments.
+func ParseAllowlist(data []byte) []string {
+	classes := make([]string, 0)
+	for line := range strings.Lines(string(data)) {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		classes = append(classes, strings.Fields(line)...)
+	}
+	return classes
+}
+
+// Validate reports each unknown class of the HTML content once
+func (v *Validator) Validate(htmlPath, _ string, content []byte) []error {
+	var errs []error
+
+	reported := make(map[string]bool)
+	for _, match := range v.classRegex.FindAllSubmatch(content, -1) {
+		for _, name := range strings.Fields(string(match[1])) {
+			if v.isAllowed(name) || reported[name] {
+				continue
+			}
+			reported[name] = true
+			errs = append(errs, fmt.Errorf("%s: unknown class %q", htmlPath, name))
+		}
+	}
+
+	return errs
+}
+
+func (v *Validator) isAllowed(name string) bool {
+	if v.allowed[name] {
+		return true
+	}
+	i := strings.LastIndex(name, ":")
+	return i >= 0 && v.allowed[name[i+1:]]
+}
@@ -0,0 +1,60 @@
+package class
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	v := NewValidator([]string{"text-4xl", "font-bold", "bg-gray-700", "heading-anchor"})
+
+	tests := []struct {
+		name       string
+		html       string
+		wantErrors []string
+	}{
+		{
+			name: "allowed classes",
+			html: `<h1 class="text-4xl font-bold">Title<a class="heading-anchor" href="#title">#</a></h1>`,
+		},
+		{
+			name: "allowed utility with variants",
+			html: `<button class="hover:bg-gray-700 dark:hover:bg-gray-700">Dark</button>`,
+		},
+		{
+			name:       "disallowed class",
+			html:       `<p class="text-4xs font-bold">Small</p>`,
+			wantErrors: []string{`page.html: unknown class "text-4xs"`},
+		},
+		{
+			name:       "disallowed class reported once",
+			html:       `<p class="text-4xs">A</p><p class="text-4xs">B</p><p class="dark:text-4xs">C</p>`,
+			wantErrors: []string{`page.html: unknown class "text-4xs"`, `page.html: unknown class "dark:text-4xs"`},
+		},
+		{
+			name: "empty class attribute",
+			html: `<p class="">Text</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := v.Validate("page.html", "", []byte(tt.html))
+			got := make([]string, 0, len(errs))
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if len(got) != len(tt.wantErrors) || (len(got) > 0 && !reflect.DeepEqual(got, tt.wantErrors)) {
+				t.Errorf("Validate() errors = %v, want %v", got, tt.wantErrors)
+			}
+		})
+	}
+}
+
+func TestParseAllowlist(t *testing.T) {
+	data := []byte("# typography\ntext-4xl font-bold\n\n  hover:underline\n")
+	want := []string{"text-4xl", "font-bold", "hover:underline"}
+	if got := ParseAllowlist(data); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseAllowlist() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+package id
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// Validator checks that the ids of the HTML elements are unique in the page, as fragment links only reach the first one
+type Validator struct {
+	idRegex *regexp.Regexp
+}
+
+// NewValidator creates a duplicate id validator.
+func NewValidator() *Validator {
+	return &Validator{
+		idRegex: regexp.MustCompile(`<[a-zA-Z][^>]*?\sid="([^"]*)"`),
+	}
+}
+
+// Validate reports each id used by several elements of the HTML content once
+func (v *Validator) Validate(htmlPath, _ string, content []byte) []error {
+	var errs []error
+
+	counts := make(map[string]int)
+	for _, match := range v.idRegex.FindAllSubmatch(content, -1) {
+		id := string(match[1])
+		counts[id]++
+		if counts[id] == 2 {
+			errs = append(errs, fmt.Errorf("%s: duplicate id %q", htmlPath, id))
+		}
+	}
+
+	return errs
+}
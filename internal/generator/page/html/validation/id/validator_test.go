@@ -0,0 +1,48 @@
+package id
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	v := NewValidator()
+
+	tests := []struct {
+		name       string
+		html       string
+		wantErrors []string
+	}{
+		{
+			name: "unique ids",
+			html: `<h2 id="intro">Intro</h2><h2 id="usage">Usage</h2><main id="main-content"></main>`,
+		},
+		{
+			name:       "duplicate id",
+			html:       `<h2 id="intro">Intro</h2><p>Text</p><div class="note" id="intro">Note</div>`,
+			wantErrors: []string{`page.html: duplicate id "intro"`},
+		},
+		{
+			name:       "duplicate id reported once",
+			html:       `<h2 id="intro">A</h2><h2 id="intro">B</h2><h2 id="intro">C</h2><h3 id="end">D</h3><h3 id="end">E</h3>`,
+			wantErrors: []string{`page.html: duplicate id "intro"`, `page.html: duplicate id "end"`},
+		},
+		{
+			name: "id like attributes are not ids",
+			html: `<div data-id="intro" aria-describedby="intro"></div><p id="intro">id="intro"</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := v.Validate("page.html", "", []byte(tt.html))
+			got := make([]string, 0, len(errs))
+			for _, err := range errs {
+				got = append(got, err.Error())
+			}
+			if len(got) != len(tt.wantErrors) || (len(got) > 0 && !reflect.DeepEqual(got, tt.wantErrors)) {
+				t.Errorf("Validate() errors = %v, want %v", got, tt.wantErrors)
+			}
+		})
+	}
+}
@@ -2,7 +2,6 @@ package image
 
 import (
 	"fmt"
-	"net/http"
 	"os"
 	"regexp"
 	"time"
@@ -14,15 +13,23 @@ import (
 type Validator struct {
 	// Timeout for HTTP requests to external images
 	Timeout time.Duration
+	// MaxRetries is the number of retries of an external URL after a connection error or a 5xx response
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled on each following retry
+	RetryBackoff time.Duration
 	// SkipExternal skips validation of external URLs
 	SkipExternal bool
-	imgRegex     *regexp.Regexp
+	// PathPrefix is the path the site is served under, e.g. /blog, prefixing its root-relative images
+	PathPrefix string
+	imgRegex   *regexp.Regexp
 }
 
 // NewValidator creates a new image validator with default settings
 func NewValidator() *Validator {
 	return &Validator{
 		Timeout:      10 * time.Second,
+		MaxRetries:   shared.DefaultMaxRetries,
+		RetryBackoff: shared.DefaultRetryBackoff,
 		SkipExternal: false,
 		imgRegex:     regexp.MustCompile(`<img[^>]+src="([^"]+)"`),
 	}
@@ -61,26 +68,12 @@ func (v *Validator) Validate(htmlPath, buildDir string, content []byte) []error
 
 // validateExternalImage checks if an external image URL is accessible
 func (v *Validator) validateExternalImage(url string) error {
-	client := &http.Client{
-		Timeout: v.Timeout,
-	}
-
-	resp, err := client.Head(url)
-	if err != nil {
-		return err
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return fmt.Errorf("HTTP %d", resp.StatusCode)
-	}
-
-	return nil
+	return shared.ExternalCheck{Timeout: v.Timeout, MaxRetries: v.MaxRetries, Backoff: v.RetryBackoff}.Check(url)
 }
 
 // validateLocalImage checks if a local image file exists
 func (v *Validator) validateLocalImage(src, htmlPath, buildDir string) error {
-	imagePath := shared.ResolveLocalPath(src, htmlPath, buildDir)
+	imagePath := shared.ResolveLocalPath(shared.TrimPathPrefix(src, v.PathPrefix), htmlPath, buildDir)
 
 	if _, err := os.Stat(imagePath); os.IsNotExist(err) {
 		return err
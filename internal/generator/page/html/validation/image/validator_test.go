@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -139,6 +140,50 @@ func TestValidator_ValidateExternalImage(t *testing.T) {
 	}
 }
 
+func TestValidator_ExternalImageFallsBackToGET(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	buildDir := t.TempDir()
+	htmlPath := filepath.Join(buildDir, "test.html")
+
+	errs := NewValidator().Validate(htmlPath, buildDir, []byte(`<img src="`+server.URL+`/image.png">`))
+	if len(errs) > 0 {
+		t.Errorf("expected no errors when GET succeeds after a rejected HEAD, got %v", errs)
+	}
+}
+
+func TestValidator_RetriesExternalImage(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	buildDir := t.TempDir()
+	htmlPath := filepath.Join(buildDir, "test.html")
+
+	v := NewValidator()
+	v.RetryBackoff = time.Millisecond
+	errs := v.Validate(htmlPath, buildDir, []byte(`<img src="`+server.URL+`/image.png">`))
+	if len(errs) > 0 {
+		t.Errorf("expected no errors once a retry succeeds, got %v", errs)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
 func TestValidator_SkipExternal(t *testing.T) {
 	buildDir := t.TempDir()
 	htmlPath := filepath.Join(buildDir, "test.html")
@@ -0,0 +1,59 @@
+package imagesize
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/shared"
+)
+
+// Validator warns about the local images which are empty or heavier than a maximum size, slowing the pages down.
+// External images are not checked, and missing images are left to the image validator.
+type Validator struct {
+	// MaxBytes is the maximum size of an image, the size is not checked when it is zero
+	MaxBytes int64
+	// PathPrefix is the path the site is served under, e.g. /blog, prefixing its root-relative images
+	PathPrefix string
+	imgRegex   *regexp.Regexp
+}
+
+// NewValidator creates an image size validator warning about the images heavier than maxBytes and the empty ones.
+func NewValidator(maxBytes int64) *Validator {
+	return &Validator{
+		MaxBytes: maxBytes,
+		imgRegex: regexp.MustCompile(`<img[^>]+src="([^"]+)"`),
+	}
+}
+
+// Validate checks the size of the local images of the img src attributes in the HTML content.
+// Its failures are tagged with shared.Warning.
+func (v *Validator) Validate(htmlPath, buildDir string, content []byte) []error {
+	var errs []error
+
+	for _, match := range v.imgRegex.FindAllSubmatch(content, -1) {
+		src := string(match[1])
+		if shared.IsExternalURL(src) || strings.HasPrefix(src, "data:") {
+			continue
+		}
+
+		target := src
+		if i := strings.IndexAny(target, "?#"); i >= 0 {
+			target = target[:i]
+		}
+		info, err := os.Stat(shared.ResolveLocalPath(shared.TrimPathPrefix(target, v.PathPrefix), htmlPath, buildDir))
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		switch {
+		case info.Size() == 0:
+			errs = append(errs, shared.Warning(fmt.Errorf("%s: empty image: %s", htmlPath, src)))
+		case v.MaxBytes > 0 && info.Size() > v.MaxBytes:
+			errs = append(errs, shared.Warning(fmt.Errorf("%s: image of %d bytes exceeds %d bytes: %s", htmlPath, info.Size(), v.MaxBytes, src)))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,81 @@
+package imagesize
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/shared"
+)
+
+func TestValidator_Validate(t *testing.T) {
+	buildDir := t.TempDir()
+	imgDir := filepath.Join(buildDir, "assets", "images")
+	if err := os.MkdirAll(imgDir, 0755); err != nil {
+		t.Fatalf("failed to create image dir: %v", err)
+	}
+	fixtures := map[string][]byte{
+		"normal.png":    bytes.Repeat([]byte{1}, 100),
+		"oversized.png": bytes.Repeat([]byte{1}, 2048),
+		"empty.png":     nil,
+	}
+	for name, data := range fixtures {
+		if err := os.WriteFile(filepath.Join(imgDir, name), data, 0644); err != nil {
+			t.Fatalf("failed to create test image: %v", err)
+		}
+	}
+	htmlPath := filepath.Join(buildDir, "post", "test.html")
+
+	tests := []struct {
+		name       string
+		html       string
+		pathPrefix string
+		wantErrors []string
+	}{
+		{
+			name: "normal-sized image",
+			html: `<img src="../assets/images/normal.png" alt="Normal">`,
+		},
+		{
+			name:       "oversized image",
+			html:       `<img src="../assets/images/oversized.png" alt="Oversized">`,
+			wantErrors: []string{"image of 2048 bytes exceeds 1024 bytes: ../assets/images/oversized.png"},
+		},
+		{
+			name:       "empty image",
+			html:       `<img src="/assets/images/empty.png" alt="Empty">`,
+			wantErrors: []string{"empty image: /assets/images/empty.png"},
+		},
+		{
+			name:       "root-relative image under the path prefix",
+			html:       `<img src="/blog/assets/images/oversized.png?v=1" alt="Oversized">`,
+			pathPrefix: "/blog",
+			wantErrors: []string{"exceeds 1024 bytes"},
+		},
+		{
+			name: "external and missing images are skipped",
+			html: `<img src="https://example.com/huge.png" alt="External"><img src="../assets/images/missing.png" alt="Missing">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := NewValidator(1024)
+			v.PathPrefix = tt.pathPrefix
+			errs := v.Validate(htmlPath, buildDir, []byte(tt.html))
+			if len(errs) != len(tt.wantErrors) {
+				t.Fatalf("Validate() got %d errors, want %d: %v", len(errs), len(tt.wantErrors), errs)
+			}
+			for i, want := range tt.wantErrors {
+				if !strings.Contains(errs[i].Error(), want) {
+					t.Errorf("error %d = %q, want it to contain %q", i, errs[i], want)
+				}
+				if shared.SeverityOf(errs[i]) != shared.SeverityWarning {
+					t.Errorf("error %d should be a warning", i)
+				}
+			}
+		})
+	}
+}
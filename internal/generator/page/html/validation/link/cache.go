@@ -0,0 +1,64 @@
+package link
+
+import "sync"
+
+// Cache memoizes the result of external link checks so that each distinct URL is only fetched once,
+// and the element ids of the local pages linked with a fragment so that each page is only parsed once.
+// It is safe for concurrent use and meant to be shared by the validators of a single build.
+type Cache struct {
+	mu      sync.Mutex
+	results map[string]*cacheEntry
+	ids     map[string]map[string]bool
+}
+
+type cacheEntry struct {
+	done chan struct{}
+	err  error
+}
+
+// NewCache creates an empty external link cache
+func NewCache() *Cache {
+	return &Cache{results: make(map[string]*cacheEntry), ids: make(map[string]map[string]bool)}
+}
+
+// check returns the cached result for url, calling fetch if url was never checked.
+// Concurrent calls for the same url wait for the first fetch instead of issuing their own.
+func (c *Cache) check(url string, fetch func(string) error) error {
+	c.mu.Lock()
+	entry, ok := c.results[url]
+	if !ok {
+		entry = &cacheEntry{done: make(chan struct{})}
+		c.results[url] = entry
+	}
+	c.mu.Unlock()
+
+	if ok {
+		<-entry.done
+		return entry.err
+	}
+
+	entry.err = fetch(url)
+	close(entry.done)
+	return entry.err
+}
+
+// pageIDs returns the cached element ids of the page at path, calling parse if the page was never parsed.
+// Parse errors are not cached, so that a page failing to be read is reported by every link to it.
+func (c *Cache) pageIDs(path string, parse func(string) (map[string]bool, error)) (map[string]bool, error) {
+	c.mu.Lock()
+	ids, ok := c.ids[path]
+	c.mu.Unlock()
+	if ok {
+		return ids, nil
+	}
+
+	ids, err := parse(path)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.ids[path] = ids
+	c.mu.Unlock()
+	return ids, nil
+}
@@ -0,0 +1,140 @@
+package link
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCache_Check(t *testing.T) {
+	c := NewCache()
+	var calls atomic.Int32
+	fetch := func(string) error {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return errors.New("HTTP 404")
+	}
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Go(func() {
+			if err := c.check("https://example.com", fetch); err == nil || err.Error() != "HTTP 404" {
+				t.Errorf("check() error = %v, want HTTP 404", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Errorf("expected a single fetch for concurrent checks of one url, got %d", got)
+	}
+}
+
+func TestValidator_ExternalLinksFetchedOnce(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		hits = map[string]int{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	buildDir := t.TempDir()
+	html := []byte(`<a href="` + server.URL + `/a">A</a>` +
+		`<a href="` + server.URL + `/missing">Missing</a>` +
+		`<a href="` + server.URL + `/a">A again</a>` +
+		`<a href="` + server.URL + `/b">B</a>` +
+		`<a href="` + server.URL + `/missing">Missing again</a>`)
+
+	// Two validators sharing a cache stand for two pages of the same build
+	cache := NewCache()
+	first, second := NewValidator(), NewValidator()
+	first.Cache, second.Cache = cache, cache
+	first.MaxConcurrency = 2
+
+	for _, v := range []*Validator{first, second} {
+		htmlPath := filepath.Join(buildDir, "page.html")
+		errs := v.Validate(htmlPath, buildDir, html)
+		if len(errs) != 2 {
+			t.Errorf("expected one error per broken link occurrence, got %v", errs)
+		}
+	}
+
+	for path, n := range hits {
+		if n != 1 {
+			t.Errorf("%s fetched %d times, want 1", path, n)
+		}
+	}
+	if len(hits) != 3 {
+		t.Errorf("expected 3 distinct urls fetched, got %v", hits)
+	}
+}
+
+func TestValidator_NilCacheFetchesEachValidation(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	buildDir := t.TempDir()
+	v := NewValidator()
+	v.Cache = nil
+	html := []byte(`<a href="` + server.URL + `/a">A</a><a href="` + server.URL + `/a">A again</a>`)
+
+	for range 2 {
+		if errs := v.Validate(filepath.Join(buildDir, "page.html"), buildDir, html); len(errs) > 0 {
+			t.Errorf("unexpected errors: %v", errs)
+		}
+	}
+	if got := hits.Load(); got != 2 {
+		t.Errorf("expected one fetch per validation without a cache, got %d", got)
+	}
+}
+
+func TestValidator_PageIDsParsedOnce(t *testing.T) {
+	buildDir := t.TempDir()
+	postsDir := filepath.Join(buildDir, "posts")
+	if err := os.MkdirAll(postsDir, 0755); err != nil {
+		t.Fatalf("failed to create posts dir: %v", err)
+	}
+	otherPath := filepath.Join(postsDir, "other.html")
+	if err := os.WriteFile(otherPath, []byte(`<h2 id="intro">Intro</h2>`), 0644); err != nil {
+		t.Fatalf("failed to create other page: %v", err)
+	}
+	html := []byte(`<a href="/posts/other.html#intro">Intro</a><a href="/posts/other.html#outro">Outro</a>`)
+
+	// Two validators sharing a cache stand for two pages of the same build
+	cache := NewCache()
+	first, second := NewValidator(), NewValidator()
+	first.Cache, second.Cache = cache, cache
+
+	errs := first.Validate(filepath.Join(buildDir, "a.html"), buildDir, html)
+	if len(errs) != 1 || errs[0].Error() != errorFor(filepath.Join(buildDir, "a.html"), "anchor #outro missing in posts/other.html") {
+		t.Fatalf("Validate() errors = %v, want the missing #outro anchor", errs)
+	}
+
+	// Ids read from the cache ignore the page rewritten after its first parse
+	if err := os.WriteFile(otherPath, []byte(`<h2 id="outro">Outro</h2>`), 0644); err != nil {
+		t.Fatalf("failed to rewrite other page: %v", err)
+	}
+	errs = second.Validate(filepath.Join(buildDir, "b.html"), buildDir, html)
+	if len(errs) != 1 || errs[0].Error() != errorFor(filepath.Join(buildDir, "b.html"), "anchor #outro missing in posts/other.html") {
+		t.Errorf("Validate() errors = %v, want the ids parsed by the first validation", errs)
+	}
+}
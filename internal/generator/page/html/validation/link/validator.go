@@ -1,12 +1,14 @@
 package link
 
 import (
+	"errors"
 	"fmt"
-	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/tjnvr/blog/internal/generator/page/html/validation/shared"
@@ -16,17 +18,40 @@ import (
 type Validator struct {
 	// Timeout for HTTP requests to external links
 	Timeout time.Duration
+	// MaxRetries is the number of retries of an external URL after a connection error or a 5xx response
+	MaxRetries int
+	// RetryBackoff is the wait before the first retry, doubled on each following retry
+	RetryBackoff time.Duration
 	// SkipExternal skips validation of external URLs
 	SkipExternal bool
-	linkRegex    *regexp.Regexp
+	// MaxConcurrency bounds the number of external links checked at the same time
+	MaxConcurrency int
+	// Cache holds the external link results shared across pages; a nil Cache disables caching
+	Cache *Cache
+	// Excluded maps the output paths of the pages intentionally left out of the build, such as drafts,
+	// to the reason they were left out, so that links to them are reported as such
+	Excluded map[string]string
+	// PathPrefix is the path the site is served under, e.g. /blog, prefixing its root-relative links
+	PathPrefix string
+	linkRegex  *regexp.Regexp
 }
 
+// idRegex matches the id attributes of HTML elements
+var idRegex = regexp.MustCompile(`\sid="([^"]*)"`)
+
+// defaultMaxConcurrency is the default number of external links checked at the same time
+const defaultMaxConcurrency = 8
+
 // NewValidator creates a new link validator with default settings
 func NewValidator() *Validator {
 	return &Validator{
-		Timeout:      10 * time.Second,
-		SkipExternal: false,
-		linkRegex:    regexp.MustCompile(`<a[^>]+href="([^"]+)"`),
+		Timeout:        10 * time.Second,
+		MaxRetries:     shared.DefaultMaxRetries,
+		RetryBackoff:   shared.DefaultRetryBackoff,
+		SkipExternal:   false,
+		MaxConcurrency: defaultMaxConcurrency,
+		Cache:          NewCache(),
+		linkRegex:      regexp.MustCompile(`<a[^>]+href="([^"]+)"`),
 	}
 }
 
@@ -37,6 +62,17 @@ func (v *Validator) Validate(htmlPath, buildDir string, content []byte) []error
 	// Find all anchor href attributes
 	matches := v.linkRegex.FindAllSubmatch(content, -1)
 
+	external := make([]string, 0)
+	for _, match := range matches {
+		if len(match) >= 2 && shared.IsExternalURL(string(match[1])) {
+			external = append(external, string(match[1]))
+		}
+	}
+	var externalErrs map[string]error
+	if !v.SkipExternal {
+		externalErrs = v.validateExternalLinks(external)
+	}
+
 	for _, match := range matches {
 		if len(match) < 2 {
 			continue
@@ -44,8 +80,11 @@ func (v *Validator) Validate(htmlPath, buildDir string, content []byte) []error
 
 		href := string(match[1])
 
-		// Skip fragment-only links (e.g., #section)
+		// Fragment-only links (e.g., #section) target the current page
 		if strings.HasPrefix(href, "#") {
+			if err := checkFragment(href[1:], relativeTo(buildDir, htmlPath), parseIDs(content)); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", htmlPath, err))
+			}
 			continue
 		}
 
@@ -63,12 +102,19 @@ func (v *Validator) Validate(htmlPath, buildDir string, content []byte) []error
 			if v.SkipExternal {
 				continue
 			}
-			if err := v.validateExternalLink(href); err != nil {
+			if err := externalErrs[href]; err != nil {
 				errs = append(errs, fmt.Errorf("%s: external link not accessible: %s (%w)", htmlPath, href, err))
 			}
+		} else if target, reason, ok := v.excludedTarget(href, htmlPath, buildDir); ok {
+			errs = append(errs, fmt.Errorf("%s: links to excluded page %s (%s)", htmlPath, relativeTo(buildDir, target), reason))
 		} else {
 			if err := v.validateLocalLink(href, htmlPath, buildDir); err != nil {
-				errs = append(errs, fmt.Errorf("%s: local link not found: %s", htmlPath, href))
+				var fragErr *fragmentError
+				if errors.As(err, &fragErr) {
+					errs = append(errs, fmt.Errorf("%s: %w", htmlPath, err))
+				} else {
+					errs = append(errs, fmt.Errorf("%s: local link not found: %s", htmlPath, href))
+				}
 			}
 		}
 	}
@@ -76,49 +122,178 @@ func (v *Validator) Validate(htmlPath, buildDir string, content []byte) []error
 	return errs
 }
 
-// validateExternalLink checks if an external URL is accessible
-func (v *Validator) validateExternalLink(url string) error {
-	client := &http.Client{
-		Timeout: v.Timeout,
+// validateExternalLinks checks the distinct urls from a bounded worker pool and returns the error of each url
+func (v *Validator) validateExternalLinks(urls []string) map[string]error {
+	results := make(map[string]error, len(urls))
+	if len(urls) == 0 {
+		return results
 	}
 
-	resp, err := client.Head(url)
-	if err != nil {
-		return err
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		jobs = make(chan string)
+	)
+
+	for range max(1, min(v.MaxConcurrency, len(urls))) {
+		wg.Go(func() {
+			for url := range jobs {
+				var err error
+				if v.Cache != nil {
+					err = v.Cache.check(url, v.validateExternalLink)
+				} else {
+					err = v.validateExternalLink(url)
+				}
+				mu.Lock()
+				results[url] = err
+				mu.Unlock()
+			}
+		})
 	}
-	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
-		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	seen := make(map[string]bool, len(urls))
+	for _, url := range urls {
+		if !seen[url] {
+			seen[url] = true
+			jobs <- url
+		}
 	}
+	close(jobs)
+	wg.Wait()
 
-	return nil
+	return results
+}
+
+// validateExternalLink checks if an external URL is accessible
+func (v *Validator) validateExternalLink(url string) error {
+	return shared.ExternalCheck{Timeout: v.Timeout, MaxRetries: v.MaxRetries, Backoff: v.RetryBackoff}.Check(url)
 }
 
-// validateLocalLink checks if a local link target exists
+// validateLocalLink checks if a local link target exists, and that its fragment identifier if any
+// matches an element id in the target
 func (v *Validator) validateLocalLink(href, htmlPath, buildDir string) error {
-	// Remove fragment identifier if present
-	href = strings.Split(href, "#")[0]
+	href, fragment, _ := strings.Cut(href, "#")
 
 	// Empty href after removing fragment means same-page link
 	if href == "" {
 		return nil
 	}
 
-	linkPath := shared.ResolveLocalPath(href, htmlPath, buildDir)
+	linkPath := shared.ResolveLocalPath(shared.TrimPathPrefix(href, v.PathPrefix), htmlPath, buildDir)
 
-	// Check if path exists as-is (could be a file or directory)
-	if _, err := os.Stat(linkPath); err == nil {
+	targetPath, err := resolveTarget(linkPath)
+	if err != nil {
+		return err
+	}
+
+	if fragment == "" || filepath.Ext(targetPath) != ".html" {
 		return nil
 	}
 
+	var ids map[string]bool
+	if v.Cache != nil {
+		ids, err = v.Cache.pageIDs(targetPath, readIDs)
+	} else {
+		ids, err = readIDs(targetPath)
+	}
+	if err != nil {
+		return err
+	}
+	return checkFragment(fragment, relativeTo(buildDir, targetPath), ids)
+}
+
+// excludedTarget returns the excluded page a local link points to, if any, along with the reason it was excluded
+func (v *Validator) excludedTarget(href, htmlPath, buildDir string) (string, string, bool) {
+	href, _, _ = strings.Cut(href, "#")
+	if len(v.Excluded) == 0 || href == "" {
+		return "", "", false
+	}
+
+	linkPath := shared.ResolveLocalPath(shared.TrimPathPrefix(href, v.PathPrefix), htmlPath, buildDir)
+	for _, target := range []string{linkPath, filepath.Join(linkPath, "index.html")} {
+		if reason, ok := v.Excluded[target]; ok {
+			return target, reason, true
+		}
+	}
+	return "", "", false
+}
+
+// readIDs returns the element ids of the page at path
+func readIDs(path string) (map[string]bool, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseIDs(content), nil
+}
+
+// parseIDs returns the set of element ids of content
+func parseIDs(content []byte) map[string]bool {
+	ids := make(map[string]bool)
+	for _, match := range idRegex.FindAllSubmatch(content, -1) {
+		ids[string(match[1])] = true
+	}
+	return ids
+}
+
+// relativeTo returns path relative to buildDir, or path itself when it is outside of buildDir
+func relativeTo(buildDir, path string) string {
+	rel, err := filepath.Rel(buildDir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// resolveTarget returns the file a local link path points to
+func resolveTarget(linkPath string) (string, error) {
+	// Check if path exists as-is (could be a file or directory)
+	if info, err := os.Stat(linkPath); err == nil {
+		if !info.IsDir() {
+			return linkPath, nil
+		}
+		indexPath := filepath.Join(linkPath, "index.html")
+		if _, err := os.Stat(indexPath); err == nil {
+			return indexPath, nil
+		}
+		return linkPath, nil
+	}
+
 	// If path doesn't have an extension, check for index.html
 	if filepath.Ext(linkPath) == "" {
 		indexPath := filepath.Join(linkPath, "index.html")
 		if _, err := os.Stat(indexPath); err == nil {
-			return nil
+			return indexPath, nil
 		}
 	}
 
-	return fmt.Errorf("path not found: %s", linkPath)
+	return "", fmt.Errorf("path not found: %s", linkPath)
+}
+
+// fragmentError reports a fragment identifier matching no element id in its target
+type fragmentError struct {
+	fragment string
+	target   string
+}
+
+func (e *fragmentError) Error() string {
+	return fmt.Sprintf("anchor #%s missing in %s", e.fragment, e.target)
+}
+
+// checkFragment checks that ids, the element ids of the target page, hold fragment
+func checkFragment(fragment, targetName string, ids map[string]bool) error {
+	// "#" alone links to the top of the page
+	if fragment == "" || fragment == "top" {
+		return nil
+	}
+
+	id := fragment
+	if unescaped, err := url.PathUnescape(fragment); err == nil {
+		id = unescaped
+	}
+
+	if !ids[id] {
+		return &fragmentError{fragment: fragment, target: targetName}
+	}
+	return nil
 }
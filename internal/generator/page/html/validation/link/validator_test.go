@@ -5,6 +5,8 @@ import (
 	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -21,6 +23,12 @@ func TestNewValidator(t *testing.T) {
 	if v.SkipExternal {
 		t.Error("SkipExternal should be false by default")
 	}
+	if v.MaxConcurrency != defaultMaxConcurrency {
+		t.Errorf("expected MaxConcurrency %d, got %d", defaultMaxConcurrency, v.MaxConcurrency)
+	}
+	if v.Cache == nil {
+		t.Error("Cache should be set by default")
+	}
 }
 
 func TestValidator_ValidateLocalLink(t *testing.T) {
@@ -33,7 +41,7 @@ func TestValidator_ValidateLocalLink(t *testing.T) {
 
 	// Create a test HTML file
 	aboutPath := filepath.Join(pagesDir, "about.html")
-	if err := os.WriteFile(aboutPath, []byte("<html></html>"), 0644); err != nil {
+	if err := os.WriteFile(aboutPath, []byte(`<html><h2 id="section">Section</h2></html>`), 0644); err != nil {
 		t.Fatalf("failed to create about page: %v", err)
 	}
 
@@ -91,7 +99,7 @@ func TestValidator_ValidateLocalLink(t *testing.T) {
 		},
 		{
 			name:      "fragment only link",
-			html:      `<a href="#section">Section</a>`,
+			html:      `<h2 id="section">Section</h2><a href="#section">Section</a>`,
 			wantError: false,
 		},
 		{
@@ -134,6 +142,69 @@ func TestValidator_ValidateLocalLink(t *testing.T) {
 	}
 }
 
+func TestValidator_ExcludedPages(t *testing.T) {
+	buildDir := t.TempDir()
+	htmlPath := filepath.Join(buildDir, "posts", "first.html")
+	// A stale output of a page excluded since the last build does not make its links valid
+	if err := os.MkdirAll(filepath.Join(buildDir, "posts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(buildDir, "posts", "stale.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	v := NewValidator()
+	v.Excluded = map[string]string{
+		filepath.Join(buildDir, "posts", "draft.html"): "draft",
+		filepath.Join(buildDir, "posts", "stale.html"): "ignored",
+		filepath.Join(buildDir, "notes", "index.html"): "ignored",
+	}
+
+	tests := []struct {
+		name    string
+		html    string
+		wantMsg string
+	}{
+		{
+			name:    "link to a draft",
+			html:    `<a href="draft.html">Draft</a>`,
+			wantMsg: "links to excluded page posts/draft.html (draft)",
+		},
+		{
+			name:    "link with fragment to a draft",
+			html:    `<a href="/posts/draft.html#intro">Draft</a>`,
+			wantMsg: "links to excluded page posts/draft.html (draft)",
+		},
+		{
+			name:    "stale output of an ignored page",
+			html:    `<a href="stale.html">Stale</a>`,
+			wantMsg: "links to excluded page posts/stale.html (ignored)",
+		},
+		{
+			name:    "directory link to an ignored index",
+			html:    `<a href="../notes/">Notes</a>`,
+			wantMsg: "links to excluded page notes/index.html (ignored)",
+		},
+		{
+			name:    "missing page",
+			html:    `<a href="missing.html">Missing</a>`,
+			wantMsg: "local link not found: missing.html",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := v.Validate(htmlPath, buildDir, []byte(tt.html))
+			if len(errs) != 1 {
+				t.Fatalf("Validate() errors = %v, want 1 error", errs)
+			}
+			if !strings.Contains(errs[0].Error(), tt.wantMsg) {
+				t.Errorf("Validate() error = %q, want it to contain %q", errs[0], tt.wantMsg)
+			}
+		})
+	}
+}
+
 func TestValidator_ValidateExternalLink(t *testing.T) {
 	// Create a test HTTP server
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -179,6 +250,134 @@ func TestValidator_ValidateExternalLink(t *testing.T) {
 	}
 }
 
+func TestValidator_ValidateFragments(t *testing.T) {
+	buildDir := t.TempDir()
+	pagesDir := filepath.Join(buildDir, "pages")
+	if err := os.MkdirAll(pagesDir, 0755); err != nil {
+		t.Fatalf("failed to create pages dir: %v", err)
+	}
+	aboutPath := filepath.Join(pagesDir, "about.html")
+	if err := os.WriteFile(aboutPath, []byte(`<html><h2 id="team">Team</h2></html>`), 0644); err != nil {
+		t.Fatalf("failed to create about page: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(pagesDir, "index.html"), []byte(`<html><h2 id="intro">Intro</h2></html>`), 0644); err != nil {
+		t.Fatalf("failed to create index page: %v", err)
+	}
+	htmlPath := filepath.Join(buildDir, "test.html")
+
+	tests := []struct {
+		name    string
+		html    string
+		wantErr string
+	}{
+		{
+			name: "existing fragment in another page",
+			html: `<a href="/pages/about.html#team">Team</a>`,
+		},
+		{
+			name:    "missing fragment in another page",
+			html:    `<a href="/pages/about.html#section">Section</a>`,
+			wantErr: errorFor(htmlPath, "anchor #section missing in pages/about.html"),
+		},
+		{
+			name: "existing fragment in a directory index",
+			html: `<a href="/pages/#intro">Intro</a>`,
+		},
+		{
+			name:    "missing fragment in a directory index",
+			html:    `<a href="/pages#missing">Missing</a>`,
+			wantErr: errorFor(htmlPath, "anchor #missing missing in pages/index.html"),
+		},
+		{
+			name: "existing same-page fragment",
+			html: `<h2 id="local">Local</h2><a href="#local">Local</a>`,
+		},
+		{
+			name:    "missing same-page fragment",
+			html:    `<a href="#nowhere">Nowhere</a>`,
+			wantErr: errorFor(htmlPath, "anchor #nowhere missing in test.html"),
+		},
+		{
+			name: "percent-encoded fragment",
+			html: `<h2 id="été">Été</h2><a href="#%C3%A9t%C3%A9">Été</a>`,
+		},
+		{
+			name: "link to the top of the page",
+			html: `<a href="#">Top</a><a href="#top">Top</a>`,
+		},
+		{
+			name:    "missing target keeps the local link error",
+			html:    `<a href="/pages/missing.html#team">Missing</a>`,
+			wantErr: errorFor(htmlPath, "local link not found: /pages/missing.html#team"),
+		},
+	}
+
+	v := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := v.Validate(htmlPath, buildDir, []byte(tt.html))
+			if tt.wantErr == "" {
+				if len(errs) > 0 {
+					t.Errorf("Validate() unexpected errors: %v", errs)
+				}
+				return
+			}
+			if len(errs) != 1 || errs[0].Error() != tt.wantErr {
+				t.Errorf("Validate() errors = %v, want [%s]", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func errorFor(htmlPath, msg string) string {
+	return htmlPath + ": " + msg
+}
+
+func TestValidator_ExternalLinkFallsBackToGET(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	buildDir := t.TempDir()
+	htmlPath := filepath.Join(buildDir, "test.html")
+
+	errs := NewValidator().Validate(htmlPath, buildDir, []byte(`<a href="`+server.URL+`/page">Page</a>`))
+	if len(errs) > 0 {
+		t.Errorf("expected no errors when GET succeeds after a rejected HEAD, got %v", errs)
+	}
+}
+
+func TestValidator_RetriesExternalLink(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= 2 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	buildDir := t.TempDir()
+	htmlPath := filepath.Join(buildDir, "test.html")
+
+	v := NewValidator()
+	v.RetryBackoff = time.Millisecond
+	errs := v.Validate(htmlPath, buildDir, []byte(`<a href="`+server.URL+`/page">Page</a>`))
+	if len(errs) > 0 {
+		t.Errorf("expected no errors once a retry succeeds, got %v", errs)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("expected 3 attempts, got %d", got)
+	}
+}
+
 func TestValidator_SkipExternal(t *testing.T) {
 	buildDir := t.TempDir()
 	htmlPath := filepath.Join(buildDir, "test.html")
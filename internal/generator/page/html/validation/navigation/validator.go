@@ -9,55 +9,76 @@ import (
 )
 
 // Validator checks that the generated HTML contains a <nav> element
-// with links to all expected sections
+// with links to all expected sections, including nested sections.
+// The site navigation landmark, with role="navigation", is checked when present, the first <nav> otherwise,
+// so that the other <nav> elements of the page, such as the breadcrumb, are not mistaken for it.
 type Validator struct {
 	sections      []section.Section
+	homeLabel     string
 	navRegex      *regexp.Regexp
 	homeHrefRegex *regexp.Regexp
 }
 
 // NewValidator creates a new navigation validator that will check
-// for the presence of nav links to all given sections plus the home page
-func NewValidator(sections []section.Section) *Validator {
+// for the presence of nav links to all given sections plus the home page.
+// homeLabel is the expected home link label; when empty the home section display name is expected.
+func NewValidator(sections []section.Section, homeLabel string) *Validator {
 	return &Validator{
 		sections:      sections,
-		navRegex:      regexp.MustCompile(`(?s)<nav[^>]*>(.*?)</nav>`),
-		homeHrefRegex: regexp.MustCompile(`href="(\.\./)*index\.html"`),
+		homeLabel:     homeLabel,
+		navRegex:      regexp.MustCompile(`(?s)<nav([^>]*)>(.*?)</nav>`),
+		homeHrefRegex: regexp.MustCompile(`href="(?:(?:/|(?:\.\./)*)index\.html|/|\./|(?:\.\./)+)"`),
 	}
 }
 
 // Validate checks the HTML content for a <nav> element containing links to all sections
 func (v *Validator) Validate(htmlPath, buildDir string, content []byte) []error {
-	var errs []error
 	html := string(content)
 
 	// Extract <nav> content
-	navMatch := v.navRegex.FindStringSubmatch(html)
-	if len(navMatch) < 2 {
-		errs = append(errs, fmt.Errorf("%s: missing <nav> element", htmlPath))
-		return errs
+	navMatches := v.navRegex.FindAllStringSubmatch(html, -1)
+	if len(navMatches) == 0 {
+		return []error{fmt.Errorf("%s: missing <nav> element", htmlPath)}
+	}
+
+	navContent := navMatches[0][2]
+	for _, m := range navMatches {
+		if strings.Contains(m[1], `role="navigation"`) {
+			navContent = m[2]
+			break
+		}
 	}
 
-	navContent := navMatch[1]
+	return v.validateSections(htmlPath, navContent, v.sections)
+}
 
-	for _, s := range v.sections {
+// validateSections checks that navContent links to sections and to their nested sections
+func (v *Validator) validateSections(htmlPath, navContent string, sections []section.Section) []error {
+	var errs []error
+	for _, s := range sections {
 		if s.DirName == "" {
-			// Home section: href may be prefixed with ../ depending on depth
-			if !strings.Contains(navContent, s.DisplayName) {
-				errs = append(errs, fmt.Errorf("%s: navigation missing home link (%s)", htmlPath, s.DisplayName))
+			// Home section: href may be prefixed with ../ depending on depth, or root-relative
+			label := s.DisplayName
+			if v.homeLabel != "" {
+				label = v.homeLabel
+			}
+			if !strings.Contains(navContent, label) {
+				errs = append(errs, fmt.Errorf("%s: navigation missing home link (%s)", htmlPath, label))
 			}
 			if !v.homeHrefRegex.MatchString(navContent) {
 				errs = append(errs, fmt.Errorf("%s: navigation missing home href to index.html", htmlPath))
 			}
 		} else {
+			// The section may be linked through its directory, with clean URLs
 			expectedHref := s.DirName + "/index.html"
-			if !strings.Contains(navContent, expectedHref) {
+			if !strings.Contains(navContent, expectedHref) && !strings.Contains(navContent, s.DirName+`/"`) {
 				errs = append(errs, fmt.Errorf("%s: navigation missing link to section %q (expected href containing %q)", htmlPath, s.DirName, expectedHref))
 			}
 			if !strings.Contains(navContent, s.DisplayName) {
 				errs = append(errs, fmt.Errorf("%s: navigation missing display name %q for section %q", htmlPath, s.DisplayName, s.DirName))
 			}
 		}
+		errs = append(errs, v.validateSections(htmlPath, navContent, s.Children)...)
 	}
 
 	return errs
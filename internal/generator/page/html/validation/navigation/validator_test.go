@@ -12,7 +12,7 @@ func TestNewValidator(t *testing.T) {
 		{DirName: "", DisplayName: "Accueil"},
 		{DirName: "posts", DisplayName: "Posts"},
 		{DirName: "about", DisplayName: "About"},
-	})
+	}, "")
 	if v == nil {
 		t.Fatal("NewValidator returned nil")
 		return
@@ -64,6 +64,37 @@ func TestValidator_Validate(t *testing.T) {
 			</body></html>`,
 			wantErrors: 0,
 		},
+		{
+			name: "site navigation landmark after another nav",
+			sections: []section.Section{
+				{DirName: "", DisplayName: "Accueil"},
+				{DirName: "posts", DisplayName: "Posts"},
+			},
+			html: `<html><body>
+				<a href="#main-content" class="sr-only">Skip to content</a>
+				<nav aria-label="breadcrumb"><a href="../index.html">Accueil</a></nav>
+				<nav role="navigation" aria-label="Main" class="flex gap-4">
+					<a href="../index.html">Accueil</a>
+					<a href="../posts/index.html">Posts</a>
+				</nav>
+				<main id="main-content"><p>Content</p></main>
+			</body></html>`,
+			wantErrors: 0,
+		},
+		{
+			name: "valid nav with clean URLs",
+			sections: []section.Section{
+				{DirName: "", DisplayName: "Accueil"},
+				{DirName: "posts", DisplayName: "Posts"},
+			},
+			html: `<html><body>
+				<nav role="navigation" aria-label="Main" class="flex gap-4">
+					<a href="../../">Accueil</a>
+					<a href="../../posts/">Posts</a>
+				</nav>
+			</body></html>`,
+			wantErrors: 0,
+		},
 		{
 			name:       "missing nav element entirely",
 			sections:   []section.Section{{DirName: "", DisplayName: "Accueil"}, {DirName: "posts", DisplayName: "Posts"}},
@@ -133,11 +164,52 @@ func TestValidator_Validate(t *testing.T) {
 			</body></html>`,
 			wantErrors: 0,
 		},
+		{
+			name: "valid nav with root-relative links",
+			sections: []section.Section{
+				{DirName: "", DisplayName: "Accueil"},
+				{DirName: "posts", DisplayName: "Posts"},
+			},
+			html: `<nav>
+				<a href="/index.html">Accueil</a>
+				<a href="/posts/index.html">Posts</a>
+			</nav>`,
+			wantErrors: 0,
+		},
+		{
+			name: "valid nav with nested sections",
+			sections: []section.Section{
+				{DirName: "", DisplayName: "Accueil"},
+				{DirName: "blog", DisplayName: "Blog", Children: []section.Section{
+					{DirName: "blog/2024", DisplayName: "2024"},
+				}},
+			},
+			html: `<nav>
+				<a href="../../index.html">Accueil</a>
+				<div><a href="../../blog/index.html">Blog</a><div><a href="../../blog/2024/index.html">2024</a></div></div>
+			</nav>`,
+			wantErrors: 0,
+		},
+		{
+			name: "nav missing a nested section link",
+			sections: []section.Section{
+				{DirName: "", DisplayName: "Accueil"},
+				{DirName: "blog", DisplayName: "Blog", Children: []section.Section{
+					{DirName: "blog/2024", DisplayName: "2024"},
+				}},
+			},
+			html: `<nav>
+				<a href="index.html">Accueil</a>
+				<a href="blog/index.html">Blog</a>
+			</nav>`,
+			wantErrors: 2,
+			wantMsg:    []string{`missing link to section "blog/2024"`, `missing display name "2024"`},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			v := NewValidator(tt.sections)
+			v := NewValidator(tt.sections, "")
 			errs := v.Validate("test.html", "/build", []byte(tt.html))
 
 			if len(errs) != tt.wantErrors {
@@ -158,3 +230,20 @@ func TestValidator_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_HomeLabel(t *testing.T) {
+	sections := []section.Section{
+		{DirName: "", DisplayName: "Accueil"},
+		{DirName: "posts", DisplayName: "Posts"},
+	}
+	html := `<nav><a href="index.html">Home</a><a href="posts/index.html">Posts</a></nav>`
+
+	if errs := NewValidator(sections, "Home").Validate("test.html", "/build", []byte(html)); len(errs) > 0 {
+		t.Errorf("expected the custom home label to be accepted, got %v", errs)
+	}
+
+	errs := NewValidator(sections, "").Validate("test.html", "/build", []byte(html))
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "navigation missing home link (Accueil)") {
+		t.Errorf("expected the home display name to be required without a home label, got %v", errs)
+	}
+}
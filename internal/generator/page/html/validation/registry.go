@@ -2,55 +2,204 @@ package validation
 
 import (
 	"errors"
+	"log/slog"
+	"time"
 
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/class"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/id"
 	"github.com/tjnvr/blog/internal/generator/page/html/validation/image"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/imagesize"
 	"github.com/tjnvr/blog/internal/generator/page/html/validation/link"
 	"github.com/tjnvr/blog/internal/generator/page/html/validation/navigation"
 	"github.com/tjnvr/blog/internal/generator/page/html/validation/script"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/shared"
 	"github.com/tjnvr/blog/internal/generator/section"
 )
 
-// Registry manages validators and runs them on HTML content
-type Registry struct {
-	validators []Validator
+type (
+	// Registry manages validators and runs them on HTML content
+	Registry struct {
+		validators []Validator
+		strict     bool
+		logger     *slog.Logger
+	}
+
+	// Option configures the default validators created by NewRegistry
+	Option func(*options)
+
+	options struct {
+		linkCache      *link.Cache
+		homeLabel      string
+		allowedClasses []string
+		excludedPages  map[string]string
+		strict         bool
+		logger         *slog.Logger
+		validators     []Validator
+		timeout        time.Duration
+		pathPrefix     string
+		maxImageSize   int64
+	}
+)
+
+// WithLinkCache returns an Option that shares the given external link results between registries,
+// so that a URL linked from several pages is only fetched once per build.
+func WithLinkCache(cache *link.Cache) Option {
+	return func(o *options) { o.linkCache = cache }
+}
+
+// WithHomeLabel returns an Option that sets the home link label expected in the navigation.
+func WithHomeLabel(label string) Option {
+	return func(o *options) { o.homeLabel = label }
+}
+
+// WithClassAllowlist returns an Option that adds a validator rejecting the classes missing from allowed.
+// A nil allowed list adds no validator.
+func WithClassAllowlist(allowed []string) Option {
+	return func(o *options) { o.allowedClasses = allowed }
+}
+
+// WithExcludedPages returns an Option that reports the links to the pages left out of the build, such as drafts,
+// as links to excluded pages. excluded maps their output path to the reason they were left out.
+func WithExcludedPages(excluded map[string]string) Option {
+	return func(o *options) { o.excludedPages = excluded }
+}
+
+// WithStrictValidation returns an Option that fails the validation on warnings too, such as the failures
+// tagged with shared.Warning. Warnings are only logged otherwise.
+func WithStrictValidation(strict bool) Option {
+	return func(o *options) { o.strict = strict }
+}
+
+// WithLogger returns an Option that sets the logger reporting the warnings of a validation which is not strict.
+// A nil logger is ignored.
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+// WithExternalTimeout returns an Option that sets the timeout of the external link and image checks.
+// A zero timeout keeps the default of the validators.
+func WithExternalTimeout(timeout time.Duration) Option {
+	return func(o *options) { o.timeout = timeout }
+}
+
+// WithPathPrefix returns an Option that resolves the root-relative links, images and scripts from the build directory
+// without prefix, the path the site is served under, e.g. /blog.
+func WithPathPrefix(prefix string) Option {
+	return func(o *options) { o.pathPrefix = prefix }
+}
+
+// WithMaxImageSize returns an Option that adds a validator warning about the local images heavier than maxBytes
+// and the empty ones. A zero maxBytes adds no validator.
+func WithMaxImageSize(maxBytes int64) Option {
+	return func(o *options) { o.maxImageSize = maxBytes }
+}
+
+// WithValidators returns an Option that registers validators after the default validators, in order.
+func WithValidators(validators ...Validator) Option {
+	return func(o *options) { o.validators = append(o.validators, validators...) }
 }
 
 // NewRegistry creates a validation registry with the navigation validator configured for the given sections
-func NewRegistry(sections []section.Section, skipURLValidation bool) *Registry {
+func NewRegistry(sections []section.Section, skipURLValidation bool, opts ...Option) *Registry {
+	o := options{logger: slog.New(slog.DiscardHandler)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	lv := link.NewValidator()
 	lv.SkipExternal = skipURLValidation
+	if o.linkCache != nil {
+		lv.Cache = o.linkCache
+	}
+	lv.Excluded = o.excludedPages
 	iv := image.NewValidator()
 	iv.SkipExternal = skipURLValidation
-	return &Registry{
+	lv.PathPrefix, iv.PathPrefix = o.pathPrefix, o.pathPrefix
+	if o.timeout > 0 {
+		lv.Timeout = o.timeout
+		iv.Timeout = o.timeout
+	}
+	r := &Registry{
 		validators: []Validator{
 			lv,
 			iv,
-			navigation.NewValidator(sections),
+			navigation.NewValidator(sections, o.homeLabel),
+			id.NewValidator(),
 		},
+		strict: o.strict,
+		logger: o.logger,
+	}
+	if o.allowedClasses != nil {
+		r.Register(class.NewValidator(o.allowedClasses))
 	}
+	if o.maxImageSize > 0 {
+		sizes := imagesize.NewValidator(o.maxImageSize)
+		sizes.PathPrefix = o.pathPrefix
+		r.Register(sizes)
+	}
+	for _, v := range o.validators {
+		r.Register(v)
+	}
+	return r
 }
 
 // NewRegistryWithValidators creates a registry with custom validators
 func NewRegistryWithValidators(validators ...Validator) *Registry {
 	return &Registry{
 		validators: validators,
+		logger:     slog.New(slog.DiscardHandler),
 	}
 }
 
-// NewDefaultRegistry creates a validation registry with default validators (image, script, link, navigation)
-func NewDefaultRegistry(sections []section.Section, skipURLValidation bool) *Registry {
+// NewDefaultRegistry creates a validation registry with default validators (image, script, link, navigation, id)
+func NewDefaultRegistry(sections []section.Section, skipURLValidation bool, opts ...Option) *Registry {
+	o := options{logger: slog.New(slog.DiscardHandler)}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	lv := link.NewValidator()
 	lv.SkipExternal = skipURLValidation
+	if o.linkCache != nil {
+		lv.Cache = o.linkCache
+	}
+	lv.Excluded = o.excludedPages
 	iv := image.NewValidator()
 	iv.SkipExternal = skipURLValidation
-	return &Registry{
+	lv.PathPrefix, iv.PathPrefix = o.pathPrefix, o.pathPrefix
+	if o.timeout > 0 {
+		lv.Timeout = o.timeout
+		iv.Timeout = o.timeout
+	}
+	sv := script.NewValidator()
+	sv.PathPrefix = o.pathPrefix
+	r := &Registry{
 		validators: []Validator{
 			iv,
-			script.NewValidator(),
+			sv,
 			lv,
-			navigation.NewValidator(sections),
+			navigation.NewValidator(sections, o.homeLabel),
+			id.NewValidator(),
 		},
+		strict: o.strict,
+		logger: o.logger,
+	}
+	if o.allowedClasses != nil {
+		r.Register(class.NewValidator(o.allowedClasses))
+	}
+	if o.maxImageSize > 0 {
+		sizes := imagesize.NewValidator(o.maxImageSize)
+		sizes.PathPrefix = o.pathPrefix
+		r.Register(sizes)
+	}
+	for _, v := range o.validators {
+		r.Register(v)
 	}
+	return r
 }
 
 // Register adds a validator to the registry
@@ -58,11 +207,19 @@ func (r *Registry) Register(v Validator) {
 	r.validators = append(r.validators, v)
 }
 
-// Validate runs all registered validators on the given HTML content
+// Validate runs all registered validators on the given HTML content.
+// Warnings are logged and left out of the returned error, unless the validation is strict.
+// Each failure is joined in the returned error as a Failure.
 func (r *Registry) Validate(htmlPath, buildDir string, content []byte) error {
 	var errs []error
 	for _, v := range r.validators {
-		errs = append(errs, v.Validate(htmlPath, buildDir, content)...)
+		for _, err := range v.Validate(htmlPath, buildDir, content) {
+			if !r.strict && shared.SeverityOf(err) == shared.SeverityWarning {
+				r.logger.Warn(err.Error())
+				continue
+			}
+			errs = append(errs, Failure{File: htmlPath, Validator: nameOf(v), Err: err})
+		}
 	}
 	if len(errs) > 0 {
 		return errors.Join(errs...)
@@ -1,9 +1,16 @@
 package validation
 
 import (
+	"bytes"
+	"errors"
+	"log/slog"
 	"strings"
 	"testing"
 
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/alt"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/imagesize"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/link"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/shared"
 	"github.com/tjnvr/blog/internal/generator/section"
 )
 
@@ -16,6 +23,14 @@ func (f fakeValidator) Validate(htmlPath, buildDir string, content []byte) []err
 	return f.validateFunc(htmlPath, buildDir, content)
 }
 
+// namedValidator is a fakeValidator implementing Named
+type namedValidator struct {
+	fakeValidator
+	name string
+}
+
+func (n namedValidator) Name() string { return n.name }
+
 func TestNewRegistry(t *testing.T) {
 	sections := []section.Section{
 		{DirName: "", DisplayName: "Accueil"},
@@ -27,8 +42,49 @@ func TestNewRegistry(t *testing.T) {
 		t.Fatal("NewRegistry() returned nil")
 		return
 	}
-	if len(r.validators) != 3 {
-		t.Errorf("NewRegistry() should have 3 validator (link, image, navigation), got %d", len(r.validators))
+	if len(r.validators) != 4 {
+		t.Errorf("NewRegistry() should have 4 validator (link, image, navigation, id), got %d", len(r.validators))
+	}
+}
+
+func TestNewRegistry_WithLinkCache(t *testing.T) {
+	cache := link.NewCache()
+	r := NewRegistry(nil, false, WithLinkCache(cache))
+
+	lv, ok := r.validators[0].(*link.Validator)
+	if !ok {
+		t.Fatalf("expected the first validator to be the link validator, got %T", r.validators[0])
+	}
+	if lv.Cache != cache {
+		t.Error("expected the link validator to use the given cache")
+	}
+}
+
+func TestNewRegistry_WithMaxImageSize(t *testing.T) {
+	r := NewRegistry(nil, true, WithMaxImageSize(500*1024), WithPathPrefix("/blog"))
+	if len(r.validators) != 5 {
+		t.Fatalf("expected the image size validator after the default validators, got %d validators", len(r.validators))
+	}
+	iv, ok := r.validators[4].(*imagesize.Validator)
+	if !ok {
+		t.Fatalf("expected the image size validator, got %T", r.validators[4])
+	}
+	if iv.MaxBytes != 500*1024 || iv.PathPrefix != "/blog" {
+		t.Errorf("unexpected image size validator configuration: %+v", iv)
+	}
+
+	if r := NewRegistry(nil, true, WithMaxImageSize(0)); len(r.validators) != 4 {
+		t.Errorf("a zero maximum image size should add no validator, got %d validators", len(r.validators))
+	}
+}
+
+func TestRegistry_RegisterAltValidator(t *testing.T) {
+	r := NewRegistry(nil, true)
+	r.Register(alt.NewValidator())
+
+	err := r.Validate("page.html", t.TempDir(), []byte(`<img src="https://example.com/a.png">`))
+	if err == nil || !strings.Contains(err.Error(), "image without alt text") {
+		t.Errorf("expected the registered alt validator to report the image, got %v", err)
 	}
 }
 
@@ -49,8 +105,8 @@ func TestNewDefaultRegistry(t *testing.T) {
 		t.Fatal("NewDefaultRegistry() returned nil")
 		return
 	}
-	if len(r.validators) != 4 {
-		t.Errorf("NewDefaultRegistry() should have 4 validators (image, script, link, navigation), got %d", len(r.validators))
+	if len(r.validators) != 5 {
+		t.Errorf("NewDefaultRegistry() should have 5 validators (image, script, link, navigation, id), got %d", len(r.validators))
 	}
 }
 
@@ -172,3 +228,89 @@ func TestNewError(t *testing.T) {
 		t.Errorf("Error() = %q, want %q", err.Error(), expected)
 	}
 }
+
+func TestRegistry_ValidateSeverities(t *testing.T) {
+	mixed := fakeValidator{validateFunc: func(string, string, []byte) []error {
+		return []error{
+			NewError("file.html", "broken link"),
+			shared.Warning(NewError("file.html", "image without alt text")),
+		}
+	}}
+	warnings := fakeValidator{validateFunc: func(string, string, []byte) []error {
+		return []error{shared.Warning(NewError("file.html", "image without alt text"))}
+	}}
+
+	tests := []struct {
+		name      string
+		validator Validator
+		strict    bool
+		wantErrs  []string
+		wantLog   bool
+	}{
+		{
+			name:      "warnings are logged by default",
+			validator: mixed,
+			wantErrs:  []string{"file.html: broken link"},
+			wantLog:   true,
+		},
+		{
+			name:      "warnings alone do not fail",
+			validator: warnings,
+			wantLog:   true,
+		},
+		{
+			name:      "strict validation fails on warnings",
+			validator: mixed,
+			strict:    true,
+			wantErrs:  []string{"file.html: broken link", "file.html: image without alt text"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var logs bytes.Buffer
+			r := NewRegistry(nil, true, WithStrictValidation(tt.strict), WithLogger(slog.New(slog.NewTextHandler(&logs, nil))))
+			r.Register(tt.validator)
+
+			err := r.Validate("file.html", t.TempDir(), []byte("<html><nav></nav></html>"))
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+			} else if err == nil || err.Error() != strings.Join(tt.wantErrs, "\n") {
+				t.Errorf("Validate() error = %v, want %q", err, strings.Join(tt.wantErrs, "\n"))
+			}
+
+			if logged := strings.Contains(logs.String(), "image without alt text"); logged != tt.wantLog {
+				t.Errorf("warning logged = %v, want %v, logs: %q", logged, tt.wantLog, logs.String())
+			}
+		})
+	}
+}
+
+func TestRegistry_Validate_Failures(t *testing.T) {
+	broken := fakeValidator{validateFunc: func(htmlPath, _ string, _ []byte) []error {
+		return []error{errors.New(htmlPath + ": broken")}
+	}}
+	r := NewRegistryWithValidators(broken, namedValidator{fakeValidator: broken, name: "footer"}, link.NewValidator())
+
+	err := r.Validate("page.html", t.TempDir(), []byte(`<a href="missing.html">Missing</a>`))
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		t.Fatalf("Validate() = %v, want joined failures", err)
+	}
+	var got []string
+	for _, e := range joined.Unwrap() {
+		var f Failure
+		if !errors.As(e, &f) {
+			t.Fatalf("%v is not a Failure", e)
+		}
+		if f.File != "page.html" {
+			t.Errorf("File = %q, want %q", f.File, "page.html")
+		}
+		got = append(got, f.Validator)
+	}
+	if want := []string{"validation", "footer", "link"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("validators = %v, want %v", got, want)
+	}
+}
@@ -12,6 +12,8 @@ import (
 
 // Validator checks that all scripts in HTML are accessible
 type Validator struct {
+	// PathPrefix is the path the site is served under, e.g. /blog, prefixing its root-relative scripts
+	PathPrefix  string
 	scriptRegex *regexp.Regexp
 }
 
@@ -41,7 +43,7 @@ func (v *Validator) Validate(htmlPath, buildDir string, content []byte) []error
 			continue
 		}
 
-		scriptPath := shared.ResolveLocalPath(src, htmlPath, buildDir)
+		scriptPath := shared.ResolveLocalPath(shared.TrimPathPrefix(src, v.PathPrefix), htmlPath, buildDir)
 
 		if _, err := os.Stat(scriptPath); os.IsNotExist(err) {
 			errs = append(errs, fmt.Errorf("%s: local script not found: %s", htmlPath, src))
@@ -0,0 +1,93 @@
+package shared
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the default number of retries of an external URL
+	DefaultMaxRetries = 2
+	// DefaultRetryBackoff is the default wait before the first retry of an external URL
+	DefaultRetryBackoff = 500 * time.Millisecond
+
+	// getPrefixSize is how much of a GET response body is read before the connection is closed
+	getPrefixSize = 512
+)
+
+// ExternalCheck checks that external URLs are accessible.
+type ExternalCheck struct {
+	// Timeout bounds all the attempts made for one URL combined
+	Timeout time.Duration
+	// MaxRetries is the number of retries after a connection error or a 5xx response
+	MaxRetries int
+	// Backoff is the wait before the first retry, doubled on each following retry
+	Backoff time.Duration
+}
+
+// Check reports whether url answers with a 2xx or 3xx status.
+// It sends a HEAD request and falls back to a GET when the server rejects HEAD with a 403, 405 or 501,
+// as some servers do for resources that exist. Connection errors and 5xx responses are retried with
+// an exponential backoff until MaxRetries is exhausted or Timeout expires.
+func (c ExternalCheck) Check(url string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.Timeout)
+	defer cancel()
+
+	backoff := c.Backoff
+	for attempt := 0; ; attempt++ {
+		status, err := attemptCheck(ctx, url)
+		if err == nil && status >= 200 && status < 400 {
+			return nil
+		}
+		if err == nil {
+			err = fmt.Errorf("HTTP %d", status)
+		}
+
+		retryable := status >= 500 || (status == 0 && ctx.Err() == nil)
+		if !retryable || attempt >= c.MaxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// attemptCheck sends a HEAD request to url, then a GET one if HEAD is rejected, and returns the status code.
+func attemptCheck(ctx context.Context, url string) (int, error) {
+	status, err := request(ctx, http.MethodHead, url)
+	if err != nil {
+		return 0, err
+	}
+
+	if status == http.StatusForbidden || status == http.StatusMethodNotAllowed || status == http.StatusNotImplemented {
+		return request(ctx, http.MethodGet, url)
+	}
+	return status, nil
+}
+
+// request sends a method request to url and returns the response status code.
+// Only a small prefix of the body is read.
+func request(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	_, _ = io.CopyN(io.Discard, resp.Body, getPrefixSize)
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,159 @@
+package shared
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyServer answers 503 to the first failures requests, then 200
+func flakyServer(t *testing.T, failures int32) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+	return server, &calls
+}
+
+func TestExternalCheck_Check(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			w.WriteHeader(http.StatusOK)
+		case "/redirect":
+			w.WriteHeader(http.StatusNotModified)
+		case "/missing":
+			w.WriteHeader(http.StatusNotFound)
+		case "/no-head", "/forbidden-head", "/unimplemented-head":
+			if r.Method == http.MethodHead {
+				status := map[string]int{
+					"/no-head":            http.StatusMethodNotAllowed,
+					"/forbidden-head":     http.StatusForbidden,
+					"/unimplemented-head": http.StatusNotImplemented,
+				}[r.URL.Path]
+				w.WriteHeader(status)
+				return
+			}
+			_, _ = w.Write([]byte("<html>content</html>"))
+		case "/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+		case "/slow-get":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			time.Sleep(200 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name    string
+		path    string
+		timeout time.Duration
+		wantErr bool
+	}{
+		{name: "2xx on HEAD", path: "/ok"},
+		{name: "3xx on HEAD", path: "/redirect"},
+		{name: "404 on HEAD", path: "/missing", wantErr: true},
+		{name: "405 on HEAD then 200 on GET", path: "/no-head"},
+		{name: "403 on HEAD then 200 on GET", path: "/forbidden-head"},
+		{name: "501 on HEAD then 200 on GET", path: "/unimplemented-head"},
+		{name: "403 on HEAD and GET", path: "/forbidden", wantErr: true},
+		{name: "timeout covers the GET fallback", path: "/slow-get", timeout: 100 * time.Millisecond, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timeout := tt.timeout
+			if timeout == 0 {
+				timeout = 5 * time.Second
+			}
+			err := ExternalCheck{Timeout: timeout}.Check(server.URL + tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Check() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExternalCheck_Retries(t *testing.T) {
+	t.Run("succeeds after two failures", func(t *testing.T) {
+		server, calls := flakyServer(t, 2)
+		check := ExternalCheck{Timeout: 5 * time.Second, MaxRetries: 2, Backoff: time.Millisecond}
+
+		if err := check.Check(server.URL); err != nil {
+			t.Errorf("Check() error = %v, want nil after retries", err)
+		}
+		if got := calls.Load(); got != 3 {
+			t.Errorf("expected 3 attempts, got %d", got)
+		}
+	})
+
+	t.Run("fails once retries are exhausted", func(t *testing.T) {
+		server, calls := flakyServer(t, 2)
+		check := ExternalCheck{Timeout: 5 * time.Second, MaxRetries: 1, Backoff: time.Millisecond}
+
+		if err := check.Check(server.URL); err == nil || err.Error() != "HTTP 503" {
+			t.Errorf("Check() error = %v, want HTTP 503", err)
+		}
+		if got := calls.Load(); got != 2 {
+			t.Errorf("expected 2 attempts, got %d", got)
+		}
+	})
+
+	t.Run("client errors are not retried", func(t *testing.T) {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		check := ExternalCheck{Timeout: 5 * time.Second, MaxRetries: 2, Backoff: time.Millisecond}
+		if err := check.Check(server.URL); err == nil {
+			t.Error("Check() expected an error for a 404")
+		}
+		if got := calls.Load(); got != 1 {
+			t.Errorf("expected a single attempt, got %d", got)
+		}
+	})
+
+	t.Run("connection errors are retried", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		url := server.URL
+		server.Close()
+
+		start := time.Now()
+		check := ExternalCheck{Timeout: 5 * time.Second, MaxRetries: 2, Backoff: 20 * time.Millisecond}
+		if err := check.Check(url); err == nil {
+			t.Error("Check() expected an error for a closed server")
+		}
+		// Two retries wait 20ms then 40ms
+		if elapsed := time.Since(start); elapsed < 60*time.Millisecond {
+			t.Errorf("expected the retries to back off, returned after %v", elapsed)
+		}
+	})
+
+	t.Run("backoff respects the timeout", func(t *testing.T) {
+		server, _ := flakyServer(t, 100)
+		check := ExternalCheck{Timeout: 100 * time.Millisecond, MaxRetries: 5, Backoff: time.Second}
+
+		start := time.Now()
+		if err := check.Check(server.URL); err == nil {
+			t.Error("Check() expected an error")
+		}
+		if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+			t.Errorf("Check() should give up at the timeout, took %v", elapsed)
+		}
+	})
+}
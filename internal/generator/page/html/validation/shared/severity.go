@@ -0,0 +1,45 @@
+package shared
+
+import "errors"
+
+// Severity ranks validation failures: errors fail the build, warnings only do in strict validation.
+type Severity int
+
+const (
+	// SeverityError is the severity of the failures not tagged otherwise
+	SeverityError Severity = iota
+	// SeverityWarning is the severity of the failures tagged with Warning
+	SeverityWarning
+)
+
+// String returns the lowercase name of the severity
+func (s Severity) String() string {
+	if s == SeverityWarning {
+		return "warning"
+	}
+	return "error"
+}
+
+// severityError tags a validation failure with its severity
+type severityError struct {
+	err      error
+	severity Severity
+}
+
+func (e severityError) Error() string { return e.err.Error() }
+
+func (e severityError) Unwrap() error { return e.err }
+
+// Warning tags err as a warning, which does not fail the build unless validation is strict.
+func Warning(err error) error {
+	return severityError{err: err, severity: SeverityWarning}
+}
+
+// SeverityOf returns the severity err is tagged with, SeverityError when it is not tagged.
+func SeverityOf(err error) Severity {
+	var se severityError
+	if errors.As(err, &se) {
+		return se.severity
+	}
+	return SeverityError
+}
@@ -0,0 +1,36 @@
+package shared
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestSeverityOf(t *testing.T) {
+	base := errors.New("image without alt text")
+
+	tests := []struct {
+		name string
+		err  error
+		want Severity
+	}{
+		{name: "untagged error", err: base, want: SeverityError},
+		{name: "warning", err: Warning(base), want: SeverityWarning},
+		{name: "wrapped warning", err: fmt.Errorf("page.html: %w", Warning(base)), want: SeverityWarning},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SeverityOf(tt.err); got != tt.want {
+				t.Errorf("SeverityOf() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !errors.Is(Warning(base), base) {
+		t.Error("Warning() should wrap the tagged error")
+	}
+	if got := Warning(base).Error(); got != base.Error() {
+		t.Errorf("Warning().Error() = %q, want %q", got, base.Error())
+	}
+}
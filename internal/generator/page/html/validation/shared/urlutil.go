@@ -11,6 +11,18 @@ func IsExternalURL(src string) bool {
 	return strings.HasPrefix(src, "http://") || strings.HasPrefix(src, "https://")
 }
 
+// TrimPathPrefix returns the root-relative src without prefix, the path the site is served under, e.g. /blog,
+// so that it resolves from the build directory. Other paths are returned as is.
+func TrimPathPrefix(src, prefix string) string {
+	if prefix == "" {
+		return src
+	}
+	if rest, ok := strings.CutPrefix(src, prefix); ok && (rest == "" || strings.HasPrefix(rest, "/")) {
+		return "/" + strings.TrimPrefix(rest, "/")
+	}
+	return src
+}
+
 // ResolveLocalPath resolves src to an absolute file path relative to the HTML
 // file or the build root, matching how browsers resolve relative vs absolute paths.
 //
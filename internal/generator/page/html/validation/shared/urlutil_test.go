@@ -75,3 +75,25 @@ func TestResolveLocalPath(t *testing.T) {
 		})
 	}
 }
+
+func TestTrimPathPrefix(t *testing.T) {
+	tests := []struct {
+		src    string
+		prefix string
+		want   string
+	}{
+		{src: "/blog/assets/photo.png", prefix: "/blog", want: "/assets/photo.png"},
+		{src: "/blog", prefix: "/blog", want: "/"},
+		{src: "/blogroll.html", prefix: "/blog", want: "/blogroll.html"},
+		{src: "assets/photo.png", prefix: "/blog", want: "assets/photo.png"},
+		{src: "/assets/photo.png", prefix: "", want: "/assets/photo.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.src, func(t *testing.T) {
+			if got := TrimPathPrefix(tt.src, tt.prefix); got != tt.want {
+				t.Errorf("TrimPathPrefix(%q, %q) = %q, want %q", tt.src, tt.prefix, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,10 @@
 package validation
 
-import "fmt"
+import (
+	"fmt"
+	"path"
+	"reflect"
+)
 
 // Error represents a validation failure
 type Error struct {
@@ -20,9 +24,45 @@ func NewError(file, format string, args ...any) Error {
 	}
 }
 
+// Failure is a failure reported by a validator of the registry, attributed to the page and the validator.
+type Failure struct {
+	// File is the path of the validated HTML page
+	File string
+	// Validator is the name of the validator reporting the failure, e.g. "link"
+	Validator string
+	// Err is the failure as reported by the validator
+	Err error
+}
+
+func (f Failure) Error() string { return f.Err.Error() }
+
+func (f Failure) Unwrap() error { return f.Err }
+
+// Named is implemented by validators naming their failures. The failures of the other validators
+// are named after the package of the validator, e.g. "link" for link.Validator.
+type Named interface {
+	Name() string
+}
+
+// nameOf returns the name of the failures of v.
+func nameOf(v Validator) string {
+	if n, ok := v.(Named); ok {
+		return n.Name()
+	}
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return path.Base(t.PkgPath())
+}
+
 // Validator validates generated HTML content
 type Validator interface {
-	// Validate checks the HTML content and returns any validation errors
+	// Validate checks the HTML content and returns any validation errors,
+	// the ones tagged with shared.Warning only fail strict validations
 	// htmlPath is the path to the generated HTML file
 	// buildDir is the root build directory for resolving relative paths
 	Validate(htmlPath, buildDir string, content []byte) []error
@@ -0,0 +1,70 @@
+// Package htmltext extracts the text of the generated HTML pages, e.g. their first paragraph or their excerpt,
+// for the substituters and the listings which summarize a page.
+package htmltext
+
+import (
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/markdown"
+)
+
+var (
+	firstParagraphRe = regexp.MustCompile(`(?s)<p>(.*?)</p>`)
+	tagRe            = regexp.MustCompile(`<[^>]*>`)
+	titleRe          = regexp.MustCompile(`(?s)<h1[^>]*>.*?</h1>\n?`)
+)
+
+// FirstParagraph returns the plain text of the first paragraph of the page, shortened to maxLength characters.
+// Paragraphs holding only a placeholder, such as {{summary}}, are skipped.
+func FirstParagraph(content string, maxLength int) string {
+	_, text := firstParagraph(content)
+	return Truncate(text, maxLength)
+}
+
+// FirstParagraphHTML returns the first <p> element of the page, skipping the same paragraphs as FirstParagraph.
+func FirstParagraphHTML(content string) string {
+	element, _ := firstParagraph(content)
+	return element
+}
+
+// firstParagraph returns the first paragraph of the page with text other than a placeholder, and its plain text.
+func firstParagraph(content string) (element, text string) {
+	for _, m := range firstParagraphRe.FindAllStringSubmatch(content, -1) {
+		text := Text(m[1])
+		if text == "" || strings.HasPrefix(text, "{{") {
+			continue
+		}
+		return m[0], text
+	}
+	return "", ""
+}
+
+// Excerpt returns the HTML before the <!--more--> marker of the page, without the page <h1>.
+// It falls back to the first paragraph when the page has no marker, as found by FirstParagraphHTML.
+func Excerpt(content string) string {
+	if before, _, ok := strings.Cut(content, markdown.MoreMarker); ok {
+		return strings.TrimSpace(titleRe.ReplaceAllString(before, ""))
+	}
+	return FirstParagraphHTML(content)
+}
+
+// Text returns the plain text of HTML content: tags removed, entities unescaped and whitespace collapsed.
+func Text(content string) string {
+	return strings.Join(strings.Fields(StripTags(content, "")), " ")
+}
+
+// StripTags replaces the tags of HTML content with replacement and unescapes its entities.
+// A space replacement keeps the words of adjacent elements apart, e.g. to count them.
+func StripTags(content, replacement string) string {
+	return html.UnescapeString(tagRe.ReplaceAllString(content, replacement))
+}
+
+// Truncate shortens text to maxLength characters, marking the cut with an ellipsis.
+func Truncate(text string, maxLength int) string {
+	if runes := []rune(text); len(runes) > maxLength {
+		return strings.TrimSpace(string(runes[:maxLength])) + "…"
+	}
+	return text
+}
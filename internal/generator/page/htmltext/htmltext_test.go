@@ -0,0 +1,52 @@
+package htmltext
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "content before the more marker",
+			content: "<h1 id=\"title\">Title<a href=\"#title\" class=\"heading-anchor\">#</a></h1>\n<p>First.</p>\n<p>Second.</p>\n<!--more-->\n<p>Rest.</p>\n",
+			want:    "<p>First.</p>\n<p>Second.</p>",
+		},
+		{
+			name:    "first paragraph without marker",
+			content: "<h1>Title</h1>\n<p>{{summary}}</p>\n<p>First <em>real</em> paragraph.</p>\n<p>Second.</p>\n",
+			want:    "<p>First <em>real</em> paragraph.</p>",
+		},
+		{
+			name:    "no paragraph",
+			content: "<h1>Title</h1>\n<ul><li>item</li></ul>\n",
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Excerpt(tt.content); got != tt.want {
+				t.Errorf("Excerpt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripTags(t *testing.T) {
+	content := "<p>Tom &amp; <em>Jerry</em></p><p>again</p>"
+
+	if got, want := StripTags(content, ""), "Tom & Jerryagain"; got != want {
+		t.Errorf("StripTags(%q, \"\") = %q, want %q", content, got, want)
+	}
+	if got, want := strings.Fields(StripTags(content, " ")), []string{"Tom", "&", "Jerry", "again"}; strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("StripTags(%q, \" \") words = %q, want %q", content, got, want)
+	}
+	if got, want := Text(content), "Tom & Jerryagain"; got != want {
+		t.Errorf("Text(%q) = %q, want %q", content, got, want)
+	}
+}
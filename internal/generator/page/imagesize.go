@@ -0,0 +1,103 @@
+package page
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"html"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var (
+	imgTagRe     = regexp.MustCompile(`<img\s[^>]*>`)
+	imgSrcAttrRe = regexp.MustCompile(`\ssrc="([^"]*)"`)
+	imgSizeRe    = regexp.MustCompile(`\s(?:width|height)=`)
+)
+
+// addImageDimensions sets the width and height attributes of the local images of the page to the intrinsic size
+// of their file in the build directory, so that browsers reserve their space before loading them.
+// Images with a width or height already set, external images and images which cannot be read are left untouched.
+func (g *Generator) addImageDimensions(content string) string {
+	return imgTagRe.ReplaceAllStringFunc(content, func(tag string) string {
+		if imgSizeRe.MatchString(tag) {
+			return tag
+		}
+		m := imgSrcAttrRe.FindStringSubmatch(tag)
+		if m == nil {
+			return tag
+		}
+		path, ok := g.localImagePath(html.UnescapeString(m[1]))
+		if !ok {
+			return tag
+		}
+		data, err := g.fs.ReadFile(path)
+		if err != nil {
+			return tag
+		}
+		width, height, ok := imageDimensions(data)
+		if !ok {
+			return tag
+		}
+
+		end := strings.TrimRight(strings.TrimSuffix(tag, ">"), "/ ")
+		return fmt.Sprintf(`%s width="%d" height="%d"%s`, end, width, height, tag[len(end):])
+	})
+}
+
+// localImagePath returns the path in the build directory of the image at src, relative to the page or root-relative.
+func (g *Generator) localImagePath(src string) (string, bool) {
+	u, err := url.Parse(src)
+	if err != nil || u.Scheme != "" || u.Host != "" || u.Path == "" {
+		return "", false
+	}
+	if strings.HasPrefix(u.Path, "/") {
+		return filepath.Join(g.buildDir, filepath.FromSlash(u.Path)), true
+	}
+	return filepath.Join(filepath.Dir(g.destinationHTMLPath), filepath.FromSlash(u.Path)), true
+}
+
+// imageDimensions returns the intrinsic size of a PNG, JPEG, GIF or WebP image.
+func imageDimensions(data []byte) (width, height int, ok bool) {
+	if width, height, ok := webpDimensions(data); ok {
+		return width, height, true
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return 0, 0, false
+	}
+	return cfg.Width, cfg.Height, true
+}
+
+// webpDimensions reads the canvas size from the header of a lossy, lossless or extended WebP image.
+func webpDimensions(data []byte) (width, height int, ok bool) {
+	if len(data) < 30 || string(data[0:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return 0, 0, false
+	}
+	switch string(data[12:16]) {
+	case "VP8 ":
+		// Frame tag, then the 9d 01 2a start code and the 14-bit dimensions
+		if !bytes.Equal(data[23:26], []byte{0x9d, 0x01, 0x2a}) {
+			return 0, 0, false
+		}
+		return int(binary.LittleEndian.Uint16(data[26:28]) & 0x3fff), int(binary.LittleEndian.Uint16(data[28:30]) & 0x3fff), true
+	case "VP8L":
+		// Signature byte, then the 14-bit dimensions minus one
+		if data[20] != 0x2f {
+			return 0, 0, false
+		}
+		bits := binary.LittleEndian.Uint32(data[21:25])
+		return int(bits&0x3fff) + 1, int(bits>>14&0x3fff) + 1, true
+	case "VP8X":
+		// Flags and reserved bytes, then the 24-bit canvas dimensions minus one
+		return int(uint32(data[24])|uint32(data[25])<<8|uint32(data[26])<<16) + 1,
+			int(uint32(data[27])|uint32(data[28])<<8|uint32(data[29])<<16) + 1, true
+	}
+	return 0, 0, false
+}
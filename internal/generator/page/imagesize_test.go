@@ -0,0 +1,157 @@
+package page
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	htmlsubstitution "github.com/tjnvr/blog/internal/generator/page/html/substitution"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation"
+	mdsubstitution "github.com/tjnvr/blog/internal/generator/page/markdown/substitution"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestGenerator_Generate_WithImageDimensions(t *testing.T) {
+	generate := func(t *testing.T, enabled bool) string {
+		t.Helper()
+		fs := filesystem.NewMemoryFileSystem()
+		fs.AddFile("/content/page.md", []byte("# Hello\n\n![Photo](/assets/photo.png)\n"))
+		fs.AddFile("/build/assets/photo.png", encodePNG(t, 3, 2))
+
+		g := NewGenerator("/content/page.md", "/build/page.html", "/build", "",
+			fs,
+			mdsubstitution.NewRegistry("/content/page.md"),
+			htmlsubstitution.NewRegistry("/build/page.html", "/content/page.md", nil, nil, nil, ""),
+			validation.NewRegistry(nil, false),
+			WithTemplate("{{content}}"),
+			WithLazyImages(false),
+			WithImageDimensions(enabled),
+		)
+		if err := g.Generate(); err != nil {
+			t.Fatalf("Generate() unexpected error: %v", err)
+		}
+		output, _ := fs.GetFile("/build/page.html")
+		return string(output)
+	}
+
+	if output := generate(t, true); !strings.Contains(output, `<img src="/assets/photo.png" alt="Photo" width="3" height="2">`) {
+		t.Errorf("output should set the image dimensions, got %q", output)
+	}
+	if output := generate(t, false); strings.Contains(output, "width=") {
+		t.Errorf("output should not set the image dimensions by default, got %q", output)
+	}
+}
+
+func TestGenerator_addImageDimensions(t *testing.T) {
+	fs := filesystem.NewMemoryFileSystem()
+	fs.AddFile("/build/posts/photo.png", encodePNG(t, 4, 3))
+	fs.AddFile("/build/assets/logo.png", encodePNG(t, 16, 16))
+	fs.AddFile("/build/assets/notes.txt", []byte("not an image"))
+	g := &Generator{fs: fs, buildDir: "/build", destinationHTMLPath: "/build/posts/first.html"}
+
+	tests := []struct {
+		name string
+		html string
+		want string
+	}{
+		{
+			name: "relative image",
+			html: `<img src="photo.png" alt="Photo">`,
+			want: `<img src="photo.png" alt="Photo" width="4" height="3">`,
+		},
+		{
+			name: "parent relative self-closing image",
+			html: `<img src="../assets/logo.png" alt="Logo" />`,
+			want: `<img src="../assets/logo.png" alt="Logo" width="16" height="16" />`,
+		},
+		{
+			name: "root-relative image",
+			html: `<p><img src="/assets/logo.png" alt="Logo"></p>`,
+			want: `<p><img src="/assets/logo.png" alt="Logo" width="16" height="16"></p>`,
+		},
+		{
+			name: "dimensions set already",
+			html: `<img src="photo.png" alt="Photo" width="600">`,
+			want: `<img src="photo.png" alt="Photo" width="600">`,
+		},
+		{
+			name: "external image",
+			html: `<img src="https://example.org/photo.png" alt="Photo">`,
+			want: `<img src="https://example.org/photo.png" alt="Photo">`,
+		},
+		{
+			name: "missing image",
+			html: `<img src="missing.png" alt="Missing">`,
+			want: `<img src="missing.png" alt="Missing">`,
+		},
+		{
+			name: "not an image",
+			html: `<img src="../assets/notes.txt" alt="Notes">`,
+			want: `<img src="../assets/notes.txt" alt="Notes">`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.addImageDimensions(tt.html); got != tt.want {
+				t.Errorf("addImageDimensions() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageDimensions(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 5, 7))
+	var jpg, gf bytes.Buffer
+	if err := jpeg.Encode(&jpg, img, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := gif.Encode(&gf, img, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	webp := func(chunk string, header ...byte) []byte {
+		data := append([]byte("RIFF\x00\x00\x00\x00WEBP"+chunk+"\x00\x00\x00\x00"), header...)
+		return append(data, make([]byte, 32)...)
+	}
+
+	tests := []struct {
+		name          string
+		data          []byte
+		width, height int
+		ok            bool
+	}{
+		{name: "png", data: encodePNG(t, 5, 7), width: 5, height: 7, ok: true},
+		{name: "jpeg", data: jpg.Bytes(), width: 5, height: 7, ok: true},
+		{name: "gif", data: gf.Bytes(), width: 5, height: 7, ok: true},
+		// 400x300 lossy frame: frame tag, start code, little-endian dimensions
+		{name: "lossy webp", data: webp("VP8 ", 0, 0, 0, 0x9d, 0x01, 0x2a, 0x90, 0x01, 0x2c, 0x01), width: 400, height: 300, ok: true},
+		// 400x300 lossless image: signature, then (399 | 299<<14) little-endian
+		{name: "lossless webp", data: webp("VP8L", 0x2f, 0x8f, 0xc1, 0x4a, 0x00), width: 400, height: 300, ok: true},
+		// 400x300 extended image: flags and reserved bytes, then 399 and 299 on 24 bits
+		{name: "extended webp", data: webp("VP8X", 0, 0, 0, 0, 0x8f, 0x01, 0x00, 0x2b, 0x01, 0x00), width: 400, height: 300, ok: true},
+		{name: "not an image", data: []byte("hello"), ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			width, height, ok := imageDimensions(tt.data)
+			if ok != tt.ok || width != tt.width || height != tt.height {
+				t.Errorf("imageDimensions() = %d, %d, %v, want %d, %d, %v", width, height, ok, tt.width, tt.height, tt.ok)
+			}
+		})
+	}
+}
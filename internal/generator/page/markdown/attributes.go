@@ -0,0 +1,100 @@
+package markdown
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// ElementAttributes maps an HTML element name, e.g. "img", to the attributes set on every such element
+// converted from markdown, e.g. {"loading": "lazy"}. Attributes set inline with {...} take precedence.
+type ElementAttributes map[string]map[string]string
+
+// attributeElements are the element names which ElementAttributes may configure.
+var attributeElements = []string{"a", "blockquote", "code", "h1", "h2", "h3", "h4", "h5", "h6", "img", "li", "ol", "p", "table", "ul"}
+
+// attributeNameRe matches valid HTML attribute names.
+var attributeNameRe = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_.:-]*$`)
+
+// Validate reports the unknown elements and invalid attribute names of the configuration.
+func (a ElementAttributes) Validate() error {
+	errs := make([]error, 0)
+	for _, element := range slices.Sorted(maps.Keys(a)) {
+		if !slices.Contains(attributeElements, element) {
+			errs = append(errs, fmt.Errorf("attributes of unknown element %q, expected one of %v", element, attributeElements))
+			continue
+		}
+		for _, name := range slices.Sorted(maps.Keys(a[element])) {
+			if !attributeNameRe.MatchString(name) {
+				errs = append(errs, fmt.Errorf("invalid attribute name %q for element %q", name, element))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// elementName returns the name of the HTML element node is rendered as, or "" when it cannot be configured.
+func elementName(node ast.Node) string {
+	switch n := node.(type) {
+	case *ast.Link, *ast.AutoLink:
+		return "a"
+	case *ast.Image:
+		return "img"
+	case *ast.Heading:
+		return fmt.Sprintf("h%d", n.Level)
+	case *ast.Paragraph:
+		return "p"
+	case *ast.Blockquote:
+		return "blockquote"
+	case *ast.CodeSpan:
+		return "code"
+	case *ast.List:
+		if n.IsOrdered() {
+			return "ol"
+		}
+		return "ul"
+	case *ast.ListItem:
+		return "li"
+	case *extast.Table:
+		return "table"
+	}
+	return ""
+}
+
+// attributesTransformer sets the configured attributes on the elements which do not set them inline.
+type attributesTransformer struct {
+	attributes ElementAttributes
+}
+
+func (t attributesTransformer) Transform(doc *ast.Document, _ text.Reader, _ parser.Context) {
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		attrs := t.attributes[elementName(node)]
+		for _, name := range slices.Sorted(maps.Keys(attrs)) {
+			if _, ok := node.AttributeString(name); !ok {
+				node.SetAttributeString(name, []byte(attrs[name]))
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// attributesExtender is the goldmark extension setting the configured attributes.
+type attributesExtender struct {
+	attributes ElementAttributes
+}
+
+func (e attributesExtender) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(attributesTransformer{attributes: e.attributes}, 100)))
+}
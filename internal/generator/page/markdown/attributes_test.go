@@ -0,0 +1,100 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverter_ElementAttributes(t *testing.T) {
+	attributes := ElementAttributes{
+		"img": {"loading": "lazy", "decoding": "async"},
+		"a":   {"rel": "noopener"},
+		"h2":  {"class": "section-title"},
+	}
+
+	tests := []struct {
+		name        string
+		input       string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "images get the configured attributes",
+			input:    "![Photo](photo.png)",
+			contains: []string{`<img src="photo.png" alt="Photo" decoding="async" loading="lazy">`},
+		},
+		{
+			name:     "links get the configured attributes",
+			input:    "[Link](https://example.com) and <https://example.org>",
+			contains: []string{`<a href="https://example.com" rel="noopener">Link</a>`, `<a href="https://example.org" rel="noopener">`},
+		},
+		{
+			name:     "headings of the configured level only",
+			input:    "## Section\n\n### Sub-section",
+			contains: []string{`<h2 id="section" class="section-title">`, `<h3 id="sub-section">`},
+		},
+		{
+			name:        "inline attributes take precedence",
+			input:       "## Section {.custom}",
+			contains:    []string{`class="custom"`},
+			notContains: []string{"section-title"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConverter(WithElementAttributes(attributes)).Convert([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Convert() should contain %q, got:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("Convert() should not contain %q, got:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
+
+func TestElementAttributes_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		attributes ElementAttributes
+		wantErr    string
+	}{
+		{
+			name:       "valid configuration",
+			attributes: ElementAttributes{"img": {"loading": "lazy"}, "a": {"data-external": "true"}},
+		},
+		{
+			name:       "unknown element",
+			attributes: ElementAttributes{"image": {"loading": "lazy"}},
+			wantErr:    `attributes of unknown element "image"`,
+		},
+		{
+			name:       "invalid attribute name",
+			attributes: ElementAttributes{"img": {"load ing": "lazy"}},
+			wantErr:    `invalid attribute name "load ing" for element "img"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.attributes.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package markdown
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+)
+
+// DefaultCacheSize is the number of conversions kept by a Cache created with a size of 0 or less.
+const DefaultCacheSize = 1024
+
+// Cache memoizes the HTML of the markdown sources, keyed on the hash of the source and of the converter configuration,
+// so that the unchanged pages of repeated builds, e.g. in watch mode, are not converted again.
+// It keeps the most recently used conversions up to its size. It is safe for concurrent use and meant to be shared
+// by the converters of successive builds.
+type Cache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type cacheEntry struct {
+	key  string
+	html string
+}
+
+// NewCache creates an empty conversion cache keeping up to size conversions, DefaultCacheSize when size is 0 or less.
+func NewCache(size int) *Cache {
+	if size <= 0 {
+		size = DefaultCacheSize
+	}
+	return &Cache{size: size, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// Len returns the number of cached conversions.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// get returns the cached HTML of key, marking it as the most recently used.
+func (c *Cache) get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).html, true
+}
+
+// put caches the HTML of key, evicting the least recently used conversion when the cache is full.
+func (c *Cache) put(key, html string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*cacheEntry).html = html
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, html: html})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
+
+// cacheKey returns the key of the conversion of source by a converter of the fingerprint configuration.
+func cacheKey(fingerprint string, source []byte) string {
+	h := sha256.New()
+	h.Write([]byte(fingerprint))
+	h.Write([]byte{0})
+	h.Write(source)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// fingerprint returns a representation of the configuration changing the HTML of the conversions,
+// the configuration pointed to included.
+func (c config) fingerprint() string {
+	var policy, codeBlocks any
+	if c.policy != nil {
+		policy = *c.policy
+	}
+	if c.codeBlocks != nil {
+		codeBlocks = *c.codeBlocks
+	}
+	c.policy, c.codeBlocks, c.cache = nil, nil, nil
+	return fmt.Sprintf("%#v %#v %#v", c, policy, codeBlocks)
+}
@@ -0,0 +1,119 @@
+package markdown
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestConverter_Cache(t *testing.T) {
+	source := []byte("# Hello\n\n```go\nfmt.Println(1)\n```\n")
+	cache := NewCache(0)
+
+	want, err := NewConverter().Convert(source)
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	converter := NewConverter(WithCache(cache))
+	for range 2 {
+		html, err := converter.Convert(source)
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if html != want {
+			t.Errorf("cached Convert() = %q, want %q", html, want)
+		}
+	}
+	if cache.Len() != 1 {
+		t.Errorf("cache should have 1 conversion, got %d", cache.Len())
+	}
+
+	// A converter of the next build with the same options hits the cache
+	if _, err := NewConverter(WithCache(cache)).Convert(source); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if cache.Len() != 1 {
+		t.Errorf("same options should hit the cache, got %d conversions", cache.Len())
+	}
+}
+
+func TestConverter_Cache_ConfigChange(t *testing.T) {
+	source := []byte("```go\nfmt.Println(1)\n```\n")
+	cache := NewCache(0)
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{name: "default"},
+		{name: "syntax theme", opts: []Option{WithSyntaxTheme("monokai")}},
+		{name: "code blocks", opts: []Option{WithCodeBlocks(CodeBlocks{})}},
+		{name: "code blocks line numbers", opts: []Option{WithCodeBlocks(CodeBlocks{LineNumbers: true})}},
+	}
+
+	seen := make(map[string]string)
+	for _, tt := range tests {
+		opts := append([]Option{WithCache(cache)}, tt.opts...)
+		html, err := NewConverter(opts...).Convert(source)
+		if err != nil {
+			t.Fatalf("%s: Convert() error = %v", tt.name, err)
+		}
+		want, err := NewConverter(tt.opts...).Convert(source)
+		if err != nil {
+			t.Fatalf("%s: Convert() error = %v", tt.name, err)
+		}
+		if html != want {
+			t.Errorf("%s: cached Convert() = %q, want %q", tt.name, html, want)
+		}
+		for name, other := range seen {
+			if html == other {
+				t.Errorf("%s: got the HTML of %s", tt.name, name)
+			}
+		}
+		seen[tt.name] = html
+	}
+	if cache.Len() != len(tests) {
+		t.Errorf("each configuration should be cached apart, got %d conversions", cache.Len())
+	}
+}
+
+func TestCache_Eviction(t *testing.T) {
+	cache := NewCache(2)
+	cache.put("a", "A")
+	cache.put("b", "B")
+	cache.get("a")
+	cache.put("c", "C")
+
+	if _, ok := cache.get("b"); ok {
+		t.Error("the least recently used conversion should be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.get(key); !ok {
+			t.Errorf("%s should be cached", key)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("cache should keep 2 conversions, got %d", cache.Len())
+	}
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	cache := NewCache(8)
+	converter := NewConverter(WithCache(cache))
+
+	var wg sync.WaitGroup
+	for i := range 64 {
+		wg.Go(func() {
+			source := fmt.Sprintf("# Post %d\n", i%16)
+			if _, err := converter.Convert([]byte(source)); err != nil {
+				t.Errorf("Convert() error = %v", err)
+			}
+		})
+	}
+	wg.Wait()
+
+	if cache.Len() > 8 {
+		t.Errorf("cache should keep at most 8 conversions, got %d", cache.Len())
+	}
+}
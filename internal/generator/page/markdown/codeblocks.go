@@ -0,0 +1,121 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// CodeBlocks configures the markup wrapping the fenced code blocks, for a script of the scripts directory to copy
+// their code and for styles to number their lines. The code itself is rendered as without it.
+type CodeBlocks struct {
+	// Class is the class of the <div> wrapping each code block, "code-block" when empty
+	Class string
+	// CopyClass is the class of the copy <button> preceding the code, "copy" when empty
+	CopyClass string
+	// LineNumbers adds a <span> with LineNumberClass, "line-number" when empty, per line of code before the code,
+	// in a <span aria-hidden="true"> so that the numbers are neither read nor copied with the code
+	LineNumbers     bool
+	LineNumberClass string
+}
+
+// kindCodeBlockWrapper is the kind of the codeBlockWrapper nodes.
+var kindCodeBlockWrapper = ast.NewNodeKind("CodeBlockWrapper")
+
+type (
+	// codeBlockWrapper is the block wrapping a fenced code block of lines lines.
+	codeBlockWrapper struct {
+		ast.BaseBlock
+		lines int
+	}
+
+	// codeBlocksExtender wraps the fenced code blocks in a codeBlockWrapper rendered as configured.
+	codeBlocksExtender struct {
+		CodeBlocks
+	}
+)
+
+func (n *codeBlockWrapper) Kind() ast.NodeKind { return kindCodeBlockWrapper }
+
+func (n *codeBlockWrapper) Dump(source []byte, level int) { ast.DumpHelper(n, source, level, nil, nil) }
+
+// withDefaults returns c with the default classes in place of the empty ones.
+func (c CodeBlocks) withDefaults() CodeBlocks {
+	if c.Class == "" {
+		c.Class = "code-block"
+	}
+	if c.CopyClass == "" {
+		c.CopyClass = "copy"
+	}
+	if c.LineNumberClass == "" {
+		c.LineNumberClass = "line-number"
+	}
+	return c
+}
+
+// Classes returns the classes of the markup, defaults included, e.g. to allow them in a class allowlist.
+func (c CodeBlocks) Classes() []string {
+	c = c.withDefaults()
+	classes := []string{c.Class, c.CopyClass}
+	if c.LineNumbers {
+		classes = append(classes, c.LineNumberClass)
+	}
+	return classes
+}
+
+func (e codeBlocksExtender) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(e, 300)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(e, 500)))
+}
+
+func (e codeBlocksExtender) Transform(doc *ast.Document, _ text.Reader, _ parser.Context) {
+	var blocks []*ast.FencedCodeBlock
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if block, ok := node.(*ast.FencedCodeBlock); ok && entering {
+			blocks = append(blocks, block)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	// Blocks are wrapped once walked, not to change the tree during the walk
+	for _, block := range blocks {
+		wrapper := &codeBlockWrapper{lines: block.Lines().Len()}
+		parent := block.Parent()
+		parent.ReplaceChild(parent, block, wrapper)
+		wrapper.AppendChild(wrapper, block)
+	}
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (e codeBlocksExtender) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindCodeBlockWrapper, e.render)
+}
+
+func (e codeBlocksExtender) render(w util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		_, _ = w.WriteString("</div>\n")
+		return ast.WalkContinue, nil
+	}
+
+	c := e.withDefaults()
+	_, _ = fmt.Fprintf(w, `<div class="%s"><button class="%s" type="button" aria-label="Copy code">Copy</button>`,
+		html.EscapeString(c.Class), html.EscapeString(c.CopyClass))
+	if c.LineNumbers {
+		_, _ = w.WriteString(`<span aria-hidden="true">`)
+		for i := 1; i <= node.(*codeBlockWrapper).lines; i++ {
+			if i > 1 {
+				_ = w.WriteByte('\n')
+			}
+			_, _ = fmt.Fprintf(w, `<span class="%s">%d</span>`, html.EscapeString(c.LineNumberClass), i)
+		}
+		_, _ = w.WriteString(`</span>`)
+	}
+	_ = w.WriteByte('\n')
+	return ast.WalkContinue, nil
+}
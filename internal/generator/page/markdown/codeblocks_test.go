@@ -0,0 +1,81 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverter_CodeBlocks(t *testing.T) {
+	source := "```go\nfmt.Println(\"<a>\")\nreturn\n```\n\n```\nplain < text\n```\n\n    indented\n"
+	plain, err := NewConverter().Convert([]byte(source))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		codeBlocks CodeBlocks
+		want       []string
+		avoid      []string
+	}{
+		{
+			name: "copy button",
+			want: []string{
+				`<div class="code-block"><button class="copy" type="button" aria-label="Copy code">Copy</button>` + "\n<pre",
+				`<div class="code-block"><button class="copy" type="button" aria-label="Copy code">Copy</button>` + "\n<pre><code>plain &lt; text\n</code></pre>\n</div>",
+			},
+			avoid: []string{"line-number"},
+		},
+		{
+			name:       "line numbers",
+			codeBlocks: CodeBlocks{LineNumbers: true},
+			want: []string{
+				`Copy</button><span aria-hidden="true"><span class="line-number">1</span>` + "\n" + `<span class="line-number">2</span></span>` + "\n<pre",
+				`Copy</button><span aria-hidden="true"><span class="line-number">1</span></span>` + "\n<pre><code>plain",
+			},
+		},
+		{
+			name:       "configured classes",
+			codeBlocks: CodeBlocks{Class: "relative", CopyClass: "copy-button", LineNumbers: true, LineNumberClass: "ln"},
+			want:       []string{`<div class="relative"><button class="copy-button"`, `<span class="ln">1</span>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := NewConverter(WithCodeBlocks(tt.codeBlocks)).Convert([]byte(source))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("Convert() = %q, want it to contain %q", html, want)
+				}
+			}
+			for _, avoid := range tt.avoid {
+				if strings.Contains(html, avoid) {
+					t.Errorf("Convert() = %q, want it not to contain %q", html, avoid)
+				}
+			}
+			if strings.Count(html, "<div") != 2 || strings.Count(html, "</div>") != 2 {
+				t.Errorf("Convert() = %q, want the two fenced code blocks wrapped once", html)
+			}
+
+			// The code itself is rendered as without the wrapper
+			for _, block := range strings.SplitAfter(plain, "</pre>") {
+				if block = strings.TrimSpace(block); strings.HasPrefix(block, "<pre") && !strings.Contains(html, block) {
+					t.Errorf("Convert() = %q, want it to contain the code block %q", html, block)
+				}
+			}
+		})
+	}
+}
+
+func TestCodeBlocks_Classes(t *testing.T) {
+	if got := strings.Join(CodeBlocks{}.Classes(), " "); got != "code-block copy" {
+		t.Errorf("Classes() = %q, want %q", got, "code-block copy")
+	}
+	if got := strings.Join(CodeBlocks{LineNumbers: true, Class: "relative"}.Classes(), " "); got != "relative copy line-number" {
+		t.Errorf("Classes() = %q, want %q", got, "relative copy line-number")
+	}
+}
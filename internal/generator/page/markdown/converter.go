@@ -10,34 +10,239 @@ import (
 	"github.com/yuin/goldmark/util"
 )
 
-// Converter wraps goldmark for markdown to HTML conversion
-type Converter struct {
-	md goldmark.Markdown
+type (
+	// Converter wraps goldmark for markdown to HTML conversion.
+	// It is safe for concurrent use: the parser and renderers are built once and keep no state between conversions,
+	// the state of a conversion, such as the heading ids, lives in its parser context.
+	Converter struct {
+		md goldmark.Markdown
+		// cache memoizes the conversions when set, keyed with fingerprint, the configuration of the converter
+		cache       *Cache
+		fingerprint string
+	}
+
+	// Option configures a Converter
+	Option func(*config)
+
+	config struct {
+		syntaxTheme string
+		// autolink turns the bare URLs, e.g. https://example.com, into links
+		autolink   bool
+		math       bool
+		emoji      bool
+		attributes ElementAttributes
+		lazyImages bool
+		// externalLinks marks the links to other hosts than siteHost
+		externalLinks bool
+		siteHost      string
+		newTab        bool
+		// unsafeHTML renders the raw HTML, sanitized with policy unless it is nil
+		unsafeHTML bool
+		policy     *Policy
+		// linkIcon is the class of the marker appended to the external links, which are not marked when it is empty
+		linkIcon         string
+		linkIconHost     string
+		linkIconNoMailto bool
+		// codeBlocks wraps the fenced code blocks when set
+		codeBlocks *CodeBlocks
+		// headingAnchorClass and headingAnchorSymbol are the class and the text of the anchor links of the headings
+		headingAnchorClass  string
+		headingAnchorSymbol string
+		// tableWrapper is the class of the <div> wrapping the tables, which are not wrapped when it is empty
+		tableWrapper string
+		// cache memoizes the conversions when set
+		cache *Cache
+	}
+)
+
+// WithSyntaxTheme returns an Option that sets the chroma style highlighting fenced code blocks, e.g. "monokai".
+// An empty or unknown name falls back to the default style.
+func WithSyntaxTheme(name string) Option {
+	return func(c *config) {
+		if name != "" {
+			c.syntaxTheme = name
+		}
+	}
+}
+
+// WithAutolink returns an Option that turns the bare URLs of the text, e.g. https://example.com or www.example.com,
+// into links, as GitHub does. It is enabled by default, bare URLs are left as text when disabled.
+func WithAutolink(enabled bool) Option {
+	return func(c *config) { c.autolink = enabled }
+}
+
+// WithMath returns an Option that renders $...$ and $$...$$ LaTeX math in elements picked up by client-side renderers.
+func WithMath(enabled bool) Option {
+	return func(c *config) { c.math = enabled }
+}
+
+// WithEmoji returns an Option that replaces the :name: shortcodes of Emojis, e.g. :tada:, with their emoji outside of code.
+func WithEmoji(enabled bool) Option {
+	return func(c *config) { c.emoji = enabled }
+}
+
+// WithElementAttributes returns an Option that sets attributes on the converted elements, e.g. loading="lazy" on images.
+func WithElementAttributes(attributes ElementAttributes) Option {
+	return func(c *config) { c.attributes = attributes }
+}
+
+// WithLazyImages returns an Option that sets loading="lazy" and decoding="async" on images,
+// unless set inline, e.g. ![Photo](photo.png){loading=eager} for images of the first viewport.
+func WithLazyImages(enabled bool) Option {
+	return func(c *config) { c.lazyImages = enabled }
+}
+
+// WithExternalLinks returns an Option that sets rel="noopener noreferrer" on the http and https links to other hosts
+// than siteHost, e.g. "example.com", and target="_blank" when newTab is set.
+func WithExternalLinks(siteHost string, newTab bool) Option {
+	return func(c *config) { c.externalLinks, c.siteHost, c.newTab = true, siteHost, newTab }
+}
+
+// WithUnsafeHTML returns an Option that renders the raw HTML of the markdown instead of omitting it.
+// Raw HTML is rendered as is unless a policy is set with WithSanitizer, only enable it for trusted markdown.
+func WithUnsafeHTML(enabled bool) Option {
+	return func(c *config) { c.unsafeHTML = enabled }
 }
 
-// NewConverter creates a new markdown converter with GFM extensions.
-func NewConverter() *Converter {
-	return &Converter{
+// WithSanitizer returns an Option that sanitizes the raw HTML rendered with WithUnsafeHTML with policy,
+// e.g. DefaultPolicy(). A nil policy renders raw HTML as is.
+func WithSanitizer(policy *Policy) Option {
+	return func(c *config) { c.policy = policy }
+}
+
+// WithTableWrapper returns an Option that wraps the tables in a <div> with class, DefaultTableWrapperClass when empty,
+// so that they can be styled to scroll horizontally instead of overflowing narrow screens.
+func WithTableWrapper(class string) Option {
+	return func(c *config) {
+		if class == "" {
+			class = DefaultTableWrapperClass
+		}
+		c.tableWrapper = class
+	}
+}
+
+// WithExternalLinkIcon returns an Option that appends an empty <span> with class, DefaultExternalLinkIconClass when empty,
+// and aria-hidden="true" to the http and https links to other hosts than siteHost, to style it as an icon.
+// The mailto: and tel: links are marked too, unless excludeMailto is set.
+func WithExternalLinkIcon(siteHost, class string, excludeMailto bool) Option {
+	return func(c *config) {
+		if class == "" {
+			class = DefaultExternalLinkIconClass
+		}
+		c.linkIcon, c.linkIconHost, c.linkIconNoMailto = class, siteHost, excludeMailto
+	}
+}
+
+// WithHeadingAnchor returns an Option that sets the class and the text of the anchor links appended to the headings,
+// DefaultHeadingAnchorClass and DefaultHeadingAnchorSymbol when empty, e.g. WithHeadingAnchor("anchor", "¶").
+func WithHeadingAnchor(class, symbol string) Option {
+	return func(c *config) { c.headingAnchorClass, c.headingAnchorSymbol = class, symbol }
+}
+
+// WithCodeBlocks returns an Option that wraps the fenced code blocks in a <div> with a copy <button>
+// and, when configured, line numbers, as set by codeBlocks.
+func WithCodeBlocks(codeBlocks CodeBlocks) Option {
+	return func(c *config) { c.codeBlocks = &codeBlocks }
+}
+
+// WithCache returns an Option that memoizes the conversions in cache, shared by the converters of successive builds
+// so that unchanged sources are not converted again. Converters of different options do not share conversions.
+func WithCache(cache *Cache) Option {
+	return func(c *config) { c.cache = cache }
+}
+
+// NewConverter creates a new markdown converter with GFM, footnotes and definition lists extensions and syntax highlighting.
+// Headings without an explicit {#id} get an id slugified from their text.
+// Images take the {...} attributes following them, e.g. ![Photo](photo.png){width=600}.
+// Raw HTML is omitted unless WithUnsafeHTML is set.
+func NewConverter(opts ...Option) *Converter {
+	cfg := config{syntaxTheme: DefaultSyntaxTheme, autolink: true}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	// GFM without its linkify extension, added when autolinks are enabled
+	extensions := []goldmark.Extender{extension.Table, extension.Strikethrough, extension.TaskList, footnotes, extension.DefinitionList, highlighter(cfg.syntaxTheme), imageAttributes}
+	if cfg.autolink {
+		extensions = append(extensions, extension.Linkify)
+	}
+	if cfg.math {
+		extensions = append(extensions, math)
+	}
+	if cfg.emoji {
+		extensions = append(extensions, emoji)
+	}
+	if cfg.externalLinks {
+		extensions = append(extensions, externalLinksExtender{siteHost: cfg.siteHost, newTab: cfg.newTab})
+	}
+	if cfg.lazyImages {
+		extensions = append(extensions, attributesExtender{attributes: lazyImageAttributes})
+	}
+	if len(cfg.attributes) > 0 {
+		extensions = append(extensions, attributesExtender{attributes: cfg.attributes})
+	}
+	if cfg.linkIcon != "" {
+		extensions = append(extensions, externalLinkIconExtender{siteHost: cfg.linkIconHost, class: cfg.linkIcon, excludeMailto: cfg.linkIconNoMailto})
+	}
+	if cfg.codeBlocks != nil {
+		extensions = append(extensions, codeBlocksExtender{CodeBlocks: *cfg.codeBlocks})
+	}
+	if cfg.tableWrapper != "" {
+		extensions = append(extensions, tableWrapperExtender{class: cfg.tableWrapper})
+	}
+
+	var rawHTMLRenderer renderer.NodeRenderer = &MoreRenderer{}
+	if cfg.unsafeHTML {
+		rawHTMLRenderer = &RawHTMLRenderer{Policy: cfg.policy}
+	}
+
+	converter := &Converter{
+		cache: cfg.cache,
 		md: goldmark.New(
-			goldmark.WithExtensions(extension.GFM),
+			goldmark.WithExtensions(extensions...),
 			goldmark.WithParserOptions(
 				parser.WithAttribute(),
 				parser.WithAutoHeadingID(),
 			),
 			goldmark.WithRendererOptions(
 				renderer.WithNodeRenderers(
-					util.Prioritized(&HeadingRenderer{}, 100),
+					util.Prioritized(&HeadingRenderer{Class: cfg.headingAnchorClass, Symbol: cfg.headingAnchorSymbol}, 100),
+					util.Prioritized(&FootnoteListRenderer{}, 100),
+					util.Prioritized(rawHTMLRenderer, 100),
 				),
 			),
 		),
 	}
+	if cfg.cache != nil {
+		converter.fingerprint = cfg.fingerprint()
+	}
+	return converter
+}
+
+// RenderString converts the markdown snippet source to HTML with a converter of opts, e.g. WithElementAttributes
+// to style its elements, without the page pipeline: the content substitutions such as the links to other pages do not apply.
+// Use a Converter to convert several snippets with the same options.
+func RenderString(source string, opts ...Option) (string, error) {
+	return NewConverter(opts...).Convert([]byte(source))
 }
 
-// Convert converts markdown source to HTML
+// Convert converts markdown source to HTML, or returns its cached HTML when the converter has a cache.
 func (c *Converter) Convert(source []byte) (string, error) {
+	var key string
+	if c.cache != nil {
+		key = cacheKey(c.fingerprint, source)
+		if html, ok := c.cache.get(key); ok {
+			return html, nil
+		}
+	}
+
 	var buf bytes.Buffer
-	if err := c.md.Convert(source, &buf); err != nil {
+	ctx := parser.NewContext(parser.WithIDs(newSlugIDs()))
+	if err := c.md.Convert(source, &buf, parser.WithContext(ctx)); err != nil {
 		return "", err
 	}
+	if c.cache != nil {
+		c.cache.put(key, buf.String())
+	}
 	return buf.String(), nil
 }
@@ -1,7 +1,9 @@
 package markdown
 
 import (
+	"fmt"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -50,7 +52,7 @@ func TestConverter_Convert(t *testing.T) {
 		{
 			name:     "converts code block with GFM",
 			input:    "```go\nfunc main() {}\n```",
-			contains: []string{`<pre><code class="language-go">`},
+			contains: []string{`<code class="language-go">`},
 		},
 		{
 			name:     "converts inline code",
@@ -178,3 +180,120 @@ func TestConverter_InlineAttributes(t *testing.T) {
 		})
 	}
 }
+
+func TestConverter_Autolink(t *testing.T) {
+	const source = "See https://example.com/docs?page=2 for details.\n\nOr [the docs](https://example.com/docs).\n"
+
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "enabled by default",
+			want: `<p>See <a href="https://example.com/docs?page=2">https://example.com/docs?page=2</a> for details.</p>`,
+		},
+		{
+			name: "disabled",
+			opts: []Option{WithAutolink(false)},
+			want: `<p>See https://example.com/docs?page=2 for details.</p>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := NewConverter(tt.opts...).Convert([]byte(source))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if !strings.Contains(html, tt.want) {
+				t.Errorf("Convert() = %q, want it to contain %q", html, tt.want)
+			}
+			// Explicit links are kept either way
+			if !strings.Contains(html, `<a href="https://example.com/docs">the docs</a>`) {
+				t.Errorf("Convert() = %q, want the explicit link", html)
+			}
+		})
+	}
+}
+
+func TestConverter_ConvertConcurrently(t *testing.T) {
+	converter := NewConverter(WithMath(true), WithLazyImages(true), WithExternalLinks("example.org", true))
+	source := func(i int) string {
+		return fmt.Sprintf("# Post %d\n\n## Intro\n\n## Intro\n\nText[^1] with $x_%d$ and [a link](https://other.org/%d).\n\n"+
+			"![Photo](photo-%d.png)\n\n```go\nfmt.Println(%d)\n```\n\n[^1]: Note %d\n", i, i, i, i, i, i)
+	}
+
+	// Reference outputs converted one after the other
+	const documents = 64
+	want := make([]string, documents)
+	for i := range want {
+		html, err := converter.Convert([]byte(source(i)))
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		want[i] = html
+	}
+
+	var wg sync.WaitGroup
+	got := make([]string, documents)
+	errs := make([]error, documents)
+	for i := range documents {
+		wg.Go(func() {
+			got[i], errs[i] = converter.Convert([]byte(source(i)))
+		})
+	}
+	wg.Wait()
+
+	for i := range documents {
+		if errs[i] != nil {
+			t.Errorf("document %d: Convert() error = %v", i, errs[i])
+		} else if got[i] != want[i] {
+			t.Errorf("document %d converted concurrently = %q, want %q", i, got[i], want[i])
+		}
+	}
+	if !strings.Contains(want[3], `<h2 id="intro-1">`) {
+		t.Errorf("heading ids should be unique per document, got %q", want[3])
+	}
+}
+
+func TestRenderString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		opts     []Option
+		contains []string
+	}{
+		{
+			name:     "default options",
+			input:    "Some *emphasis*",
+			contains: []string{"<p>Some <em>emphasis</em></p>"},
+		},
+		{
+			name:     "element attributes style the snippet",
+			input:    "## Section\n\n[Link](https://example.com)",
+			opts:     []Option{WithElementAttributes(ElementAttributes{"h2": {"class": "text-xl"}, "a": {"class": "underline"}})},
+			contains: []string{`<h2 id="section" class="text-xl">`, `<a href="https://example.com" class="underline">Link</a>`},
+		},
+		{
+			name:     "table wrapper",
+			input:    "| a |\n|---|\n| 1 |",
+			opts:     []Option{WithTableWrapper("overflow-x-auto")},
+			contains: []string{"<div class=\"overflow-x-auto\">\n<table>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderString(tt.input, tt.opts...)
+			if err != nil {
+				t.Fatalf("RenderString() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("RenderString() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+type (
+	emojiParser    struct{}
+	emojiExtension struct{}
+)
+
+// emoji replaces the :name: shortcodes of Emojis with their emoji outside of code.
+var emoji goldmark.Extender = emojiExtension{}
+
+// Emojis maps the recognized shortcodes, without colons, to their emoji. Names follow the GitHub shortcodes.
+var Emojis = map[string]string{
+	"+1":                       "👍",
+	"-1":                       "👎",
+	"100":                      "💯",
+	"airplane":                 "✈️",
+	"alarm_clock":              "⏰",
+	"angry":                    "😠",
+	"apple":                    "🍎",
+	"arrow_down":               "⬇️",
+	"arrow_left":               "⬅️",
+	"arrow_right":              "➡️",
+	"arrow_up":                 "⬆️",
+	"art":                      "🎨",
+	"beer":                     "🍺",
+	"bell":                     "🔔",
+	"bike":                     "🚲",
+	"book":                     "📖",
+	"books":                    "📚",
+	"boom":                     "💥",
+	"brain":                    "🧠",
+	"bug":                      "🐛",
+	"bulb":                     "💡",
+	"calendar":                 "📆",
+	"camera":                   "📷",
+	"cat":                      "🐱",
+	"chart_with_upwards_trend": "📈",
+	"clap":                     "👏",
+	"clipboard":                "📋",
+	"cloud":                    "☁️",
+	"coffee":                   "☕",
+	"computer":                 "💻",
+	"confused":                 "😕",
+	"construction":             "🚧",
+	"cry":                      "😢",
+	"dog":                      "🐶",
+	"email":                    "📧",
+	"exclamation":              "❗",
+	"eyes":                     "👀",
+	"fire":                     "🔥",
+	"flag_fr":                  "🇫🇷",
+	"gear":                     "⚙️",
+	"gift":                     "🎁",
+	"globe_with_meridians":     "🌐",
+	"grin":                     "😁",
+	"hammer":                   "🔨",
+	"hammer_and_wrench":        "🛠️",
+	"heart":                    "❤️",
+	"heavy_check_mark":         "✔️",
+	"hourglass":                "⌛",
+	"house":                    "🏠",
+	"hugs":                     "🤗",
+	"information_source":       "ℹ️",
+	"joy":                      "😂",
+	"key":                      "🔑",
+	"laughing":                 "😆",
+	"link":                     "🔗",
+	"lock":                     "🔒",
+	"mag":                      "🔍",
+	"memo":                     "📝",
+	"moon":                     "🌔",
+	"muscle":                   "💪",
+	"musical_note":             "🎵",
+	"ok_hand":                  "👌",
+	"package":                  "📦",
+	"pencil2":                  "✏️",
+	"point_right":              "👉",
+	"pray":                     "🙏",
+	"pushpin":                  "📌",
+	"question":                 "❓",
+	"raised_hands":             "🙌",
+	"recycle":                  "♻️",
+	"robot":                    "🤖",
+	"rocket":                   "🚀",
+	"rotating_light":           "🚨",
+	"scream":                   "😱",
+	"see_no_evil":              "🙈",
+	"shrug":                    "🤷",
+	"skull":                    "💀",
+	"smile":                    "😄",
+	"smiley":                   "😃",
+	"snake":                    "🐍",
+	"snowflake":                "❄️",
+	"sob":                      "😭",
+	"sparkles":                 "✨",
+	"star":                     "⭐",
+	"sunglasses":               "😎",
+	"sunny":                    "☀️",
+	"tada":                     "🎉",
+	"thinking":                 "🤔",
+	"thumbsdown":               "👎",
+	"thumbsup":                 "👍",
+	"trophy":                   "🏆",
+	"warning":                  "⚠️",
+	"wave":                     "👋",
+	"white_check_mark":         "✅",
+	"wink":                     "😉",
+	"wrench":                   "🔧",
+	"x":                        "❌",
+	"zap":                      "⚡",
+}
+
+func (emojiExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithInlineParsers(util.Prioritized(emojiParser{}, 999)))
+}
+
+func (emojiParser) Trigger() []byte { return []byte{':'} }
+
+// Parse replaces a known :name: shortcode with its emoji. Shortcodes following a letter or a digit,
+// e.g. in 10:30:00, are not recognized.
+func (emojiParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	if prev := block.PrecendingCharacter(); util.IsAlphaNumeric(byte(prev)) && prev < 0x80 {
+		return nil
+	}
+	line, _ := block.PeekLine()
+	end := bytes.IndexByte(line[1:], ':')
+	if end <= 0 {
+		return nil
+	}
+	value, ok := Emojis[string(line[1:end+1])]
+	if !ok {
+		return nil
+	}
+	block.Advance(end + 2)
+	return ast.NewString([]byte(value))
+}
@@ -0,0 +1,72 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverter_Emoji(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "recognized shortcode",
+			input: "Released :tada:!",
+			want:  "<p>Released 🎉!</p>\n",
+		},
+		{
+			name:  "several shortcodes",
+			input: ":+1: :rocket::fire:",
+			want:  "<p>👍 🚀🔥</p>\n",
+		},
+		{
+			name:  "unknown shortcode is left as is",
+			input: "Hello :not_an_emoji: there",
+			want:  "<p>Hello :not_an_emoji: there</p>\n",
+		},
+		{
+			name:  "code span is left literal",
+			input: "Write `:tada:` for :tada:",
+			want:  "<p>Write <code>:tada:</code> for 🎉</p>\n",
+		},
+		{
+			name:  "times are not shortcodes",
+			input: "At 10:100:00",
+			want:  "<p>At 10:100:00</p>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConverter(WithEmoji(true)).Convert([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Convert() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("code block is left literal", func(t *testing.T) {
+		got, err := NewConverter(WithEmoji(true)).Convert([]byte("```\n:tada:\n```\n"))
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if !strings.Contains(got, ":tada:") || strings.Contains(got, "🎉") {
+			t.Errorf("Convert() = %q, want the literal shortcode", got)
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		got, err := NewConverter().Convert([]byte("Released :tada:!"))
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if want := "<p>Released :tada:!</p>\n"; got != want {
+			t.Errorf("Convert() = %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,20 @@
+package markdown
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// DefaultExtensions are the extensions of the markdown sources when none are configured.
+var DefaultExtensions = []string{".md", ".markdown"}
+
+// IsSource reports whether the file at path is a markdown source, its extension being one of extensions,
+// or of DefaultExtensions when extensions is empty. Extensions are compared case-insensitively.
+func IsSource(path string, extensions []string) bool {
+	if len(extensions) == 0 {
+		extensions = DefaultExtensions
+	}
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext != "" && slices.ContainsFunc(extensions, func(e string) bool { return strings.ToLower(e) == ext })
+}
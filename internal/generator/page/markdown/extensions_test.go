@@ -0,0 +1,26 @@
+package markdown
+
+import "testing"
+
+func TestIsSource(t *testing.T) {
+	tests := []struct {
+		path       string
+		extensions []string
+		want       bool
+	}{
+		{path: "posts/hello.md", want: true},
+		{path: "posts/hello.markdown", want: true},
+		{path: "posts/HELLO.MD", want: true},
+		{path: "posts/hello.mdown", want: false},
+		{path: "posts/hello.mdown", extensions: []string{".md", ".mdown"}, want: true},
+		{path: "posts/hello.markdown", extensions: []string{".md"}, want: false},
+		{path: "posts/photo.png", want: false},
+		{path: "posts/md", want: false},
+	}
+
+	for _, tt := range tests {
+		if got := IsSource(tt.path, tt.extensions); got != tt.want {
+			t.Errorf("IsSource(%q, %v) = %v, want %v", tt.path, tt.extensions, got, tt.want)
+		}
+	}
+}
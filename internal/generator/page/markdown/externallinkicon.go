@@ -0,0 +1,118 @@
+package markdown
+
+import (
+	"net/url"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// DefaultExternalLinkIconClass is the class of the external link marker when none is configured.
+const DefaultExternalLinkIconClass = "external-link-icon"
+
+// kindExternalLinkIcon is the kind of the externalLinkIcon nodes.
+var kindExternalLinkIcon = ast.NewNodeKind("ExternalLinkIcon")
+
+type (
+	// externalLinkIcon is the empty <span> marking an external link, styled to show an icon.
+	externalLinkIcon struct {
+		ast.BaseInline
+	}
+
+	// externalLinkIconExtender appends an externalLinkIcon rendered with class to the links to other hosts than siteHost,
+	// and to the mailto: and tel: links unless excludeMailto is set.
+	externalLinkIconExtender struct {
+		siteHost      string
+		class         string
+		excludeMailto bool
+	}
+)
+
+func (n *externalLinkIcon) Kind() ast.NodeKind { return kindExternalLinkIcon }
+
+func (n *externalLinkIcon) Dump(source []byte, level int) { ast.DumpHelper(n, source, level, nil, nil) }
+
+func (e externalLinkIconExtender) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(e, 90)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(e, 500)))
+}
+
+func (e externalLinkIconExtender) Transform(doc *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+	var links []ast.Node
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := node.(type) {
+		case *ast.Link:
+			if e.isMarked(string(n.Destination)) {
+				links = append(links, n)
+			}
+		case *ast.AutoLink:
+			destination := string(n.URL(source))
+			if n.AutoLinkType == ast.AutoLinkEmail {
+				destination = "mailto:" + destination
+			}
+			if e.isMarked(destination) {
+				links = append(links, n)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+
+	// Links are marked once walked, not to change the tree during the walk
+	for _, node := range links {
+		link, ok := node.(*ast.Link)
+		if !ok {
+			link = autoLinkAsLink(node.(*ast.AutoLink), source)
+		}
+		link.AppendChild(link, &externalLinkIcon{})
+	}
+}
+
+// autoLinkAsLink replaces n with a link to the same destination, which can hold the marker after its label.
+func autoLinkAsLink(n *ast.AutoLink, source []byte) *ast.Link {
+	link := ast.NewLink()
+	link.Destination = n.URL(source)
+	if n.AutoLinkType == ast.AutoLinkEmail {
+		link.Destination = append([]byte("mailto:"), link.Destination...)
+	}
+	for _, attribute := range n.Attributes() {
+		link.SetAttribute(attribute.Name, attribute.Value)
+	}
+	link.AppendChild(link, ast.NewString(n.Label(source)))
+	parent := n.Parent()
+	parent.ReplaceChild(parent, n, link)
+	return link
+}
+
+// isMarked reports whether the link to destination is marked as external.
+func (e externalLinkIconExtender) isMarked(destination string) bool {
+	u, err := url.Parse(destination)
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "mailto" || u.Scheme == "tel" {
+		return !e.excludeMailto
+	}
+	return externalLinksExtender{siteHost: e.siteHost}.isExternal(destination)
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (e externalLinkIconExtender) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindExternalLinkIcon, e.render)
+}
+
+func (e externalLinkIconExtender) render(w util.BufWriter, _ []byte, _ ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString(`<span class="`)
+		_, _ = w.Write(util.EscapeHTML([]byte(e.class)))
+		_, _ = w.WriteString(`" aria-hidden="true"></span>`)
+	}
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,86 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverter_ExternalLinkIcon(t *testing.T) {
+	const icon = `<span class="external-link-icon" aria-hidden="true"></span>`
+
+	tests := []struct {
+		name          string
+		source        string
+		excludeMailto bool
+		want          string
+	}{
+		{
+			name:   "external link",
+			source: "[Go](https://go.dev/doc)",
+			want:   `<a href="https://go.dev/doc">Go` + icon + `</a>`,
+		},
+		{
+			name:   "external autolink",
+			source: "<https://go.dev>",
+			want:   `<a href="https://go.dev">https://go.dev` + icon + `</a>`,
+		},
+		{
+			name:   "site host link",
+			source: "[About](https://example.com/about.html)",
+			want:   `<a href="https://example.com/about.html">About</a>`,
+		},
+		{
+			name:   "relative link",
+			source: "[About](about.md)",
+			want:   `<a href="about.md">About</a>`,
+		},
+		{
+			name:   "fragment",
+			source: "[Top](#top)",
+			want:   `<a href="#top">Top</a>`,
+		},
+		{
+			name:   "mailto link",
+			source: "[Mail](mailto:me@example.com)",
+			want:   `<a href="mailto:me@example.com">Mail` + icon + `</a>`,
+		},
+		{
+			name:          "mailto link excluded",
+			source:        "[Mail](mailto:me@example.com)",
+			excludeMailto: true,
+			want:          `<a href="mailto:me@example.com">Mail</a>`,
+		},
+		{
+			name:          "tel link excluded",
+			source:        "[Call](tel:+33100000000)",
+			excludeMailto: true,
+			want:          `<a href="tel:+33100000000">Call</a>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := NewConverter(WithExternalLinkIcon("example.com", "", tt.excludeMailto)).Convert([]byte(tt.source))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if !strings.Contains(html, tt.want) {
+				t.Errorf("Convert() = %q, want it to contain %q", html, tt.want)
+			}
+		})
+	}
+}
+
+func TestConverter_ExternalLinkIconClass(t *testing.T) {
+	html, err := NewConverter(
+		WithExternalLinkIcon("example.com", "icon-external", false),
+		WithExternalLinks("example.com", true),
+	).Convert([]byte("[Go](https://go.dev)"))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	want := `<a href="https://go.dev" rel="noopener noreferrer" target="_blank">Go<span class="icon-external" aria-hidden="true"></span></a>`
+	if !strings.Contains(html, want) {
+		t.Errorf("Convert() = %q, want it to contain %q", html, want)
+	}
+}
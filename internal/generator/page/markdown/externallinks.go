@@ -0,0 +1,66 @@
+package markdown
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// externalLinksExtender is the goldmark extension marking the links to other hosts than siteHost,
+// with rel="noopener noreferrer" and, when newTab is set, target="_blank".
+type externalLinksExtender struct {
+	siteHost string
+	newTab   bool
+}
+
+func (e externalLinksExtender) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(e, 100)))
+}
+
+func (e externalLinksExtender) Transform(doc *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		var destination string
+		switch n := node.(type) {
+		case *ast.Link:
+			destination = string(n.Destination)
+		case *ast.AutoLink:
+			if n.AutoLinkType != ast.AutoLinkURL {
+				return ast.WalkContinue, nil
+			}
+			destination = string(n.URL(source))
+		default:
+			return ast.WalkContinue, nil
+		}
+
+		if !e.isExternal(destination) {
+			return ast.WalkContinue, nil
+		}
+		// Attributes set inline with {...} take precedence
+		if _, ok := node.AttributeString("rel"); !ok {
+			node.SetAttributeString("rel", []byte("noopener noreferrer"))
+		}
+		if _, ok := node.AttributeString("target"); !ok && e.newTab {
+			node.SetAttributeString("target", []byte("_blank"))
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// isExternal reports whether destination is an http or https URL to another host than the site host.
+func (e externalLinksExtender) isExternal(destination string) bool {
+	u, err := url.Parse(destination)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	return !strings.EqualFold(u.Host, e.siteHost)
+}
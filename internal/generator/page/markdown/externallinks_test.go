@@ -0,0 +1,65 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverter_ExternalLinks(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		input       string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "external link",
+			opts:     []Option{WithExternalLinks("example.com", false)},
+			input:    "[Go](https://go.dev/doc)",
+			contains: []string{`<a href="https://go.dev/doc" rel="noopener noreferrer">Go</a>`},
+		},
+		{
+			name:     "external link in a new tab",
+			opts:     []Option{WithExternalLinks("example.com", true)},
+			input:    "[Go](http://go.dev) and <https://pkg.go.dev>",
+			contains: []string{`<a href="http://go.dev" rel="noopener noreferrer" target="_blank">Go</a>`, `<a href="https://pkg.go.dev" rel="noopener noreferrer" target="_blank">`},
+		},
+		{
+			name:        "site links are internal",
+			opts:        []Option{WithExternalLinks("example.com", true)},
+			input:       "[About](https://Example.com/about.html)",
+			notContains: []string{"rel=", "target="},
+		},
+		{
+			name:        "relative, fragment and mailto links are untouched",
+			opts:        []Option{WithExternalLinks("example.com", true)},
+			input:       "[Post](posts/first.md) [Top](#top) [Mail](mailto:me@example.org) <me@example.org>",
+			notContains: []string{"rel=", "target="},
+		},
+		{
+			name:        "disabled by default",
+			input:       "[Go](https://go.dev)",
+			notContains: []string{"rel=", "target="},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConverter(tt.opts...).Convert([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Convert() should contain %q, got:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("Convert() should not contain %q, got:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package frontmatter
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const delimiter = "---"
+
+// Frontmatter holds the values of the YAML block opening a markdown file.
+type Frontmatter struct {
+	Title       string    `yaml:"title"`
+	Description string    `yaml:"description"`
+	Date        time.Time `yaml:"date"`
+	Draft       bool      `yaml:"draft"`
+	Tags        []string  `yaml:"tags"`
+	HeadExtra   string    `yaml:"head_extra"`
+	// Fields holds every key of the front matter, the ones above included, as decoded by YAML,
+	// e.g. a string, an int, a bool, a time.Time or a list. It is nil without front matter.
+	Fields map[string]any `yaml:"-"`
+}
+
+// Parse splits data into its front matter and the remaining markdown.
+// A file without a leading "---" line has no front matter and is returned unchanged.
+func Parse(data []byte) (Frontmatter, []byte, error) {
+	var fm Frontmatter
+
+	firstLine, rest, _ := cutLine(data)
+	if string(firstLine) != delimiter {
+		return fm, data, nil
+	}
+
+	block := rest
+	for offset := 0; offset < len(block); {
+		line, next, found := cutLine(block[offset:])
+		if string(line) == delimiter {
+			if err := yaml.Unmarshal(block[:offset], &fm); err != nil {
+				return Frontmatter{}, nil, fmt.Errorf("malformed front matter: %w", err)
+			}
+			if err := yaml.Unmarshal(block[:offset], &fm.Fields); err != nil {
+				return Frontmatter{}, nil, fmt.Errorf("malformed front matter: %w", err)
+			}
+			return fm, next, nil
+		}
+		if !found {
+			break
+		}
+		offset = len(block) - len(next)
+	}
+
+	return Frontmatter{}, nil, errors.New("unterminated front matter: missing closing ---")
+}
+
+// cutLine returns the first line of data without its line ending, and the data following it.
+func cutLine(data []byte) (line, rest []byte, found bool) {
+	line, rest, found = bytes.Cut(data, []byte("\n"))
+	return bytes.TrimSuffix(line, []byte("\r")), rest, found
+}
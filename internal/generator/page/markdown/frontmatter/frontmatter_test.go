@@ -0,0 +1,111 @@
+package frontmatter
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     Frontmatter
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "no front matter",
+			data:     "# Hello\n\nsome content",
+			want:     Frontmatter{},
+			wantBody: "# Hello\n\nsome content",
+		},
+		{
+			name:     "horizontal rule later in the file is not front matter",
+			data:     "# Hello\n\n---\n\nafter rule",
+			want:     Frontmatter{},
+			wantBody: "# Hello\n\n---\n\nafter rule",
+		},
+		{
+			name: "all fields",
+			data: "---\ntitle: My Post\ndate: 2026-01-24\ndraft: true\ntags: [go, blog]\n---\n# Hello\n",
+			want: Frontmatter{
+				Title: "My Post",
+				Date:  time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC),
+				Draft: true,
+				Tags:  []string{"go", "blog"},
+				Fields: map[string]any{
+					"title": "My Post",
+					"date":  time.Date(2026, 1, 24, 0, 0, 0, 0, time.UTC),
+					"draft": true,
+					"tags":  []any{"go", "blog"},
+				},
+			},
+			wantBody: "# Hello\n",
+		},
+		{
+			name: "multiline head extra",
+			data: "---\nhead_extra: |\n  <link rel=\"preload\" href=\"/hero.webp\" as=\"image\">\n  <meta name=\"robots\" content=\"noindex\">\n---\n# Hello\n",
+			want: Frontmatter{
+				HeadExtra: "<link rel=\"preload\" href=\"/hero.webp\" as=\"image\">\n<meta name=\"robots\" content=\"noindex\">\n",
+				Fields:    map[string]any{"head_extra": "<link rel=\"preload\" href=\"/hero.webp\" as=\"image\">\n<meta name=\"robots\" content=\"noindex\">\n"},
+			},
+			wantBody: "# Hello\n",
+		},
+		{
+			name:     "windows line endings",
+			data:     "---\r\ntitle: My Post\r\n---\r\n# Hello\r\n",
+			want:     Frontmatter{Title: "My Post", Fields: map[string]any{"title": "My Post"}},
+			wantBody: "# Hello\r\n",
+		},
+		{
+			name:     "empty front matter",
+			data:     "---\n---\n# Hello\n",
+			want:     Frontmatter{},
+			wantBody: "# Hello\n",
+		},
+		{
+			name:     "closing delimiter at end of file",
+			data:     "---\ntitle: Only front matter\n---",
+			want:     Frontmatter{Title: "Only front matter", Fields: map[string]any{"title": "Only front matter"}},
+			wantBody: "",
+		},
+		{
+			name:     "custom fields",
+			data:     "---\nauthor: Tim\nfeatured: true\nrating: 4.5\n---\n# Hello\n",
+			want:     Frontmatter{Fields: map[string]any{"author": "Tim", "featured": true, "rating": 4.5}},
+			wantBody: "# Hello\n",
+		},
+		{
+			name:    "unterminated front matter",
+			data:    "---\ntitle: My Post\n# Hello\n",
+			wantErr: true,
+		},
+		{
+			name:    "malformed yaml",
+			data:    "---\ntitle: [unclosed\n---\n# Hello\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, body, err := Parse([]byte(tt.data))
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Parse() expected error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse() unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() front matter = %+v, want %+v", got, tt.want)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("Parse() body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package markdown
+
+import (
+	"fmt"
+	"html"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+)
+
+// DefaultSyntaxTheme is the chroma style used to highlight fenced code blocks.
+const DefaultSyntaxTheme = "github"
+
+// highlighter returns the goldmark extension highlighting fenced code blocks with inline styles of theme.
+// Blocks in an unknown language are rendered unhighlighted.
+func highlighter(theme string) goldmark.Extender {
+	return highlighting.NewHighlighting(
+		highlighting.WithStyle(theme),
+		highlighting.WithCodeBlockOptions(func(c highlighting.CodeBlockContext) []chromahtml.Option {
+			language, _ := c.Language()
+			return []chromahtml.Option{chromahtml.WithPreWrapper(languagePreWrapper{language: string(language)})}
+		}),
+	)
+}
+
+// languagePreWrapper wraps highlighted code in <pre><code class="language-xxx">,
+// keeping the class goldmark puts on unhighlighted code blocks.
+type languagePreWrapper struct {
+	language string
+}
+
+func (p languagePreWrapper) Start(code bool, styleAttr string) string {
+	if !code {
+		return fmt.Sprintf(`<pre tabindex="0"%s>`, styleAttr)
+	}
+	if p.language == "" {
+		return fmt.Sprintf(`<pre tabindex="0"%s><code>`, styleAttr)
+	}
+	return fmt.Sprintf(`<pre tabindex="0"%s><code class="language-%s">`, styleAttr, html.EscapeString(p.language))
+}
+
+func (p languagePreWrapper) End(code bool) string {
+	if code {
+		return "</code></pre>"
+	}
+	return "</pre>"
+}
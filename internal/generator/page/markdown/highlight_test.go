@@ -0,0 +1,69 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverter_SyntaxHighlighting(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		input       string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:  "known language is tokenized and keeps its language class",
+			input: "```go\nfunc main() {}\n```",
+			contains: []string{
+				`<pre tabindex="0" style="`,
+				`<code class="language-go">`,
+				`<span style="color:#cf222e">func</span>`,
+			},
+		},
+		{
+			name:        "unknown language degrades to unhighlighted code",
+			input:       "```nope\nx < y\n```",
+			contains:    []string{"<pre><code class=\"language-nope\">x &lt; y\n</code></pre>"},
+			notContains: []string{"<span"},
+		},
+		{
+			name:        "code block without language is not highlighted",
+			input:       "```\nplain\n```",
+			contains:    []string{"<pre><code>plain\n</code></pre>"},
+			notContains: []string{"<span"},
+		},
+		{
+			name:     "configured theme",
+			opts:     []Option{WithSyntaxTheme("monokai")},
+			input:    "```go\nfunc main() {}\n```",
+			contains: []string{"background-color:#272822"},
+		},
+		{
+			name:     "empty theme keeps the default one",
+			opts:     []Option{WithSyntaxTheme("")},
+			input:    "```go\nfunc main() {}\n```",
+			contains: []string{`<span style="color:#cf222e">func</span>`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewConverter(tt.opts...).Convert([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			for _, substr := range tt.contains {
+				if !strings.Contains(result, substr) {
+					t.Errorf("Convert() result should contain %q, got %q", substr, result)
+				}
+			}
+			for _, substr := range tt.notContains {
+				if strings.Contains(result, substr) {
+					t.Errorf("Convert() result should not contain %q, got %q", substr, result)
+				}
+			}
+		})
+	}
+}
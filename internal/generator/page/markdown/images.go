@@ -0,0 +1,56 @@
+package markdown
+
+import (
+	"fmt"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// lazyImageAttributes defer the loading and decoding of images until they are needed.
+var lazyImageAttributes = ElementAttributes{"img": {"loading": "lazy", "decoding": "async"}}
+
+// imageAttributes is the goldmark extension setting the {...} attributes following an image on the image,
+// e.g. ![Photo](photo.png){loading=eager}. Registered at a lower priority, they run after the configured attributes
+// and take precedence over them.
+var imageAttributes = imageAttributesExtender{}
+
+type imageAttributesExtender struct{}
+
+func (e imageAttributesExtender) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(e, 200)))
+}
+
+func (e imageAttributesExtender) Transform(doc *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		image, ok := node.(*ast.Image)
+		if !entering || !ok {
+			return ast.WalkContinue, nil
+		}
+		next, ok := image.NextSibling().(*ast.Text)
+		if !ok || next.Segment.Len() == 0 || source[next.Segment.Start] != '{' {
+			return ast.WalkContinue, nil
+		}
+
+		attributesReader := text.NewReader(source[next.Segment.Start:next.Segment.Stop])
+		attributes, ok := parser.ParseAttributes(attributesReader)
+		if !ok {
+			return ast.WalkContinue, nil
+		}
+		for _, attribute := range attributes {
+			// Unquoted numbers and booleans are parsed as such, and only bytes are rendered
+			value, ok := attribute.Value.([]byte)
+			if !ok {
+				value = fmt.Append(nil, attribute.Value)
+			}
+			image.SetAttribute(attribute.Name, value)
+		}
+		_, consumed := attributesReader.Position()
+		next.Segment = next.Segment.WithStart(next.Segment.Start + consumed.Start)
+		return ast.WalkContinue, nil
+	})
+}
@@ -0,0 +1,64 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverter_ImageAttributes(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []Option
+		input       string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "inline attributes",
+			input:    "![Photo](photo.png){width=600 .wide} caption",
+			contains: []string{`<img src="photo.png" alt="Photo" width="600" class="wide"> caption`},
+		},
+		{
+			name:     "not attributes",
+			input:    "![Photo](photo.png){not closed",
+			contains: []string{`<img src="photo.png" alt="Photo">{not closed`},
+		},
+		{
+			name:     "lazy images",
+			opts:     []Option{WithLazyImages(true)},
+			input:    "![First](first.png)\n\n![Second](second.png)",
+			contains: []string{`<img src="first.png" alt="First" decoding="async" loading="lazy">`, `<img src="second.png" alt="Second" decoding="async" loading="lazy">`},
+		},
+		{
+			name:        "inline loading wins over lazy images",
+			opts:        []Option{WithLazyImages(true)},
+			input:       "![Hero](hero.png){loading=eager}",
+			contains:    []string{`loading="eager"`, `decoding="async"`},
+			notContains: []string{`loading="lazy"`, "{"},
+		},
+		{
+			name:        "images are eager by default",
+			input:       "![Photo](photo.png)",
+			notContains: []string{"loading=", "decoding="},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewConverter(tt.opts...).Convert([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			for _, want := range tt.contains {
+				if !strings.Contains(got, want) {
+					t.Errorf("Convert() should contain %q, got:\n%s", want, got)
+				}
+			}
+			for _, unwanted := range tt.notContains {
+				if strings.Contains(got, unwanted) {
+					t.Errorf("Convert() should not contain %q, got:\n%s", unwanted, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Math nodes are rendered with the \( \) and \[ \] delimiters recognized by client-side renderers such as KaTeX or MathJax,
+// in elements with the "math inline" and "math display" classes.
+var (
+	KindMathBlock  = ast.NewNodeKind("MathBlock")
+	KindInlineMath = ast.NewNodeKind("InlineMath")
+
+	mathDelimiter = []byte("$$")
+)
+
+type (
+	// MathBlock is a $$ ... $$ math block, which may span several lines.
+	MathBlock struct {
+		ast.BaseBlock
+		closed bool
+	}
+
+	// InlineMath is $...$ inline math, or $$...$$ display math written on a single line of a paragraph.
+	InlineMath struct {
+		ast.BaseInline
+		Display bool
+	}
+
+	mathBlockParser  struct{}
+	inlineMathParser struct{}
+
+	// MathRenderer renders math nodes.
+	MathRenderer struct{}
+
+	mathExtension struct{}
+)
+
+// math recognizes $...$ inline math and $$...$$ display math outside of code.
+var math goldmark.Extender = mathExtension{}
+
+func (n *MathBlock) Kind() ast.NodeKind { return KindMathBlock }
+func (n *MathBlock) IsRaw() bool        { return true }
+func (n *MathBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+func (n *InlineMath) Kind() ast.NodeKind { return KindInlineMath }
+func (n *InlineMath) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+func (mathExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(util.Prioritized(mathBlockParser{}, 150)),
+		parser.WithInlineParsers(util.Prioritized(inlineMathParser{}, 150)),
+	)
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(&MathRenderer{}, 150)))
+}
+
+func (mathBlockParser) Trigger() []byte { return []byte{'$'} }
+
+func (mathBlockParser) Open(parent ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 || !bytes.HasPrefix(line[pos:], mathDelimiter) {
+		return nil, parser.NoChildren
+	}
+
+	node := &MathBlock{}
+	start := segment.Start + pos + len(mathDelimiter)
+	rest := util.TrimRightSpace(line[pos+len(mathDelimiter):])
+	if bytes.HasSuffix(rest, mathDelimiter) {
+		node.closed = true
+		rest = rest[:len(rest)-len(mathDelimiter)]
+	}
+	if len(util.TrimLeftSpace(rest)) > 0 {
+		node.Lines().Append(text.NewSegment(start, start+len(rest)))
+	}
+	reader.Advance(segment.Len() - 1)
+	return node, parser.NoChildren
+}
+
+func (mathBlockParser) Continue(node ast.Node, reader text.Reader, pc parser.Context) parser.State {
+	n := node.(*MathBlock)
+	if n.closed {
+		return parser.Close
+	}
+
+	line, segment := reader.PeekLine()
+	content := util.TrimRightSpace(line)
+	if bytes.HasSuffix(content, mathDelimiter) {
+		n.closed = true
+		content = content[:len(content)-len(mathDelimiter)]
+	}
+	if len(util.TrimLeftSpace(content)) > 0 {
+		n.Lines().Append(text.NewSegment(segment.Start, segment.Start+len(content)))
+	}
+	reader.Advance(segment.Len() - 1)
+	if n.closed {
+		return parser.Close
+	}
+	return parser.Continue | parser.NoChildren
+}
+
+func (mathBlockParser) Close(node ast.Node, reader text.Reader, pc parser.Context) {}
+
+func (mathBlockParser) CanInterruptParagraph() bool { return true }
+
+func (mathBlockParser) CanAcceptIndentedLine() bool { return false }
+
+func (inlineMathParser) Trigger() []byte { return []byte{'$'} }
+
+// Parse parses math up to the closing delimiter of the current line.
+// Following the pandoc rules, the content of $...$ cannot start or end with a space
+// and the closing $ cannot be followed by a digit, so that "$5 and $10" is not math.
+func (inlineMathParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, segment := block.PeekLine()
+
+	if bytes.HasPrefix(line, mathDelimiter) {
+		end := bytes.Index(line[len(mathDelimiter):], mathDelimiter)
+		if end <= 0 {
+			return nil
+		}
+		node := &InlineMath{Display: true}
+		start := segment.Start + len(mathDelimiter)
+		node.AppendChild(node, ast.NewRawTextSegment(text.NewSegment(start, start+end)))
+		block.Advance(end + 2*len(mathDelimiter))
+		return node
+	}
+
+	if len(line) < 3 || util.IsSpace(line[1]) || line[1] == '$' {
+		return nil
+	}
+	for i := 2; i < len(line); i++ {
+		switch {
+		case line[i] == '\\':
+			i++
+		case line[i] == '$':
+			if util.IsSpace(line[i-1]) || (i+1 < len(line) && line[i+1] >= '0' && line[i+1] <= '9') {
+				continue
+			}
+			node := &InlineMath{}
+			node.AppendChild(node, ast.NewRawTextSegment(text.NewSegment(segment.Start+1, segment.Start+i)))
+			block.Advance(i + 1)
+			return node
+		}
+	}
+	return nil
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r *MathRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindMathBlock, r.renderMathBlock)
+	reg.Register(KindInlineMath, r.renderInlineMath)
+}
+
+func (r *MathRenderer) renderMathBlock(
+	w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	_, _ = w.WriteString(`<div class="math display">\[`)
+	lines := node.Lines()
+	for i := 0; i < lines.Len(); i++ {
+		if i > 0 {
+			_ = w.WriteByte('\n')
+		}
+		segment := lines.At(i)
+		_, _ = w.Write(util.EscapeHTML(segment.Value(source)))
+	}
+	_, _ = w.WriteString("\\]</div>\n")
+	return ast.WalkContinue, nil
+}
+
+func (r *MathRenderer) renderInlineMath(
+	w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*InlineMath)
+	if n.Display {
+		_, _ = w.WriteString(`<span class="math display">\[`)
+	} else {
+		_, _ = w.WriteString(`<span class="math inline">\(`)
+	}
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		_, _ = w.Write(util.EscapeHTML(c.(*ast.Text).Value(source)))
+	}
+	if n.Display {
+		_, _ = w.WriteString(`\]</span>`)
+	} else {
+		_, _ = w.WriteString(`\)</span>`)
+	}
+	return ast.WalkSkipChildren, nil
+}
@@ -0,0 +1,93 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverter_Math(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:     "inline math",
+			input:    "Euler: $e^{i\\pi} + 1 = 0$.",
+			contains: []string{`<p>Euler: <span class="math inline">\(e^{i\pi} + 1 = 0\)</span>.</p>`},
+		},
+		{
+			name:     "display math block",
+			input:    "$$\n\\sum_{i=0}^n i\n= \\frac{n(n+1)}{2}\n$$\n",
+			contains: []string{"<div class=\"math display\">\\[\\sum_{i=0}^n i\n= \\frac{n(n+1)}{2}\\]</div>"},
+		},
+		{
+			name:     "single line display math block",
+			input:    "$$x^2$$\n",
+			contains: []string{`<div class="math display">\[x^2\]</div>`},
+		},
+		{
+			name:     "display math in a paragraph",
+			input:    "Before $$x^2$$ after.",
+			contains: []string{`<p>Before <span class="math display">\[x^2\]</span> after.</p>`},
+		},
+		{
+			name:     "math is escaped",
+			input:    "$a < b$",
+			contains: []string{`\(a &lt; b\)`},
+		},
+		{
+			name:        "prices are not math",
+			input:       "It costs $5 and $10.",
+			contains:    []string{"<p>It costs $5 and $10.</p>"},
+			notContains: []string{"math"},
+		},
+		{
+			name:        "escaped dollars are not math",
+			input:       `\$x\$`,
+			notContains: []string{"math"},
+		},
+		{
+			name:        "math in code blocks is not transformed",
+			input:       "```\n$x$ and\n$$\ny\n$$\n```",
+			contains:    []string{"<pre><code>$x$ and\n$$\ny\n$$\n</code></pre>"},
+			notContains: []string{"math"},
+		},
+		{
+			name:        "math in code spans is not transformed",
+			input:       "Run `echo $HOME$`.",
+			contains:    []string{"<code>echo $HOME$</code>"},
+			notContains: []string{"math"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewConverter(WithMath(true)).Convert([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			for _, substr := range tt.contains {
+				if !strings.Contains(result, substr) {
+					t.Errorf("Convert() result should contain %q, got %q", substr, result)
+				}
+			}
+			for _, substr := range tt.notContains {
+				if strings.Contains(result, substr) {
+					t.Errorf("Convert() result should not contain %q, got %q", substr, result)
+				}
+			}
+		})
+	}
+}
+
+func TestConverter_MathDisabledByDefault(t *testing.T) {
+	result, err := NewConverter().Convert([]byte("$x$"))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if result != "<p>$x$</p>\n" {
+		t.Errorf("Convert() = %q, want math left as text", result)
+	}
+}
@@ -0,0 +1,64 @@
+package markdown
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// RawHTMLRenderer renders the raw HTML blocks and inline raw HTML of the markdown, which MoreRenderer omits,
+// sanitized with Policy unless it is nil. The MoreMarker block is kept.
+type RawHTMLRenderer struct {
+	Policy *Policy
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r *RawHTMLRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindHTMLBlock, r.renderHTMLBlock)
+	reg.Register(ast.KindRawHTML, r.renderRawHTML)
+}
+
+func (r *RawHTMLRenderer) renderHTMLBlock(
+	w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+	n := node.(*ast.HTMLBlock)
+	if isMoreMarker(n, source) {
+		_, _ = w.WriteString(MoreMarker + "\n")
+		return ast.WalkContinue, nil
+	}
+
+	var block []byte
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		block = append(block, line.Value(source)...)
+	}
+	if n.HasClosure() {
+		block = append(block, n.ClosureLine.Value(source)...)
+	}
+	_, _ = w.WriteString(r.sanitize(string(block)))
+	return ast.WalkContinue, nil
+}
+
+func (r *RawHTMLRenderer) renderRawHTML(
+	w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkSkipChildren, nil
+	}
+	n := node.(*ast.RawHTML)
+	var raw []byte
+	for i := 0; i < n.Segments.Len(); i++ {
+		segment := n.Segments.At(i)
+		raw = append(raw, segment.Value(source)...)
+	}
+	_, _ = w.WriteString(r.sanitize(string(raw)))
+	return ast.WalkSkipChildren, nil
+}
+
+func (r *RawHTMLRenderer) sanitize(raw string) string {
+	if r.Policy == nil {
+		return raw
+	}
+	return r.Policy.Sanitize(raw)
+}
@@ -0,0 +1,35 @@
+package markdown
+
+import (
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/util"
+)
+
+// footnotes enables [^1] footnotes, rendered in a section at the bottom of the page by FootnoteListRenderer.
+var footnotes = extension.NewFootnote(extension.WithFootnoteLinkClass("footnote-ref"), extension.WithFootnoteBacklinkClass("footnote-backref"))
+
+// FootnoteListRenderer renders the footnotes of a page in a <section class="footnotes"> element.
+type FootnoteListRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r *FootnoteListRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(east.KindFootnoteList, r.renderFootnoteList)
+}
+
+func (r *FootnoteListRenderer) renderFootnoteList(
+	w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString(`<section class="footnotes" role="doc-endnotes"`)
+		if node.Attributes() != nil {
+			html.RenderAttributes(w, node, html.GlobalAttributeFilter)
+		}
+		_, _ = w.WriteString(">\n<hr>\n<ol>\n")
+	} else {
+		_, _ = w.WriteString("</ol>\n</section>\n")
+	}
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,54 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverter_Footnotes(t *testing.T) {
+	input := "Text[^1] and more[^note].\n\n[^1]: First note.\n[^note]: Second note.\n"
+	result, err := NewConverter().Convert([]byte(input))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	for _, substr := range []string{
+		`<sup id="fnref:1"><a href="#fn:1" class="footnote-ref" role="doc-noteref">1</a></sup>`,
+		`<sup id="fnref:2"><a href="#fn:2" class="footnote-ref" role="doc-noteref">2</a></sup>`,
+		`<section class="footnotes" role="doc-endnotes">`,
+		`<li id="fn:1">`,
+		`<a href="#fnref:1" class="footnote-backref" role="doc-backlink">`,
+	} {
+		if !strings.Contains(result, substr) {
+			t.Errorf("Convert() result should contain %q, got %q", substr, result)
+		}
+	}
+
+	if strings.Index(result, `<section class="footnotes"`) < strings.Index(result, "</p>") {
+		t.Errorf("footnotes should be rendered after the content, got %q", result)
+	}
+	if strings.Contains(result, `<div class="footnotes"`) {
+		t.Errorf("footnotes should not be rendered in a div, got %q", result)
+	}
+}
+
+func TestConverter_WithoutFootnotes(t *testing.T) {
+	result, err := NewConverter().Convert([]byte("No notes here."))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	if strings.Contains(result, "footnotes") {
+		t.Errorf("Convert() should not render a footnotes section, got %q", result)
+	}
+}
+
+func TestConverter_DefinitionLists(t *testing.T) {
+	result, err := NewConverter().Convert([]byte("Term\n: Definition\n"))
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	want := "<dl>\n<dt>Term</dt>\n<dd>Definition</dd>\n</dl>\n"
+	if result != want {
+		t.Errorf("Convert() = %q, want %q", result, want)
+	}
+}
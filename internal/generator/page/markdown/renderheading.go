@@ -7,8 +7,21 @@ import (
 	"github.com/yuin/goldmark/util"
 )
 
-// HeadingRenderer renders headings with a clickable anchor link.
-type HeadingRenderer struct{}
+const (
+	// DefaultHeadingAnchorClass is the class of the anchor links of the headings
+	DefaultHeadingAnchorClass = "heading-anchor"
+	// DefaultHeadingAnchorSymbol is the text of the anchor links of the headings
+	DefaultHeadingAnchorSymbol = "#"
+)
+
+// HeadingRenderer renders the headings having an id with a clickable anchor link to it, e.g. <a href="#id" class="heading-anchor">#</a>.
+// The link is written once, when the heading is rendered, so the markup of the heading text is never duplicated.
+type HeadingRenderer struct {
+	// Class is the class of the anchor link, DefaultHeadingAnchorClass when empty
+	Class string
+	// Symbol is the text of the anchor link, DefaultHeadingAnchorSymbol when empty, e.g. ¶
+	Symbol string
+}
 
 // RegisterFuncs implements renderer.NodeRenderer.
 func (r *HeadingRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
@@ -29,7 +42,11 @@ func (r *HeadingRenderer) renderHeading(
 		if id, ok := n.AttributeString("id"); ok {
 			_, _ = w.WriteString(`<a href="#`)
 			_, _ = w.Write(util.EscapeHTML(id.([]byte)))
-			_, _ = w.WriteString(`" class="heading-anchor">#</a>`)
+			_, _ = w.WriteString(`" class="`)
+			_, _ = w.Write(util.EscapeHTML([]byte(r.class())))
+			_, _ = w.WriteString(`">`)
+			_, _ = w.Write(util.EscapeHTML([]byte(r.symbol())))
+			_, _ = w.WriteString(`</a>`)
 		}
 		_, _ = w.WriteString("</h")
 		_ = w.WriteByte("0123456"[n.Level])
@@ -37,3 +54,17 @@ func (r *HeadingRenderer) renderHeading(
 	}
 	return ast.WalkContinue, nil
 }
+
+func (r *HeadingRenderer) class() string {
+	if r.Class == "" {
+		return DefaultHeadingAnchorClass
+	}
+	return r.Class
+}
+
+func (r *HeadingRenderer) symbol() string {
+	if r.Symbol == "" {
+		return DefaultHeadingAnchorSymbol
+	}
+	return r.Symbol
+}
@@ -1,9 +1,15 @@
 package markdown
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
 )
 
 func TestHeadingRenderer_AllLevels(t *testing.T) {
@@ -146,3 +152,44 @@ func TestHeadingRenderer_AnchorStructure(t *testing.T) {
 		t.Errorf("anchor should appear after heading text, got %q", result)
 	}
 }
+
+func TestHeadingRenderer_CustomAnchor(t *testing.T) {
+	converter := NewConverter(WithHeadingAnchor("anchor-link", "¶"))
+
+	for i := 0; i < 2; i++ {
+		result, err := converter.Convert([]byte("## Getting Started\n\nText"))
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+
+		want := `<h2 id="getting-started">Getting Started<a href="#getting-started" class="anchor-link">¶</a></h2>`
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q, got %q", want, result)
+		}
+		if count := strings.Count(result, "<a "); count != 1 {
+			t.Errorf("expected a single anchor link, got %d in %q", count, result)
+		}
+	}
+}
+
+func TestHeadingRenderer_WithoutID(t *testing.T) {
+	// Without auto heading ids, only the headings with an explicit id have one
+	md := goldmark.New(
+		goldmark.WithParserOptions(parser.WithAttribute()),
+		goldmark.WithRendererOptions(
+			renderer.WithNodeRenderers(util.Prioritized(&HeadingRenderer{Class: "anchor-link"}, 100)),
+		),
+	)
+
+	var buf bytes.Buffer
+	if err := md.Convert([]byte("## No ID\n\n## With ID {#custom}"), &buf); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if !strings.Contains(buf.String(), "<h2>No ID</h2>") {
+		t.Errorf("headings without id should have no anchor link, got %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), `<h2 id="custom">With ID<a href="#custom" class="anchor-link">#</a></h2>`) {
+		t.Errorf("headings with an id should link to it, got %q", buf.String())
+	}
+}
@@ -0,0 +1,47 @@
+package markdown
+
+import (
+	"bytes"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/util"
+)
+
+// MoreMarker splits a page between its excerpt and the rest of its content.
+const MoreMarker = "<!--more-->"
+
+// MoreRenderer keeps the MoreMarker HTML block in the converted page, other raw HTML blocks are omitted.
+type MoreRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (r *MoreRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(ast.KindHTMLBlock, r.renderHTMLBlock)
+}
+
+func (r *MoreRenderer) renderHTMLBlock(
+	w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*ast.HTMLBlock)
+	if entering {
+		if isMoreMarker(n, source) {
+			_, _ = w.WriteString(MoreMarker + "\n")
+		} else {
+			_, _ = w.WriteString("<!-- raw HTML omitted -->\n")
+		}
+	} else if n.HasClosure() {
+		_, _ = w.WriteString("<!-- raw HTML omitted -->\n")
+	}
+	return ast.WalkContinue, nil
+}
+
+func isMoreMarker(n *ast.HTMLBlock, source []byte) bool {
+	var block []byte
+	for i := 0; i < n.Lines().Len(); i++ {
+		line := n.Lines().At(i)
+		block = append(block, line.Value(source)...)
+	}
+	if n.HasClosure() {
+		block = append(block, n.ClosureLine.Value(source)...)
+	}
+	return string(bytes.TrimSpace(block)) == MoreMarker
+}
@@ -0,0 +1,46 @@
+package markdown
+
+import (
+	"testing"
+)
+
+func TestConverter_MoreMarker(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "more marker is kept",
+			input: "Intro.\n\n<!--more-->\n\nRest.\n",
+			want:  "<p>Intro.</p>\n<!--more-->\n<p>Rest.</p>\n",
+		},
+		{
+			name:  "spaces around the marker",
+			input: "Intro.\n\n  <!--more-->  \n\nRest.\n",
+			want:  "<p>Intro.</p>\n<!--more-->\n<p>Rest.</p>\n",
+		},
+		{
+			name:  "other comments are omitted",
+			input: "Intro.\n\n<!-- note -->\n\nRest.\n",
+			want:  "<p>Intro.</p>\n<!-- raw HTML omitted -->\n<p>Rest.</p>\n",
+		},
+		{
+			name:  "raw HTML blocks are omitted",
+			input: "<div>\nraw\n</div>\n",
+			want:  "<!-- raw HTML omitted -->\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := NewConverter().Convert([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			if result != tt.want {
+				t.Errorf("Convert() = %q, want %q", result, tt.want)
+			}
+		})
+	}
+}
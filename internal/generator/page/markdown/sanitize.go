@@ -0,0 +1,155 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Policy is the allowlist the raw HTML of the markdown is sanitized with when it is rendered.
+// Elements which are not allowed are removed but their content is kept, except for script and style elements
+// which are removed with their content. Comments are removed.
+type Policy struct {
+	// Elements maps the allowed elements to their allowed attributes, besides the global attributes
+	Elements map[string][]string
+	// Attributes are allowed on every allowed element
+	Attributes []string
+	// Classes are the allowed classes, any class is allowed when nil
+	Classes []string
+}
+
+var (
+	// rawHTMLTokenRe matches the comments, tags and declarations of raw HTML
+	rawHTMLTokenRe = regexp.MustCompile(`(?s)<!--.*?-->|<(/?)([a-zA-Z][a-zA-Z0-9-]*)((?:\s+[^\s"'>/=]+(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?)*)\s*/?>|<[!?][^>]*>`)
+	rawHTMLAttrRe  = regexp.MustCompile(`([^\s"'>/=]+)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'=<>` + "`" + `]+)))?`)
+	// rawHTMLDropped are the elements removed with their content
+	rawHTMLDropped = []string{"script", "style"}
+	// rawHTMLURLAttributes are the attributes whose value must be a safe URL
+	rawHTMLURLAttributes = []string{"href", "src", "cite", "poster"}
+)
+
+// DefaultPolicy returns a Policy allowing the usual formatting, media and table elements,
+// with the id, class, title and lang global attributes.
+func DefaultPolicy() *Policy {
+	elements := map[string][]string{
+		"a":       {"href", "rel", "target"},
+		"img":     {"src", "alt", "width", "height", "loading", "decoding"},
+		"video":   {"src", "poster", "controls", "width", "height", "muted", "loop", "playsinline"},
+		"audio":   {"src", "controls", "loop"},
+		"source":  {"src", "type"},
+		"abbr":    nil,
+		"td":      {"colspan", "rowspan", "align"},
+		"th":      {"colspan", "rowspan", "align", "scope"},
+		"ol":      {"start", "reversed"},
+		"details": {"open"},
+	}
+	for _, name := range []string{
+		"p", "div", "span", "br", "hr", "em", "strong", "b", "i", "u", "s", "del", "ins", "mark", "small",
+		"sub", "sup", "code", "kbd", "samp", "pre", "blockquote", "ul", "li", "dl", "dt", "dd",
+		"h1", "h2", "h3", "h4", "h5", "h6", "figure", "figcaption", "summary", "picture",
+		"table", "thead", "tbody", "tfoot", "tr", "caption",
+	} {
+		elements[name] = nil
+	}
+	return &Policy{Elements: elements, Attributes: []string{"id", "class", "title", "lang"}}
+}
+
+// Sanitize returns raw with the elements, attributes and classes not allowed by the policy removed,
+// and the URLs other than relative, http, https and mailto ones removed.
+func (p *Policy) Sanitize(raw string) string {
+	var b strings.Builder
+	for raw != "" {
+		loc := rawHTMLTokenRe.FindStringSubmatchIndex(raw)
+		if loc == nil {
+			b.WriteString(escapeLessThan(raw))
+			break
+		}
+		b.WriteString(escapeLessThan(raw[:loc[0]]))
+		token, rest := raw[loc[0]:loc[1]], raw[loc[1]:]
+		raw = rest
+
+		// Comments and declarations, which have no element name
+		if loc[4] < 0 {
+			continue
+		}
+		closing := loc[3] > loc[2]
+		name := strings.ToLower(token[loc[4]-loc[0] : loc[5]-loc[0]])
+		if slices.Contains(rawHTMLDropped, name) {
+			if !closing {
+				raw = skipElementContent(raw, name)
+			}
+			continue
+		}
+		allowed, ok := p.Elements[name]
+		if !ok {
+			continue
+		}
+		if closing {
+			b.WriteString("</" + name + ">")
+			continue
+		}
+
+		b.WriteString("<" + name)
+		for _, m := range rawHTMLAttrRe.FindAllStringSubmatch(token[loc[6]-loc[0]:loc[7]-loc[0]], -1) {
+			attr := strings.ToLower(m[1])
+			if !slices.Contains(allowed, attr) && !slices.Contains(p.Attributes, attr) {
+				continue
+			}
+			value := html.UnescapeString(m[2] + m[3] + m[4])
+			if slices.Contains(rawHTMLURLAttributes, attr) && !isSafeURL(value) {
+				continue
+			}
+			if attr == "class" && p.Classes != nil {
+				value = p.filterClasses(value)
+				if value == "" {
+					continue
+				}
+			}
+			b.WriteString(" " + attr + `="` + html.EscapeString(value) + `"`)
+		}
+		b.WriteString(">")
+	}
+	return b.String()
+}
+
+// filterClasses returns the classes of value allowed by the policy.
+func (p *Policy) filterClasses(value string) string {
+	classes := strings.Fields(value)
+	classes = slices.DeleteFunc(classes, func(class string) bool { return !slices.Contains(p.Classes, class) })
+	return strings.Join(classes, " ")
+}
+
+// skipElementContent returns raw after the end tag of the name element, or an empty string when it is not closed.
+func skipElementContent(raw, name string) string {
+	lower := strings.ToLower(raw)
+	i := strings.Index(lower, "</"+name)
+	if i < 0 {
+		return ""
+	}
+	if end := strings.Index(raw[i:], ">"); end >= 0 {
+		return raw[i+end+1:]
+	}
+	return ""
+}
+
+// escapeLessThan escapes the < which do not start a tag, so that no tag is left unsanitized.
+func escapeLessThan(text string) string {
+	return strings.ReplaceAll(text, "<", "&lt;")
+}
+
+// isSafeURL reports whether value is a relative URL or an http, https or mailto URL.
+func isSafeURL(value string) bool {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == 0x7f {
+			return -1
+		}
+		return r
+	}, value)
+	i := strings.IndexAny(value, ":/?#")
+	if i < 0 || value[i] != ':' {
+		return true
+	}
+	scheme := strings.ToLower(value[:i])
+	return scheme == "http" || scheme == "https" || scheme == "mailto"
+}
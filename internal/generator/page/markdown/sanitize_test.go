@@ -0,0 +1,128 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPolicy_Sanitize(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy *Policy
+		raw    string
+		want   string
+	}{
+		{
+			name:   "allowed elements and attributes are kept",
+			policy: DefaultPolicy(),
+			raw:    `<div class="note" id="n1"><a href="/about.html" title="About">About</a></div>`,
+			want:   `<div class="note" id="n1"><a href="/about.html" title="About">About</a></div>`,
+		},
+		{
+			name:   "script is removed with its content",
+			policy: DefaultPolicy(),
+			raw:    "<p>Hi</p>\n<script>alert(document.cookie)</script>\n<p>Bye</p>",
+			want:   "<p>Hi</p>\n\n<p>Bye</p>",
+		},
+		{
+			name:   "unclosed script removes the rest",
+			policy: DefaultPolicy(),
+			raw:    `<p>Hi</p><SCRIPT src="x.js">`,
+			want:   `<p>Hi</p>`,
+		},
+		{
+			name:   "event handlers are removed",
+			policy: DefaultPolicy(),
+			raw:    `<img src="photo.png" onerror="alert(1)" alt="Photo">`,
+			want:   `<img src="photo.png" alt="Photo">`,
+		},
+		{
+			name:   "javascript URLs are removed",
+			policy: DefaultPolicy(),
+			raw:    `<a href=" JaVaScript:alert(1)">Click</a><a href="mailto:me@example.org">Mail</a>`,
+			want:   `<a>Click</a><a href="mailto:me@example.org">Mail</a>`,
+		},
+		{
+			name:   "entity encoded javascript URLs are removed",
+			policy: DefaultPolicy(),
+			raw:    `<a href="&#106;avascript:alert(1)">Click</a>`,
+			want:   `<a>Click</a>`,
+		},
+		{
+			name:   "unknown elements are removed, their content is kept",
+			policy: DefaultPolicy(),
+			raw:    `<iframe src="https://example.org"></iframe><marquee>Hello</marquee>`,
+			want:   `Hello`,
+		},
+		{
+			name:   "comments are removed",
+			policy: DefaultPolicy(),
+			raw:    `<p>Hi<!-- <script>alert(1)</script> --></p>`,
+			want:   `<p>Hi</p>`,
+		},
+		{
+			name:   "attribute values are escaped",
+			policy: DefaultPolicy(),
+			raw:    `<span title='a "quoted" > title'>Hi</span>`,
+			want:   `<span title="a &#34;quoted&#34; &gt; title">Hi</span>`,
+		},
+		{
+			name:   "stray less than is escaped",
+			policy: DefaultPolicy(),
+			raw:    `<p>1 < 2 <3</p>`,
+			want:   `<p>1 &lt; 2 &lt;3</p>`,
+		},
+		{
+			name:   "classes are filtered",
+			policy: &Policy{Elements: map[string][]string{"span": nil}, Attributes: []string{"class"}, Classes: []string{"badge"}},
+			raw:    `<span class="badge evil">New</span><span class="evil">Old</span>`,
+			want:   `<span class="badge">New</span><span>Old</span>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Sanitize(tt.raw); got != tt.want {
+				t.Errorf("Sanitize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConverter_RawHTML(t *testing.T) {
+	const input = "Hello <em>world</em>.\n\n<div class=\"note\">\n<script>alert(1)</script>\n</div>\n\n<!--more-->\n\nInline <script>alert(2)</script> script.\n"
+
+	t.Run("omitted by default", func(t *testing.T) {
+		got, err := NewConverter().Convert([]byte(input))
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if strings.Contains(got, "<script>") || strings.Contains(got, `<div class="note">`) {
+			t.Errorf("Convert() = %q, raw HTML should be omitted", got)
+		}
+		if !strings.Contains(got, MoreMarker) {
+			t.Errorf("Convert() = %q, should keep the more marker", got)
+		}
+	})
+
+	t.Run("rendered when unsafe", func(t *testing.T) {
+		got, err := NewConverter(WithUnsafeHTML(true)).Convert([]byte(input))
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if !strings.Contains(got, "<script>alert(1)</script>") {
+			t.Errorf("Convert() = %q, raw HTML should be rendered as is", got)
+		}
+	})
+
+	t.Run("sanitized", func(t *testing.T) {
+		got, err := NewConverter(WithUnsafeHTML(true), WithSanitizer(DefaultPolicy())).Convert([]byte(input))
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		want := "<p>Hello <em>world</em>.</p>\n<div class=\"note\">\n\n</div>\n<!--more-->\n<p>Inline alert(2) script.</p>\n"
+		if got != want {
+			t.Errorf("Convert() = %q, want %q", got, want)
+		}
+	})
+}
@@ -0,0 +1,66 @@
+package markdown
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"golang.org/x/text/unicode/norm"
+)
+
+// slugIDs generates heading ids from a slugified version of the heading text.
+// A fresh instance must be used for each page so that collisions are only de-duplicated within one page.
+type slugIDs struct {
+	values map[string]bool
+}
+
+func newSlugIDs() parser.IDs {
+	return &slugIDs{values: map[string]bool{}}
+}
+
+// Generate implements parser.IDs.
+func (s *slugIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	base := Slugify(string(value))
+	if base == "" {
+		if kind == ast.KindHeading {
+			base = "heading"
+		} else {
+			base = "id"
+		}
+	}
+
+	id := base
+	for i := 1; s.values[id]; i++ {
+		id = base + "-" + strconv.Itoa(i)
+	}
+	s.values[id] = true
+	return []byte(id)
+}
+
+// Put implements parser.IDs, reserving ids set explicitly by the author.
+func (s *slugIDs) Put(value []byte) {
+	s.values[string(value)] = true
+}
+
+// Slugify lowercases text, strips accents and punctuation, and joins words with hyphens.
+func Slugify(text string) string {
+	var b strings.Builder
+	pendingHyphen := false
+	for _, r := range norm.NFD.String(text) {
+		switch {
+		case unicode.Is(unicode.Mn, r):
+			// Combining accent left over by the decomposition
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if pendingHyphen && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			pendingHyphen = false
+			b.WriteRune(unicode.ToLower(r))
+		case unicode.IsSpace(r) || r == '-' || r == '_':
+			pendingHyphen = true
+		}
+	}
+	return b.String()
+}
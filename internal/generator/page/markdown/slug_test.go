@@ -0,0 +1,95 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Hello World", "hello-world"},
+		{"  Trim me  ", "trim-me"},
+		{"Café à la crème", "cafe-a-la-creme"},
+		{"Élève, déjà vu !", "eleve-deja-vu"},
+		{"What's new? (2024)", "whats-new-2024"},
+		{"snake_case and-kebab", "snake-case-and-kebab"},
+		{"Multiple   spaces", "multiple-spaces"},
+		{"!!!", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := Slugify(tt.input); got != tt.expected {
+				t.Errorf("Slugify(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestConverter_SlugHeadingIDs(t *testing.T) {
+	converter := NewConverter()
+
+	tests := []struct {
+		name     string
+		input    string
+		contains []string
+	}{
+		{
+			name:     "accented characters",
+			input:    "## Été à Paris",
+			contains: []string{`<h2 id="ete-a-paris">`},
+		},
+		{
+			name:     "duplicate headings",
+			input:    "## Title\n\n## Title\n\n## Title",
+			contains: []string{`<h2 id="title">`, `<h2 id="title-1">`, `<h2 id="title-2">`},
+		},
+		{
+			name:     "explicit id is kept",
+			input:    "## Title {#custom}\n\n## Other",
+			contains: []string{`<h2 id="custom">`, `<h2 id="other">`},
+		},
+		{
+			name:     "explicit id is not reused by a generated one",
+			input:    "## Intro {#title}\n\n## Title",
+			contains: []string{`<h2 id="title">Intro`, `<h2 id="title-1">Title`},
+		},
+		{
+			name:     "punctuation only falls back to heading",
+			input:    "## ???",
+			contains: []string{`<h2 id="heading">`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := converter.Convert([]byte(tt.input))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+
+			for _, substr := range tt.contains {
+				if !strings.Contains(result, substr) {
+					t.Errorf("Convert() result should contain %q, got %q", substr, result)
+				}
+			}
+		})
+	}
+}
+
+func TestConverter_SlugIDsArePerPage(t *testing.T) {
+	converter := NewConverter()
+
+	for range 2 {
+		result, err := converter.Convert([]byte("## Title"))
+		if err != nil {
+			t.Fatalf("Convert() error = %v", err)
+		}
+		if !strings.Contains(result, `<h2 id="title">`) {
+			t.Errorf("ids should not collide across conversions, got %q", result)
+		}
+	}
+}
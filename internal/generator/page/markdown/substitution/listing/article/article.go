@@ -2,42 +2,78 @@ package article
 
 import (
 	"fmt"
-	"io/fs"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
 
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	"github.com/tjnvr/blog/internal/generator/page/htmltext"
+	"github.com/tjnvr/blog/internal/generator/page/markdown"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
 	"github.com/tjnvr/blog/internal/generator/page/markdown/metadata"
 )
 
+// excerptMaxLength is the number of characters kept from the excerpt of an article, as for page descriptions.
+const excerptMaxLength = 160
+
 type Article struct {
 	name      string
 	filePath  string
 	createdAt string
+	// excerpt is the plain text of the article excerpt, escaped for markdown
+	excerpt string
 }
 
+// Print returns the list item of the article, followed by its excerpt on a new line of the item when it has one.
 func (a Article) Print() string {
+	item := fmt.Sprintf("- [%s](%s)", a.name, a.filePath)
 	if a.createdAt != "" {
-		return fmt.Sprintf("- [%s](%s) · *%s*", a.name, a.filePath, a.createdAt)
+		item += fmt.Sprintf(" · *%s*", a.createdAt)
+	}
+	if a.excerpt != "" {
+		item += "\\\n  " + a.excerpt
 	}
-	return fmt.Sprintf("- [%s](%s)", a.name, a.filePath)
+	return item
 }
 
 type ListPageArticles struct {
 	indexFilePath string
+	includeDrafts bool
+	fs            filesystem.FileSystem
+	extensions    []string
+	converter     *markdown.Converter
 }
 
-func NewPageArticlesLister(indexFilePath string) ListPageArticles {
+// NewPageArticlesLister lists the articles next to indexFilePath read from fs, leaving out drafts unless includeDrafts is set.
+func NewPageArticlesLister(indexFilePath string, includeDrafts bool, fs filesystem.FileSystem) ListPageArticles {
 	return ListPageArticles{
 		indexFilePath: indexFilePath,
+		includeDrafts: includeDrafts,
+		fs:            fs,
 	}
 }
 
+// WithExtensions returns a copy of la listing the markdown files with one of extensions,
+// markdown.DefaultExtensions when empty.
+func (la ListPageArticles) WithExtensions(extensions ...string) ListPageArticles {
+	la.extensions = extensions
+	return la
+}
+
+// WithExcerpts returns a copy of la listing each article with the plain text of the excerpt of its page,
+// as resolved by {{excerpt}}, the articles being converted with converter, e.g. the converter of the site.
+// Articles are listed without excerpt when converter is nil.
+func (la ListPageArticles) WithExcerpts(converter *markdown.Converter) ListPageArticles {
+	la.converter = converter
+	return la
+}
+
+// ListPrinters lists the articles most recent first, with their excerpt shortened to 160 characters when enabled.
 func (la ListPageArticles) ListPrinters() ([]Article, error) {
 	articles := make([]Article, 0)
 	dir := filepath.Dir(la.indexFilePath)
-	if err := filepath.Walk(dir, func(path string, info fs.FileInfo, err error) error {
+	if err := la.fs.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -45,14 +81,18 @@ func (la ListPageArticles) ListPrinters() ([]Article, error) {
 		if info.IsDir() && path != dir {
 			return filepath.SkipDir
 		}
-		// only first-level .md files, excluding the index file itself
-		if filepath.Dir(path) != dir || filepath.Ext(path) != ".md" || path == la.indexFilePath {
+		// only first-level markdown files, excluding the index file itself
+		if filepath.Dir(path) != dir || !markdown.IsSource(path, la.extensions) || path == la.indexFilePath {
 			return nil
 		}
-		data, err := os.ReadFile(path)
+		data, err := la.fs.ReadFile(path)
 		if err != nil {
 			return err
 		}
+		fm, body, err := frontmatter.Parse(data)
+		if err == nil && fm.Draft && !la.includeDrafts {
+			return nil
+		}
 		name := extractTitle(data)
 		if name == "" {
 			return nil
@@ -61,6 +101,7 @@ func (la ListPageArticles) ListPrinters() ([]Article, error) {
 			name:      name,
 			filePath:  filepath.Base(path),
 			createdAt: metadata.Extract(data).CreationDate,
+			excerpt:   la.excerpt(body),
 		})
 		return nil
 	}); err != nil {
@@ -82,3 +123,23 @@ func extractTitle(data []byte) string {
 	}
 	return ""
 }
+
+// excerpt returns the plain text of the excerpt of the article body, escaped for markdown.
+// It is empty when excerpts are not listed, or when the body cannot be converted or has no excerpt.
+func (la ListPageArticles) excerpt(body []byte) string {
+	if la.converter == nil {
+		return ""
+	}
+	html, err := la.converter.Convert(body)
+	if err != nil {
+		return ""
+	}
+	text := htmltext.Truncate(htmltext.Text(htmltext.Excerpt(html)), excerptMaxLength)
+	return markdownEscaper.Replace(text)
+}
+
+// markdownEscaper escapes the characters of plain text which markdown would interpret inline.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`,
+	"<", `\<`, ">", `\>`, "!", `\!`, "|", `\|`, "~", `\~`, "&", `\&`,
+)
@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	"github.com/tjnvr/blog/internal/generator/page/markdown"
 )
 
 func TestArticlePrint(t *testing.T) {
@@ -22,6 +25,11 @@ func TestArticlePrint(t *testing.T) {
 			a:    Article{name: "Hello", filePath: "hello.md", createdAt: "2024-03-15"},
 			want: "- [Hello](hello.md) · *2024-03-15*",
 		},
+		{
+			name: "with excerpt",
+			a:    Article{name: "Hello", filePath: "hello.md", createdAt: "2024-03-15", excerpt: "First words."},
+			want: "- [Hello](hello.md) · *2024-03-15*\\\n  First words.",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -60,6 +68,7 @@ func TestListPrinters(t *testing.T) {
 		name      string
 		files     map[string]string // relative path -> content
 		indexFile string            // relative path of the index file
+		drafts    bool              // whether draft articles are listed
 		wantNames []string
 		wantErr   bool
 	}{
@@ -99,6 +108,27 @@ func TestListPrinters(t *testing.T) {
 			indexFile: "index.md",
 			wantNames: []string{"Hello"},
 		},
+		{
+			name: "skips drafts",
+			files: map[string]string{
+				"index.md": "# Index",
+				"draft.md": "---\ndraft: true\n---\n# Draft",
+				"hello.md": "---\ndraft: false\n---\n# Hello\ncontent",
+			},
+			indexFile: "index.md",
+			wantNames: []string{"Hello"},
+		},
+		{
+			name: "lists drafts when included",
+			files: map[string]string{
+				"index.md": "# Index",
+				"draft.md": "---\ndraft: true\n---\n# Draft",
+				"hello.md": "# Hello\ncontent",
+			},
+			indexFile: "index.md",
+			drafts:    true,
+			wantNames: []string{"Draft", "Hello"},
+		},
 	}
 
 	for _, tt := range tests {
@@ -114,7 +144,7 @@ func TestListPrinters(t *testing.T) {
 				}
 			}
 
-			lister := NewPageArticlesLister(filepath.Join(dir, tt.indexFile))
+			lister := NewPageArticlesLister(filepath.Join(dir, tt.indexFile), tt.drafts, filesystem.NewOSFileSystem())
 			articles, err := lister.ListPrinters()
 			if tt.wantErr {
 				if err == nil {
@@ -142,3 +172,51 @@ func TestListPrinters(t *testing.T) {
 		})
 	}
 }
+
+func TestListPrinters_Excerpts(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"index.md":  "# Index\n\n{{list-child-articles}}",
+		"marker.md": "# Marker\n\nIntro with *emphasis* and a_b.\n\nSecond intro paragraph.\n\n<!--more-->\n\nRest of the post.",
+		"plain.md":  "# Plain\n\n![Cover](cover.png)\n\nFirst paragraph.\n\nSecond paragraph.",
+		"empty.md":  "# Empty",
+		"emoji.md":  "# Emoji\n\nShipped :rocket:",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("WriteFile: %v", err)
+		}
+	}
+	lister := NewPageArticlesLister(filepath.Join(dir, "index.md"), false, filesystem.NewOSFileSystem())
+
+	t.Run("with the given converter", func(t *testing.T) {
+		articles, err := lister.WithExcerpts(markdown.NewConverter(markdown.WithEmoji(true))).ListPrinters()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := map[string]string{
+			"Marker": `Intro with emphasis and a\_b. Second intro paragraph.`,
+			"Plain":  "First paragraph.",
+			"Empty":  "",
+			"Emoji":  "Shipped 🚀",
+		}
+		for _, a := range articles {
+			if a.excerpt != want[a.name] {
+				t.Errorf("excerpt of %q = %q, want %q", a.name, a.excerpt, want[a.name])
+			}
+		}
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		articles, err := lister.ListPrinters()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, a := range articles {
+			if a.excerpt != "" {
+				t.Errorf("excerpt of %q = %q, want none", a.name, a.excerpt)
+			}
+		}
+	})
+}
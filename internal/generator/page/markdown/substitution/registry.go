@@ -3,19 +3,60 @@ package substitution
 import (
 	"strings"
 
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	"github.com/tjnvr/blog/internal/generator/page/markdown"
 	"github.com/tjnvr/blog/internal/generator/page/markdown/substitution/listing"
 	"github.com/tjnvr/blog/internal/generator/page/markdown/substitution/listing/article"
 )
 
-// Registry manages substitutions and applies them to templates
-type Registry struct {
-	substitutions []Substituer
+type (
+	// Registry manages substitutions and applies them to templates
+	Registry struct {
+		substitutions []Substituer
+	}
+
+	// Option configures the default substituters created by NewRegistry
+	Option func(*options)
+
+	options struct {
+		includeDrafts bool
+		fs            filesystem.FileSystem
+		extensions    []string
+		converter     *markdown.Converter
+	}
+)
+
+// WithIncludeDrafts returns an Option that lists draft articles along with published ones.
+func WithIncludeDrafts(include bool) Option {
+	return func(o *options) { o.includeDrafts = include }
+}
+
+// WithFileSystem returns an Option that sets the filesystem articles are listed from.
+func WithFileSystem(fs filesystem.FileSystem) Option {
+	return func(o *options) { o.fs = fs }
+}
+
+// WithMarkdownExtensions returns an Option that lists the articles with one of extensions,
+// markdown.DefaultExtensions when empty.
+func WithMarkdownExtensions(extensions ...string) Option {
+	return func(o *options) { o.extensions = extensions }
+}
+
+// WithExcerpts returns an Option that lists the articles with their excerpt, converted with converter,
+// e.g. the converter of the site. Articles are listed without excerpt when converter is nil.
+func WithExcerpts(converter *markdown.Converter) Option {
+	return func(o *options) { o.converter = converter }
 }
 
 // NewRegistry creates a new substitution registry with default substituters
-func NewRegistry(filePath string) *Registry {
+func NewRegistry(filePath string, opts ...Option) *Registry {
+	o := options{fs: filesystem.NewOSFileSystem()}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	return NewRegistryWithSubstituters(
-		listing.NewSubstituer("{{list-child-articles}}", article.NewPageArticlesLister(filePath), "\n"),
+		listing.NewSubstituer("{{list-child-articles}}", article.NewPageArticlesLister(filePath, o.includeDrafts, o.fs).WithExtensions(o.extensions...).WithExcerpts(o.converter), "\n"),
 	)
 }
 
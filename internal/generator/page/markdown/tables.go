@@ -0,0 +1,73 @@
+package markdown
+
+import (
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// DefaultTableWrapperClass is the class of the <div> wrapping the tables when none is configured.
+const DefaultTableWrapperClass = "table-wrapper"
+
+// kindTableWrapper is the kind of the tableWrapper nodes.
+var kindTableWrapper = ast.NewNodeKind("TableWrapper")
+
+type (
+	// tableWrapper is the block wrapping a table, rendered as a <div> which can scroll horizontally on narrow screens.
+	tableWrapper struct {
+		ast.BaseBlock
+	}
+
+	// tableWrapperExtender wraps the tables in a tableWrapper rendered with class.
+	tableWrapperExtender struct {
+		class string
+	}
+)
+
+func (n *tableWrapper) Kind() ast.NodeKind { return kindTableWrapper }
+
+func (n *tableWrapper) Dump(source []byte, level int) { ast.DumpHelper(n, source, level, nil, nil) }
+
+func (e tableWrapperExtender) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(util.Prioritized(e, 300)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(e, 500)))
+}
+
+func (e tableWrapperExtender) Transform(doc *ast.Document, _ text.Reader, _ parser.Context) {
+	var tables []ast.Node
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if _, ok := node.(*extast.Table); ok && entering {
+			tables = append(tables, node)
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+
+	// Tables are wrapped once walked, not to change the tree during the walk
+	for _, table := range tables {
+		wrapper := &tableWrapper{}
+		parent := table.Parent()
+		parent.ReplaceChild(parent, table, wrapper)
+		wrapper.AppendChild(wrapper, table)
+	}
+}
+
+// RegisterFuncs implements renderer.NodeRenderer.
+func (e tableWrapperExtender) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindTableWrapper, e.render)
+}
+
+func (e tableWrapperExtender) render(w util.BufWriter, _ []byte, _ ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		_, _ = w.WriteString(`<div class="`)
+		_, _ = w.Write(util.EscapeHTML([]byte(e.class)))
+		_, _ = w.WriteString("\">\n")
+	} else {
+		_, _ = w.WriteString("</div>\n")
+	}
+	return ast.WalkContinue, nil
+}
@@ -0,0 +1,60 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestConverter_TableWrapper(t *testing.T) {
+	source := "| Name | Value |\n| ---- | ----- |\n| a    | 1     |\n\n> | Quoted |\n> | ------ |\n> | b      |\n"
+
+	tests := []struct {
+		name  string
+		opts  []Option
+		want  []string
+		avoid []string
+	}{
+		{
+			name:  "not wrapped by default",
+			avoid: []string{"<div"},
+		},
+		{
+			name: "default class",
+			opts: []Option{WithTableWrapper("")},
+			want: []string{
+				"<div class=\"table-wrapper\">\n<table>",
+				"<blockquote>\n<div class=\"table-wrapper\">\n<table>",
+			},
+		},
+		{
+			name: "configured class",
+			opts: []Option{WithTableWrapper("overflow-x-auto")},
+			want: []string{"<div class=\"overflow-x-auto\">\n<table>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			html, err := NewConverter(tt.opts...).Convert([]byte(source))
+			if err != nil {
+				t.Fatalf("Convert() error = %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(html, want) {
+					t.Errorf("Convert() = %q, want it to contain %q", html, want)
+				}
+			}
+			for _, avoid := range tt.avoid {
+				if strings.Contains(html, avoid) {
+					t.Errorf("Convert() = %q, want it not to contain %q", html, avoid)
+				}
+			}
+			if opened, closed := strings.Count(html, "<div"), strings.Count(html, "</div>"); opened != closed {
+				t.Errorf("Convert() = %q has %d <div> and %d </div>", html, opened, closed)
+			}
+			if !strings.Contains(html, "</table>\n</div>") && len(tt.want) > 0 {
+				t.Errorf("Convert() = %q, want the wrapper closed after the table", html)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package page
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// minifyTokenRe matches, in order of precedence, comments, elements whose content is kept verbatim, and tags.
+	minifyTokenRe = regexp.MustCompile(`(?is)<!--.*?-->|<(?:pre|code|script|style|textarea)\b.*?</(?:pre|code|script|style|textarea)\s*>|<[^>]*>`)
+	tagNameRe     = regexp.MustCompile(`^</?([a-zA-Z0-9!]+)`)
+	whitespaceRe  = regexp.MustCompile(`\s+`)
+
+	// blockTags are elements around which whitespace does not render, so that it can be dropped.
+	blockTags = map[string]bool{
+		"!doctype": true, "html": true, "head": true, "body": true, "meta": true, "link": true, "title": true,
+		"script": true, "style": true, "noscript": true, "header": true, "footer": true, "main": true,
+		"nav": true, "article": true, "section": true, "aside": true, "div": true, "p": true, "pre": true,
+		"blockquote": true, "figure": true, "figcaption": true, "hr": true, "br": true, "ul": true, "ol": true,
+		"li": true, "dl": true, "dt": true, "dd": true, "table": true, "thead": true, "tbody": true,
+		"tfoot": true, "tr": true, "th": true, "td": true, "caption": true, "form": true,
+		"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	}
+)
+
+// minifyHTML drops comments and collapses whitespace between tags.
+// Whitespace runs are collapsed to a single space, and removed next to block elements where it does not render.
+// The content of <pre>, <code>, <script>, <style> and <textarea> elements is kept byte-for-byte.
+func minifyHTML(content string) string {
+	var sb strings.Builder
+	sb.Grow(len(content))
+
+	prevBlock := true
+	// text holds the content seen since the last tag, comments excluded
+	var text strings.Builder
+	flushText := func(nextBlock bool) {
+		collapsed := whitespaceRe.ReplaceAllString(text.String(), " ")
+		if prevBlock {
+			collapsed = strings.TrimPrefix(collapsed, " ")
+		}
+		if nextBlock {
+			collapsed = strings.TrimSuffix(collapsed, " ")
+		}
+		sb.WriteString(collapsed)
+		text.Reset()
+	}
+
+	last := 0
+	for _, loc := range minifyTokenRe.FindAllStringIndex(content, -1) {
+		text.WriteString(content[last:loc[0]])
+		last = loc[1]
+
+		token := content[loc[0]:loc[1]]
+		if strings.HasPrefix(token, "<!--") {
+			continue
+		}
+
+		block := isBlockTag(token)
+		flushText(block)
+		sb.WriteString(token)
+		prevBlock = block
+	}
+	text.WriteString(content[last:])
+	flushText(true)
+
+	return sb.String()
+}
+
+func isBlockTag(tag string) bool {
+	m := tagNameRe.FindStringSubmatch(tag)
+	return m != nil && blockTags[strings.ToLower(m[1])]
+}
@@ -0,0 +1,60 @@
+package page
+
+import "testing"
+
+func TestMinifyHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "drops whitespace between block tags",
+			input: "<html>\n  <body>\n    <p>Hello</p>\n  </body>\n</html>\n",
+			want:  "<html><body><p>Hello</p></body></html>",
+		},
+		{
+			name:  "collapses whitespace in text",
+			input: "<p>Hello\n    world  !</p>",
+			want:  "<p>Hello world !</p>",
+		},
+		{
+			name:  "keeps a space between inline elements",
+			input: "<p><em>a</em>\n  <strong>b</strong></p>",
+			want:  "<p><em>a</em> <strong>b</strong></p>",
+		},
+		{
+			name:  "drops comments",
+			input: "<p>a <!-- note --> b</p>\n<!-- <p>hidden</p> -->",
+			want:  "<p>a b</p>",
+		},
+		{
+			name:  "keeps pre content",
+			input: "<div>\n<pre><code>  a\n\n    b  <!-- kept -->\n</code></pre>\n</div>",
+			want:  "<div><pre><code>  a\n\n    b  <!-- kept -->\n</code></pre></div>",
+		},
+		{
+			name:  "keeps inline code content",
+			input: "<p>run <code>go  test   ./...</code> now</p>",
+			want:  "<p>run <code>go  test   ./...</code> now</p>",
+		},
+		{
+			name:  "keeps script content",
+			input: "<head>\n  <script>\n    if (a  <  b) {}\n  </script>\n</head>",
+			want:  "<head><script>\n    if (a  <  b) {}\n  </script></head>",
+		},
+		{
+			name:  "keeps textarea content",
+			input: "<form>\n  <textarea>  line\n  line</textarea>\n</form>",
+			want:  "<form><textarea>  line\n  line</textarea></form>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := minifyHTML(tt.input); got != tt.want {
+				t.Errorf("minifyHTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
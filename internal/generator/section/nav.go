@@ -0,0 +1,64 @@
+package section
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// NavConfig customizes how sections appear in the navigation bar.
+// The home section is referred to by the empty name "".
+type NavConfig struct {
+	// Order lists the sections shown first in the navigation, in that order.
+	// Sections left out keep their alphabetical order after the listed ones.
+	Order []string `json:"order"`
+	// DisplayNames maps a section directory name to the label shown in the navigation.
+	// Sections left out keep their index page title.
+	DisplayNames map[string]string `json:"display_names"`
+}
+
+// ParseNavConfig parses a JSON navigation configuration such as
+//
+//	{"order": ["", "posts", "a-propos"], "display_names": {"a-propos": "À propos"}}
+func ParseNavConfig(data []byte) (NavConfig, error) {
+	var cfg NavConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return NavConfig{}, fmt.Errorf("malformed navigation config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Apply returns sections renamed and reordered according to the configuration,
+// along with the sections of Order that are not in sections.
+func (c NavConfig) Apply(sections []Section) ([]Section, []string) {
+	byName := make(map[string]Section, len(sections))
+	for _, s := range sections {
+		if name, ok := c.DisplayNames[s.DirName]; ok {
+			s.DisplayName = name
+		}
+		byName[s.DirName] = s
+	}
+
+	result := make([]Section, 0, len(sections))
+	placed := make(map[string]bool, len(c.Order))
+	unknown := make([]string, 0)
+	for _, dirName := range c.Order {
+		s, ok := byName[dirName]
+		if !ok {
+			unknown = append(unknown, dirName)
+			continue
+		}
+		if placed[dirName] {
+			continue
+		}
+		placed[dirName] = true
+		result = append(result, s)
+	}
+
+	for _, s := range sections {
+		if !placed[s.DirName] {
+			result = append(result, byName[s.DirName])
+		}
+	}
+
+	return result, unknown
+}
@@ -0,0 +1,84 @@
+package section
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseNavConfig(t *testing.T) {
+	cfg, err := ParseNavConfig([]byte(`{"order": ["", "posts"], "display_names": {"a-propos": "À propos"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, NavConfig{
+		Order:        []string{"", "posts"},
+		DisplayNames: map[string]string{"a-propos": "À propos"},
+	}, cfg)
+
+	_, err = ParseNavConfig([]byte(`{"order": "posts"}`))
+	assert.ErrorContains(t, err, "malformed navigation config")
+}
+
+func TestNavConfig_Apply(t *testing.T) {
+	sections := []Section{
+		{DirName: "", DisplayName: "Accueil"},
+		{DirName: "a-propos", DisplayName: "A-propos"},
+		{DirName: "posts", DisplayName: "Posts"},
+		{DirName: "projects", DisplayName: "Projects"},
+	}
+
+	tests := []struct {
+		name        string
+		cfg         NavConfig
+		want        []Section
+		wantUnknown []string
+	}{
+		{
+			name: "empty config keeps sections",
+			cfg:  NavConfig{},
+			want: sections,
+		},
+		{
+			name: "display names override titles",
+			cfg:  NavConfig{DisplayNames: map[string]string{"a-propos": "À propos", "": "Home"}},
+			want: []Section{
+				{DirName: "", DisplayName: "Home"},
+				{DirName: "a-propos", DisplayName: "À propos"},
+				{DirName: "posts", DisplayName: "Posts"},
+				{DirName: "projects", DisplayName: "Projects"},
+			},
+		},
+		{
+			name: "ordered sections come first, others follow in discovery order",
+			cfg:  NavConfig{Order: []string{"posts", ""}},
+			want: []Section{
+				{DirName: "posts", DisplayName: "Posts"},
+				{DirName: "", DisplayName: "Accueil"},
+				{DirName: "a-propos", DisplayName: "A-propos"},
+				{DirName: "projects", DisplayName: "Projects"},
+			},
+		},
+		{
+			name:        "unknown and duplicated ordered sections",
+			cfg:         NavConfig{Order: []string{"projects", "missing", "projects"}},
+			wantUnknown: []string{"missing"},
+			want: []Section{
+				{DirName: "projects", DisplayName: "Projects"},
+				{DirName: "", DisplayName: "Accueil"},
+				{DirName: "a-propos", DisplayName: "A-propos"},
+				{DirName: "posts", DisplayName: "Posts"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unknown := tt.cfg.Apply(sections)
+			assert.Equal(t, tt.want, got)
+			if tt.wantUnknown == nil {
+				assert.Empty(t, unknown)
+			} else {
+				assert.Equal(t, tt.wantUnknown, unknown)
+			}
+		})
+	}
+}
@@ -1,7 +1,32 @@
 package section
 
-// Section represents a top-level site section.
+import (
+	"slices"
+	"strings"
+)
+
+// Section represents a site section, along with its nested sections.
 type Section struct {
-	DirName     string // directory name (used for URL path construction)
-	DisplayName string // display name shown in navigation (from # title in index.md)
+	DirName     string    // directory name (used for URL path construction), e.g. "blog/2024" for a nested section
+	DisplayName string    // display name shown in navigation (from # title in index.md)
+	Children    []Section // nested sections, shown under the section in the navigation
+}
+
+// Contains reports whether sections, or their nested sections, include the section at dirName.
+func Contains(sections []Section, dirName string) bool {
+	for _, s := range sections {
+		if s.DirName == dirName || Contains(s.Children, dirName) {
+			return true
+		}
+	}
+	return false
+}
+
+// Sort sorts sections and their nested sections alphabetically by directory name, the home section first,
+// so that their order does not depend on the order the directories are listed in.
+func Sort(sections []Section) {
+	slices.SortFunc(sections, func(a, b Section) int { return strings.Compare(a.DirName, b.DirName) })
+	for i := range sections {
+		Sort(sections[i].Children)
+	}
 }
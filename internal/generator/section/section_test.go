@@ -0,0 +1,38 @@
+package section
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContains(t *testing.T) {
+	sections := []Section{
+		{DirName: ""},
+		{DirName: "blog", Children: []Section{
+			{DirName: "blog/2024", Children: []Section{{DirName: "blog/2024/06"}}},
+		}},
+	}
+
+	assert.True(t, Contains(sections, ""))
+	assert.True(t, Contains(sections, "blog"))
+	assert.True(t, Contains(sections, "blog/2024/06"))
+	assert.False(t, Contains(sections, "2024"))
+	assert.False(t, Contains(nil, "blog"))
+}
+
+func TestSort(t *testing.T) {
+	sections := []Section{
+		{DirName: "posts", Children: []Section{{DirName: "posts/2025"}, {DirName: "posts/2024"}}},
+		{DirName: "about"},
+		{DirName: ""},
+	}
+
+	Sort(sections)
+
+	assert.Equal(t, []Section{
+		{DirName: ""},
+		{DirName: "about"},
+		{DirName: "posts", Children: []Section{{DirName: "posts/2024"}, {DirName: "posts/2025"}}},
+	}, sections)
+}
@@ -0,0 +1,57 @@
+package site
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// cleanURLRewriter returns a function rewriting the links of a page to index pages into links to their directory,
+// e.g. posts/hello/index.html into posts/hello/. It returns nil when clean URLs are disabled.
+// Links with a scheme or a host are left as they are, except the ones under the base URL.
+func (g *Generator) cleanURLRewriter() func(content string) string {
+	if !g.cleanURLs {
+		return nil
+	}
+	return func(content string) string {
+		return referenceAttributeRe.ReplaceAllStringFunc(content, func(attr string) string {
+			m := referenceAttributeRe.FindStringSubmatch(attr)
+			if !strings.Contains(m[1], "href") {
+				return attr
+			}
+			return m[1] + g.cleanURL(m[2]) + m[3]
+		})
+	}
+}
+
+// cleanURL returns ref without its index.html file name, keeping its query and fragment.
+func (g *Generator) cleanURL(ref string) string {
+	target, suffix := ref, ""
+	if i := strings.IndexAny(ref, "?#"); i >= 0 {
+		target, suffix = ref[:i], ref[i:]
+	}
+	if g.baseURL == "" || !strings.HasPrefix(target, g.baseURL+"/") {
+		if u, err := url.Parse(target); err != nil || u.Scheme != "" || u.Host != "" {
+			return ref
+		}
+	}
+
+	switch {
+	case target == "index.html":
+		return "./" + suffix
+	case strings.HasSuffix(target, "/index.html"):
+		return strings.TrimSuffix(target, "index.html") + suffix
+	default:
+		return ref
+	}
+}
+
+// pageDepth returns the number of directories between the page generated at htmlPath and the output directory
+// of pageSection, which is not zero for the pages output in a directory of their own.
+func (g *Generator) pageDepth(htmlPath, pageSection string) int {
+	rel, err := filepath.Rel(filepath.Join(g.buildDir, g.outputSection(pageSection)), filepath.Dir(htmlPath))
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return 0
+	}
+	return strings.Count(filepath.ToSlash(rel), "/") + 1
+}
@@ -0,0 +1,37 @@
+package site
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// NonMarkdownFiles is the handling of the files of the content directory which are not markdown pages
+type NonMarkdownFiles string
+
+const (
+	// NonMarkdownCopy copies the files to the same path in the build directory, e.g. images next to their post.
+	NonMarkdownCopy NonMarkdownFiles = "copy"
+	// NonMarkdownIgnore skips the files.
+	NonMarkdownIgnore NonMarkdownFiles = "ignore"
+	// NonMarkdownError fails the generation.
+	NonMarkdownError NonMarkdownFiles = "error"
+)
+
+// copyColocated copies the content file at path to its path in the build directory, following the path strategy.
+func (g *Generator) copyColocated(path, relPath string) error {
+	data, err := g.fs.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	outPath := g.outputPath(relPath)
+	if err := g.fs.MkdirAll(filepath.Dir(outPath), g.dirMode); err != nil {
+		return fmt.Errorf("creating directory for %s: %w", outPath, err)
+	}
+
+	if err := g.writeOutput(outPath, data); err != nil {
+		return fmt.Errorf("writing %s: %w", outPath, err)
+	}
+	g.logger.Debug("copied", "source", path, "output", outPath)
+	return nil
+}
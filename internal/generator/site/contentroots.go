@@ -0,0 +1,119 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// contentRoot is a content directory whose files are output under mount in the build directory.
+type contentRoot struct {
+	dir   string
+	mount string
+}
+
+// WithContentDirs returns an Option that builds the site from several content directories, e.g. "docs" and "blog",
+// instead of the default one. Their files are output at the same relative path in the build directory,
+// under their mount directory when set with WithContentMount. Sections found in several directories are merged,
+// and files of several directories output at the same path fail the generation.
+// Links to the pages of another directory are written as if all directories were merged.
+func WithContentDirs(dirs ...string) Option {
+	return func(g *Generator) { g.contentDirs = append(g.contentDirs, dirs...) }
+}
+
+// WithContentMount returns an Option that outputs the files of the content directory dir under mount,
+// a directory relative to the build directory, e.g. "docs". Its root becomes the section mount.
+func WithContentMount(dir, mount string) Option {
+	return func(g *Generator) {
+		if g.contentMounts == nil {
+			g.contentMounts = make(map[string]string)
+		}
+		g.contentMounts[filepath.Clean(dir)] = filepath.Clean(mount)
+	}
+}
+
+// contentRoots returns the content directories of the site, the default content directory when none is configured.
+func (g *Generator) contentRoots() []contentRoot {
+	dirs := g.contentDirs
+	if len(dirs) == 0 {
+		dirs = []string{g.contentDir}
+	}
+	roots := make([]contentRoot, 0, len(dirs))
+	for _, dir := range dirs {
+		mount := g.contentMounts[filepath.Clean(dir)]
+		if mount == "." {
+			mount = ""
+		}
+		roots = append(roots, contentRoot{dir: dir, mount: mount})
+	}
+	return roots
+}
+
+// relPath returns the path of the file at path of the root relative to the content of the site,
+// that is relative to the root directory and under its mount directory.
+func (r contentRoot) relPath(path string) (string, error) {
+	rel, err := filepath.Rel(r.dir, path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(r.mount, rel), nil
+}
+
+// sourcePath returns the path of the file of the root at relPath, relative to the content of the site,
+// or false when relPath is not under the root mount directory.
+func (r contentRoot) sourcePath(relPath string) (string, bool) {
+	if r.mount == "" {
+		return filepath.Join(r.dir, relPath), true
+	}
+	rel, err := filepath.Rel(r.mount, relPath)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.Join(r.dir, rel), true
+}
+
+// rootOf returns the content root holding the file at path, the first root when none does.
+func (g *Generator) rootOf(path string) contentRoot {
+	roots := g.contentRoots()
+	for _, r := range roots {
+		rel, err := filepath.Rel(r.dir, path)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return r
+		}
+	}
+	return roots[0]
+}
+
+// contentSource returns the path of the content file at relPath, relative to the content of the site,
+// in the first content root holding it. The path in the first root which may hold it is returned otherwise.
+func (g *Generator) contentSource(relPath string) (string, bool) {
+	fallback := ""
+	for _, r := range g.contentRoots() {
+		path, ok := r.sourcePath(relPath)
+		if !ok {
+			continue
+		}
+		if _, err := g.fs.Stat(path); err == nil {
+			return path, true
+		}
+		if fallback == "" {
+			fallback = path
+		}
+	}
+	if fallback == "" {
+		fallback = filepath.Join(g.contentDir, relPath)
+	}
+	return fallback, false
+}
+
+// walkContent walks the content roots in order, calling fn with the root of each file or directory.
+func (g *Generator) walkContent(fn func(root contentRoot, path string, info os.FileInfo, err error) error) error {
+	for _, r := range g.contentRoots() {
+		if err := g.fs.Walk(r.dir, func(path string, info os.FileInfo, err error) error {
+			return fn(r, path, info, err)
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
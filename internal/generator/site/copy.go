@@ -8,24 +8,25 @@ import (
 )
 
 func (g *Generator) copyAssets() error {
-	return copyDir(g.assetsDir, filepath.Join(g.buildDir, "assets"), nil)
+	return g.copyDir(g.assetsDir, filepath.Join(g.buildDir, g.assetsOutDir), nil, g.fingerprintRenamer(g.assetsOutDir))
 }
 
 func (g *Generator) copyScripts() error {
-	return copyDir(g.scriptsDir, filepath.Join(g.buildDir, "scripts"), func(path string) bool {
+	return g.copyDir(g.scriptsDir, filepath.Join(g.buildDir, g.scriptsOutDir), func(path string) bool {
 		return strings.HasSuffix(path, ".js")
-	})
+	}, g.fingerprintRenamer(g.scriptsOutDir))
 }
 
 // copyDir copies files from srcDir to destDir, optionally filtering by the provided function.
 // If filter is nil, all files are copied. If filter returns true, the file is copied.
-func copyDir(srcDir, destDir string, filter func(path string) bool) error {
-	return filepath.WalkDir(srcDir, func(path string, d os.DirEntry, err error) error {
+// If rename is not nil, files are written at the path it returns for their path relative to srcDir and content.
+func (g *Generator) copyDir(srcDir, destDir string, filter func(path string) bool, rename func(relPath string, data []byte) string) error {
+	return g.fs.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 
-		if d.IsDir() {
+		if info.IsDir() {
 			return nil
 		}
 
@@ -38,21 +39,24 @@ func copyDir(srcDir, destDir string, filter func(path string) bool) error {
 			return err
 		}
 
-		outPath := filepath.Join(destDir, relPath)
-
-		data, err := os.ReadFile(path)
+		data, err := g.fs.ReadFile(path)
 		if err != nil {
 			return fmt.Errorf("reading %s: %w", path, err)
 		}
 
-		if err := os.MkdirAll(filepath.Dir(outPath), 0755); err != nil {
+		if rename != nil {
+			relPath = rename(relPath, data)
+		}
+		outPath := filepath.Join(destDir, relPath)
+
+		if err := g.fs.MkdirAll(filepath.Dir(outPath), g.dirMode); err != nil {
 			return fmt.Errorf("creating directory for %s: %w", outPath, err)
 		}
 
-		if err := os.WriteFile(outPath, data, 0644); err != nil {
+		if err := g.writeOutput(outPath, data); err != nil {
 			return fmt.Errorf("writing %s: %w", outPath, err)
 		}
-		fmt.Printf("Copied: %s -> %s\n", relPath, outPath)
+		g.logger.Debug("copied", "source", path, "output", outPath)
 		return nil
 	})
 }
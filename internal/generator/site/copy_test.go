@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
 )
 
 func TestCopyDir(t *testing.T) {
@@ -85,7 +87,7 @@ func TestCopyDir(t *testing.T) {
 				}
 			}
 
-			err := copyDir(srcDir, destDir, tt.filter)
+			err := (&Generator{logger: defaultLogger(), fs: filesystem.NewOSFileSystem(), fileMode: filesystem.DefaultFileMode, dirMode: filesystem.DefaultDirMode}).copyDir(srcDir, destDir, tt.filter, nil)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("copyDir() error = %v, wantErr %v", err, tt.wantErr)
@@ -136,7 +138,7 @@ func TestCopyDir_NonExistentSource(t *testing.T) {
 	srcDir := filepath.Join(tmpDir, "nonexistent")
 	destDir := filepath.Join(tmpDir, "dest")
 
-	err := copyDir(srcDir, destDir, nil)
+	err := (&Generator{logger: defaultLogger(), fs: filesystem.NewOSFileSystem(), fileMode: filesystem.DefaultFileMode, dirMode: filesystem.DefaultDirMode}).copyDir(srcDir, destDir, nil, nil)
 	if err == nil {
 		t.Error("expected error for non-existent source directory")
 	}
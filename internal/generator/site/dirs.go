@@ -5,9 +5,10 @@ import (
 	"path/filepath"
 )
 
+// makeAllDirectories creates the build directory and the assets and scripts output directories in it.
 func (g *Generator) makeAllDirectories() error {
-	for _, d := range []string{g.assetsOutDir, g.scriptsOutDir, g.buildDir} {
-		if err := g.fs.MkdirAll(filepath.Dir(d), 0755); err != nil {
+	for _, d := range []string{g.buildDir, filepath.Join(g.buildDir, g.assetsOutDir), filepath.Join(g.buildDir, g.scriptsOutDir)} {
+		if err := g.fs.MkdirAll(d, g.dirMode); err != nil {
 			return fmt.Errorf("creating directory %s: %w", d, err)
 		}
 	}
@@ -0,0 +1,20 @@
+package site
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMakeAllDirectories(t *testing.T) {
+	buildDir := filepath.Join(t.TempDir(), "target", "build")
+	g, _ := NewGenerator(WithAssetsOutDir("static"))
+	g.withBuildDir(buildDir)
+
+	assert.NoError(t, g.makeAllDirectories())
+
+	assert.DirExists(t, buildDir)
+	assert.DirExists(t, filepath.Join(buildDir, "static"))
+	assert.DirExists(t, filepath.Join(buildDir, "scripts"))
+}
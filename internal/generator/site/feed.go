@@ -0,0 +1,284 @@
+package site
+
+import (
+	"encoding/xml"
+	"fmt"
+	"html"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/title"
+	"github.com/tjnvr/blog/internal/generator/page/htmltext"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/metadata"
+)
+
+// FeedFormat is the syndication format of the generated feed
+type FeedFormat string
+
+const (
+	FeedRSS  FeedFormat = "rss"
+	FeedAtom FeedFormat = "atom"
+)
+
+type (
+	feedConfig struct {
+		section string
+		path    string
+		format  FeedFormat
+	}
+
+	// sectionFeedsConfig writes a feed named name per section, all the sections when none is set
+	sectionFeedsConfig struct {
+		name     string
+		format   FeedFormat
+		sections []string
+	}
+
+	// feedItem is a format agnostic feed entry
+	feedItem struct {
+		title     string
+		link      string
+		summary   string
+		createdAt string
+	}
+
+	rssFeed struct {
+		XMLName xml.Name   `xml:"rss"`
+		Version string     `xml:"version,attr"`
+		Channel rssChannel `xml:"channel"`
+	}
+
+	rssChannel struct {
+		Title         string    `xml:"title"`
+		Link          string    `xml:"link"`
+		Description   string    `xml:"description"`
+		LastBuildDate string    `xml:"lastBuildDate"`
+		Items         []rssItem `xml:"item"`
+	}
+
+	rssItem struct {
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		GUID        string `xml:"guid"`
+		Description string `xml:"description"`
+		PubDate     string `xml:"pubDate,omitempty"`
+	}
+
+	atomFeed struct {
+		XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+		Title   string      `xml:"title"`
+		ID      string      `xml:"id"`
+		Updated string      `xml:"updated"`
+		Link    atomLink    `xml:"link"`
+		Entries []atomEntry `xml:"entry"`
+	}
+
+	atomLink struct {
+		Href string `xml:"href,attr"`
+	}
+
+	atomEntry struct {
+		Title   string   `xml:"title"`
+		ID      string   `xml:"id"`
+		Link    atomLink `xml:"link"`
+		Updated string   `xml:"updated"`
+		Summary string   `xml:"summary"`
+	}
+)
+
+// generateFeed writes the feed of the configured section pages, if any.
+// Pages without a <h1> title are skipped.
+func (g *Generator) generateFeed() error {
+	if g.feed == nil {
+		return nil
+	}
+
+	items, err := g.feedItems(g.feed.section)
+	if err != nil {
+		return err
+	}
+
+	return g.writeFeed(filepath.Join(g.buildDir, g.feed.path), g.feed.format, g.sectionDisplayName(g.feed.section),
+		g.pageURL(g.outputPath(filepath.Join(g.feed.section, "index.md"))), items)
+}
+
+// generateSectionFeeds writes the feed of each configured section in its output directory,
+// and the aggregate feed of all of them at the build root. Feeds without pages are not written.
+func (g *Generator) generateSectionFeeds() error {
+	if g.sectionFeeds == nil {
+		return nil
+	}
+
+	sections := g.sectionFeeds.sections
+	if len(sections) == 0 {
+		for _, s := range g.sections {
+			if s.DirName != "" {
+				sections = append(sections, s.DirName)
+			}
+		}
+	}
+
+	all := make([]feedItem, 0)
+	for _, section := range sections {
+		items, err := g.feedItems(section)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			continue
+		}
+		all = append(all, items...)
+
+		feedPath := filepath.Join(g.buildDir, g.outputSection(section), g.sectionFeeds.name)
+		sectionLink := g.pageURL(g.outputPath(filepath.Join(section, "index.md")))
+		if err := g.writeFeed(feedPath, g.sectionFeeds.format, g.sectionDisplayName(section), sectionLink, items); err != nil {
+			return err
+		}
+	}
+
+	if len(all) == 0 {
+		return nil
+	}
+	sortFeedItems(all)
+	return g.writeFeed(filepath.Join(g.buildDir, g.sectionFeeds.name), g.sectionFeeds.format, g.homeName(),
+		g.pageURL(g.outputPath("index.md")), all)
+}
+
+// homeName returns the title of the aggregate feed: the home label when set, the home section display name otherwise.
+func (g *Generator) homeName() string {
+	if g.homeLabel != "" {
+		return g.homeLabel
+	}
+	if name := g.sectionDisplayName(""); name != "" {
+		return name
+	}
+	return "Home"
+}
+
+// writeFeed writes the items in format at feedPath, in a feed titled feedTitle linking to feedLink.
+func (g *Generator) writeFeed(feedPath string, format FeedFormat, feedTitle, feedLink string, items []feedItem) error {
+	var (
+		now  = time.Now()
+		feed any
+	)
+
+	switch format {
+	case FeedRSS:
+		channel := rssChannel{
+			Title:         feedTitle,
+			Link:          feedLink,
+			Description:   feedTitle,
+			LastBuildDate: now.Format(time.RFC1123Z),
+		}
+		for _, item := range items {
+			channel.Items = append(channel.Items, rssItem{
+				Title:       item.title,
+				Link:        item.link,
+				GUID:        item.link,
+				Description: item.summary,
+				PubDate:     formatFeedDate(item.createdAt, time.RFC1123Z),
+			})
+		}
+		feed = rssFeed{Version: "2.0", Channel: channel}
+	case FeedAtom:
+		atom := atomFeed{
+			Title:   feedTitle,
+			ID:      feedLink,
+			Updated: now.Format(time.RFC3339),
+			Link:    atomLink{Href: feedLink},
+		}
+		for _, item := range items {
+			updated := formatFeedDate(item.createdAt, time.RFC3339)
+			if updated == "" {
+				updated = atom.Updated
+			}
+			atom.Entries = append(atom.Entries, atomEntry{
+				Title:   item.title,
+				ID:      item.link,
+				Link:    atomLink{Href: item.link},
+				Updated: updated,
+				Summary: item.summary,
+			})
+		}
+		feed = atom
+	default:
+		return fmt.Errorf("unknown feed format %q", format)
+	}
+
+	data, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding feed: %w", err)
+	}
+
+	if err := g.fs.MkdirAll(filepath.Dir(feedPath), g.dirMode); err != nil {
+		return fmt.Errorf("creating directory for %s: %w", feedPath, err)
+	}
+	if err := g.writeOutput(feedPath, append([]byte(xml.Header), data...)); err != nil {
+		return fmt.Errorf("writing %s: %w", feedPath, err)
+	}
+	g.logger.Info("generated feed", "path", feedPath)
+	return nil
+}
+
+// feedItems returns the feed entries for the pages of section, most recent first.
+// The section index page is a listing and is not part of the feed.
+func (g *Generator) feedItems(section string) ([]feedItem, error) {
+	items := make([]feedItem, 0)
+	for _, p := range g.pages {
+		if p.unlisted || p.section != section || g.isSectionIndex(p.destinationHTMLPath, section) {
+			continue
+		}
+
+		content, err := g.fs.ReadFile(p.destinationHTMLPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p.destinationHTMLPath, err)
+		}
+
+		pageTitle, err := title.NewSubstituer().Resolve(string(content))
+		if err != nil {
+			continue
+		}
+
+		var createdAt string
+		if source, err := g.fs.ReadFile(p.sourceMDPath); err == nil {
+			createdAt = metadata.Extract(source).CreationDate
+		}
+
+		items = append(items, feedItem{
+			title:     html.UnescapeString(pageTitle),
+			link:      g.pageLocation(p.destinationHTMLPath),
+			summary:   htmltext.Text(htmltext.FirstParagraphHTML(string(content))),
+			createdAt: createdAt,
+		})
+	}
+
+	sortFeedItems(items)
+	return items, nil
+}
+
+// sortFeedItems sorts items most recent first.
+func sortFeedItems(items []feedItem) {
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].createdAt > items[j].createdAt
+	})
+}
+
+// sectionDisplayName returns the navigation display name of a section, or the directory name when unknown.
+func (g *Generator) sectionDisplayName(dirName string) string {
+	for _, s := range g.sections {
+		if s.DirName == dirName {
+			return s.DisplayName
+		}
+	}
+	return dirName
+}
+
+// formatFeedDate formats a "2006-01-02" creation date with layout, or returns an empty string if it cannot be parsed.
+func formatFeedDate(date, layout string) string {
+	t, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return ""
+	}
+	return t.Format(layout)
+}
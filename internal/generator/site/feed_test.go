@@ -0,0 +1,233 @@
+package site
+
+import (
+	"encoding/xml"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGenerateFeed(t *testing.T) {
+	files := map[string]string{
+		"index.md":        "# Home\n",
+		"posts/index.md":  "# Articles\n\n{{list-child-articles}}\n",
+		"posts/first.md":  "<!-- creation-date: 2026-01-10 -->\n# First & Best\n\nFirst **summary**.\n\nMore.\n",
+		"posts/second.md": "<!-- creation-date: 2026-02-20 -->\n# Second\n\nSecond summary.\n",
+		"about/index.md":  "# About\n\nNot a post.\n",
+	}
+
+	t.Run("rss", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithFeed("posts", "rss.xml", FeedRSS))
+		if err := gen.Generate(); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		data, err := os.ReadFile(filepath.Join(buildDir, "rss.xml"))
+		if err != nil {
+			t.Fatalf("rss.xml should be written: %v", err)
+		}
+
+		var feed rssFeed
+		if err := xml.Unmarshal(data, &feed); err != nil {
+			t.Fatalf("rss.xml is not valid XML: %v", err)
+		}
+		if feed.Channel.LastBuildDate == "" {
+			t.Error("channel should have a lastBuildDate")
+		}
+		if feed.Channel.Title != "Articles" {
+			t.Errorf("channel title = %q, want %q", feed.Channel.Title, "Articles")
+		}
+		if len(feed.Channel.Items) != 2 {
+			t.Fatalf("expected 2 items, got %d: %+v", len(feed.Channel.Items), feed.Channel.Items)
+		}
+
+		// Most recent first
+		second, first := feed.Channel.Items[0], feed.Channel.Items[1]
+		if second.Link != "/posts/second.html" {
+			t.Errorf("first item link = %q, want %q", second.Link, "/posts/second.html")
+		}
+		if first.Title != "First & Best" {
+			t.Errorf("item title = %q, want %q", first.Title, "First & Best")
+		}
+		if first.Description != "First summary." {
+			t.Errorf("item description = %q, want %q", first.Description, "First summary.")
+		}
+		if !strings.HasPrefix(first.PubDate, "Sat, 10 Jan 2026") {
+			t.Errorf("item pubDate = %q, want creation date", first.PubDate)
+		}
+	})
+
+	t.Run("atom", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithFeed("posts", "atom.xml", FeedAtom))
+		if err := gen.Generate(); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		data, err := os.ReadFile(filepath.Join(buildDir, "atom.xml"))
+		if err != nil {
+			t.Fatalf("atom.xml should be written: %v", err)
+		}
+
+		var feed atomFeed
+		if err := xml.Unmarshal(data, &feed); err != nil {
+			t.Fatalf("atom.xml is not valid XML: %v", err)
+		}
+		if len(feed.Entries) != 2 {
+			t.Fatalf("expected 2 entries, got %d", len(feed.Entries))
+		}
+		if feed.Entries[1].Link.Href != "/posts/first.html" {
+			t.Errorf("entry link = %q, want %q", feed.Entries[1].Link.Href, "/posts/first.html")
+		}
+	})
+
+	t.Run("no feed by default", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files)
+		if err := gen.Generate(); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		for _, name := range []string{"rss.xml", "atom.xml"} {
+			if _, err := os.Stat(filepath.Join(buildDir, name)); err == nil {
+				t.Errorf("%s should not be written without WithFeed", name)
+			}
+		}
+	})
+}
+
+func TestFeedItems_SkipsPagesWithoutTitle(t *testing.T) {
+	buildDir := t.TempDir()
+	pages := map[string]string{
+		"posts/titled.html":   "<h1>Titled</h1><p>Summary</p>",
+		"posts/untitled.html": "<h2>No h1</h2><p>Summary</p>",
+	}
+
+	g, _ := NewGenerator()
+	g.withBuildDir(buildDir)
+	for path, content := range pages {
+		fullPath := filepath.Join(buildDir, path)
+		_ = os.MkdirAll(filepath.Dir(fullPath), 0755)
+		_ = os.WriteFile(fullPath, []byte(content), 0644)
+		g.pages = append(g.pages, generatedPage{
+			sourceMDPath:        "missing.md",
+			destinationHTMLPath: fullPath,
+			section:             "posts",
+		})
+	}
+
+	items, err := g.feedItems("posts")
+	if err != nil {
+		t.Fatalf("feedItems() error = %v", err)
+	}
+	if len(items) != 1 || items[0].title != "Titled" {
+		t.Errorf("expected only the titled page, got %+v", items)
+	}
+}
+
+func TestGenerateSectionFeeds(t *testing.T) {
+	files := map[string]string{
+		"index.md":        "# Home\n",
+		"posts/index.md":  "# Articles\n",
+		"posts/first.md":  "<!-- creation-date: 2026-01-10 -->\n# First post\n\nFirst.\n",
+		"posts/second.md": "<!-- creation-date: 2026-03-05 -->\n# Second post\n\nSecond.\n",
+		"notes/index.md":  "# Notes\n",
+		"notes/idea.md":   "<!-- creation-date: 2026-02-01 -->\n# An idea\n\nIdea.\n",
+		"about/index.md":  "# About\n",
+	}
+
+	readFeed := func(t *testing.T, path string) rssFeed {
+		t.Helper()
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("%s should be written: %v", path, err)
+		}
+		var feed rssFeed
+		if err := xml.Unmarshal(data, &feed); err != nil {
+			t.Fatalf("%s is not valid XML: %v", path, err)
+		}
+		return feed
+	}
+	links := func(feed rssFeed) []string {
+		result := make([]string, 0, len(feed.Channel.Items))
+		for _, item := range feed.Channel.Items {
+			result = append(result, item.Link)
+		}
+		return result
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files,
+		WithBaseURL("https://example.org"), WithSectionFeeds("feed.xml", FeedRSS), WithPathStrategy(LegacyPathStrategy{}))
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	posts := readFeed(t, filepath.Join(buildDir, "post", "feed.xml"))
+	if got, want := strings.Join(links(posts), " "), "https://example.org/post/second.html https://example.org/post/first.html"; got != want {
+		t.Errorf("posts feed links = %q, want %q", got, want)
+	}
+	if posts.Channel.Link != "https://example.org/post/index.html" {
+		t.Errorf("posts feed link = %q, want the section index", posts.Channel.Link)
+	}
+
+	notes := readFeed(t, filepath.Join(buildDir, "notes", "feed.xml"))
+	if got, want := strings.Join(links(notes), " "), "https://example.org/notes/idea.html"; got != want {
+		t.Errorf("notes feed links = %q, want %q", got, want)
+	}
+
+	all := readFeed(t, filepath.Join(buildDir, "feed.xml"))
+	want := "https://example.org/post/second.html https://example.org/notes/idea.html https://example.org/post/first.html"
+	if got := strings.Join(links(all), " "); got != want {
+		t.Errorf("aggregate feed links = %q, want %q", got, want)
+	}
+
+	if _, err := os.Stat(filepath.Join(buildDir, "about", "feed.xml")); err == nil {
+		t.Error("a section without pages should not get a feed")
+	}
+}
+
+func TestGenerate_FeedPathCollision(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n",
+		"posts/index.md": "# Posts\n",
+		"posts/first.md": "<!-- creation-date: 2026-01-10 -->\n# First post\n\nFirst.\n",
+	}
+
+	t.Run("feed at the aggregate section feed path", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files,
+			WithFeed("posts", "feed.xml", FeedRSS),
+			WithSectionFeeds("feed.xml", FeedRSS),
+		)
+		err := gen.Generate()
+		if err == nil {
+			t.Fatal("Generate() should fail when two feeds are written at the same path")
+		}
+		want := filepath.Join(buildDir, "feed.xml") + " is output from several sources: the feed, the aggregate section feed"
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Generate() error = %v, want it to contain %q", err, want)
+		}
+		if _, statErr := os.Stat(filepath.Join(buildDir, "feed.xml")); statErr == nil {
+			t.Error("no feed should be written")
+		}
+	})
+
+	t.Run("section feed at the path of a content file", func(t *testing.T) {
+		withFeedFile := map[string]string{"posts/rss.xml": "<rss></rss>"}
+		maps.Copy(withFeedFile, files)
+		gen, buildDir := newIntegrationTestGenerator(t, withFeedFile, WithSectionFeeds("rss.xml", FeedRSS, "posts"))
+		err := gen.Generate()
+		want := filepath.Join(buildDir, "posts", "rss.xml") + " is output from several sources: "
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Generate() error = %v, want it to contain %q", err, want)
+		}
+	})
+
+	t.Run("distinct paths", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files,
+			WithFeed("posts", "posts.xml", FeedRSS),
+			WithSectionFeeds("feed.xml", FeedRSS),
+		)
+		if err := gen.Generate(); err != nil {
+			t.Errorf("Generate() error = %v", err)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package site
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// fingerprintLength is the number of hexadecimal characters of the content hash inserted in file names.
+const fingerprintLength = 8
+
+// urlAttributeRe matches the attributes of generated pages which may reference a copied file.
+var urlAttributeRe = regexp.MustCompile(`(\s(?:href|src|content)=")([^"]*)(")`)
+
+// fingerprintName inserts a short hash of data before the extension of the file name, e.g. style.abc12345.css.
+func fingerprintName(name string, data []byte) string {
+	sum := sha256.Sum256(data)
+	hash := hex.EncodeToString(sum[:])[:fingerprintLength]
+	ext := filepath.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// fingerprintRenamer returns the copyDir rename function recording the fingerprinted name of the files copied
+// to outDir, relative to the build directory. It returns nil when fingerprinting is disabled.
+func (g *Generator) fingerprintRenamer(outDir string) func(relPath string, data []byte) string {
+	if !g.fingerprint {
+		return nil
+	}
+	return func(relPath string, data []byte) string {
+		renamed := fingerprintName(relPath, data)
+		outDir := filepath.ToSlash(outDir)
+		g.fingerprints[path.Join(outDir, filepath.ToSlash(relPath))] = path.Join(outDir, filepath.ToSlash(renamed))
+		return renamed
+	}
+}
+
+// fingerprintRewriter returns a function rewriting the references of the page generated at htmlPath
+// to fingerprinted files. It returns nil when fingerprinting is disabled.
+// References are relative, root-relative or absolute under the base URL, in href, src and content attributes.
+func (g *Generator) fingerprintRewriter(htmlPath string) func(content string) string {
+	if !g.fingerprint {
+		return nil
+	}
+
+	pageDir := g.pageDir(htmlPath)
+	return func(content string) string {
+		return urlAttributeRe.ReplaceAllStringFunc(content, func(attr string) string {
+			m := urlAttributeRe.FindStringSubmatch(attr)
+			return m[1] + g.fingerprintedURL(m[2], pageDir) + m[3]
+		})
+	}
+}
+
+// fingerprintedURL returns ref with the file name of the fingerprinted file it references, or ref itself.
+func (g *Generator) fingerprintedURL(ref, pageDir string) string {
+	target, suffix := ref, ""
+	if i := strings.IndexAny(ref, "?#"); i >= 0 {
+		target, suffix = ref[:i], ref[i:]
+	}
+
+	buildPath, ok := g.referencedBuildPath(target, pageDir)
+	if !ok {
+		return ref
+	}
+
+	renamed, ok := g.fingerprints[buildPath]
+	if !ok {
+		return ref
+	}
+	return strings.TrimSuffix(target, path.Base(target)) + path.Base(renamed) + suffix
+}
+
+// pageDir returns the directory of the page generated at htmlPath, relative to the build directory.
+func (g *Generator) pageDir(htmlPath string) string {
+	if rel, err := filepath.Rel(g.buildDir, filepath.Dir(htmlPath)); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return "."
+}
+
+// referencedBuildPath returns the path, relative to the build directory, of the file referenced by target
+// from a page in pageDir. Targets are relative, root-relative or absolute under the base URL, without query or fragment.
+// It returns false for other targets, such as external URLs.
+func (g *Generator) referencedBuildPath(target, pageDir string) (string, bool) {
+	buildPath := target
+	switch {
+	case target == "":
+		return "", false
+	case g.baseURL != "" && strings.HasPrefix(target, g.siteURL()+"/"):
+		buildPath = strings.TrimPrefix(target, g.siteURL()+"/")
+	case g.pathPrefix != "" && strings.HasPrefix(target, g.pathPrefix+"/"):
+		buildPath = strings.TrimPrefix(target, g.pathPrefix+"/")
+	case strings.HasPrefix(target, "/"):
+		buildPath = strings.TrimPrefix(target, "/")
+	default:
+		if u, err := url.Parse(target); err != nil || u.Scheme != "" || u.Host != "" {
+			return "", false
+		}
+		buildPath = path.Join(pageDir, target)
+	}
+	return path.Clean(buildPath), true
+}
@@ -0,0 +1,66 @@
+package site
+
+import "testing"
+
+func TestFingerprintName(t *testing.T) {
+	a := fingerprintName("css/style.css", []byte("body {}"))
+	b := fingerprintName("css/style.css", []byte("body { color: red }"))
+
+	if a == b {
+		t.Errorf("fingerprintName() should change with the content, got %q twice", a)
+	}
+	if a != fingerprintName("css/style.css", []byte("body {}")) {
+		t.Errorf("fingerprintName() should be stable for the same content")
+	}
+	if len(a) != len("css/style..css")+fingerprintLength {
+		t.Errorf("fingerprintName() = %q, want a %d characters hash before the extension", a, fingerprintLength)
+	}
+}
+
+func TestGenerator_FingerprintedURL(t *testing.T) {
+	g := &Generator{
+		baseURL: "https://example.org",
+		fingerprints: map[string]string{
+			"assets/images/logo.png": "assets/images/logo.1234abcd.png",
+			"scripts/app.js":         "scripts/app.deadbeef.js",
+		},
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		pageDir string
+		want    string
+	}{
+		{name: "relative from the root", ref: "assets/images/logo.png", pageDir: ".", want: "assets/images/logo.1234abcd.png"},
+		{name: "relative from a section", ref: "../scripts/app.js", pageDir: "posts", want: "../scripts/app.deadbeef.js"},
+		{name: "root-relative", ref: "/scripts/app.js", pageDir: "posts", want: "/scripts/app.deadbeef.js"},
+		{name: "absolute under the base url", ref: "https://example.org/assets/images/logo.png", pageDir: ".", want: "https://example.org/assets/images/logo.1234abcd.png"},
+		{name: "query and fragment are kept", ref: "/scripts/app.js?v=1#main", pageDir: ".", want: "/scripts/app.deadbeef.js?v=1#main"},
+		{name: "other host", ref: "https://cdn.example.com/scripts/app.js", pageDir: ".", want: "https://cdn.example.com/scripts/app.js"},
+		{name: "not fingerprinted", ref: "../styles.css", pageDir: "posts", want: "../styles.css"},
+		{name: "fragment only", ref: "#top", pageDir: ".", want: "#top"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := g.fingerprintedURL(tt.ref, tt.pageDir); got != tt.want {
+				t.Errorf("fingerprintedURL(%q, %q) = %q, want %q", tt.ref, tt.pageDir, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_FingerprintRewriter(t *testing.T) {
+	g := &Generator{buildDir: "/build", fingerprints: map[string]string{"scripts/app.js": "scripts/app.deadbeef.js"}}
+	if g.fingerprintRewriter("/build/index.html") != nil {
+		t.Fatal("fingerprintRewriter() should be nil when fingerprinting is disabled")
+	}
+
+	g.fingerprint = true
+	got := g.fingerprintRewriter("/build/posts/index.html")(`<script src="../scripts/app.js"></script><a href="../scripts/app.js">app.js</a>`)
+	want := `<script src="../scripts/app.deadbeef.js"></script><a href="../scripts/app.deadbeef.js">app.js</a>`
+	if got != want {
+		t.Errorf("rewrite = %q, want %q", got, want)
+	}
+}
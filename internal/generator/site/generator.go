@@ -3,77 +3,832 @@ package site
 import (
 	"errors"
 	"fmt"
+	"log/slog"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	htmlsubstitutions "github.com/tjnvr/blog/internal/generator/page/html/substitution"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/noscript"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/pager"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/link"
+	"github.com/tjnvr/blog/internal/generator/page/markdown"
 	"github.com/tjnvr/blog/internal/generator/section"
 )
 
 type (
 	PageGenerator interface {
 		Generate() error
+		// Load reads the page generated by a previous build instead of generating it
+		Load() error
 		Validate() error
 	}
 
-	pageGeneratorFactory func(sourceMDPath, destinationHTMLPath, buildDir, pageSection string, assetsPathTranslater, linksPathTranslater newPathResolver, sections []section.Section, skipURLValidation bool) PageGenerator
+	// pageConfig holds everything needed to build the generator of a single page
+	pageConfig struct {
+		sourceMDPath         string
+		source               []byte
+		destinationHTMLPath  string
+		buildDir             string
+		pageSection          string
+		assetsPathTranslater newPathResolver
+		linksPathTranslater  newPathResolver
+		sections             []section.Section
+		skipURLValidation    bool
+		externalTimeout      time.Duration
+		noscriptFallbacks    []noscript.Fallback
+		dateLayout           string
+		lastModLayout        string
+		linkCache            *link.Cache
+		template             string
+		includeDrafts        bool
+		listingExcerpts      bool
+		homeLabel            string
+		pageURL              string
+		defaultImage         string
+		headExtra            string
+		copyrightStartYear   int
+		pathPrefix           string
+		maxImageSize         int64
+		navActiveClass       string
+		siteVariables        map[string]string
+		strictSiteVariables  bool
+		markdownExtensions   []string
+		pageDepth            int
+		substituters         []htmlsubstitutions.Substituer
+		validators           []validation.Validator
+		converter            *markdown.Converter
+		converterOptions     []markdown.Option
+		minify               bool
+		imageDimensions      bool
+		rewrite              func(content string) string
+		logger               *slog.Logger
+		fs                   filesystem.FileSystem
+		newerPost            pager.Link
+		olderPost            pager.Link
+		allowedClasses       []string
+		excludedPages        map[string]string
+		strictValidation     bool
+		fileMode             os.FileMode
+		dirMode              os.FileMode
+	}
+
+	pageGeneratorFactory func(cfg pageConfig) PageGenerator
+
+	// generatedPage records where a page generated during the build comes from and goes to
+	generatedPage struct {
+		sourceMDPath        string
+		destinationHTMLPath string
+		section             string
+		// unlisted pages, such as the not-found page, are not listed in the sitemap, feed or search index
+		unlisted bool
+	}
 
 	Option func(*Generator)
 )
 
+// defaultMaxSectionDepth is the deepest section nesting (e.g. "a/b/c") accepted without warning.
+const defaultMaxSectionDepth = 3
+
 // Generator is the site generator which allows to generate and validate the site
 // All files and directories attributes are relative to the project root.
 type Generator struct {
 	contentDir           string
+	contentDirs          []string
+	contentMounts        map[string]string
 	assetsDir            string
 	assetsOutDir         string
 	buildDir             string
 	scriptsDir           string
 	scriptsOutDir        string
 	skipURLValidation    bool
+	externalTimeout      time.Duration
+	noscriptFallbacks    []noscript.Fallback
+	dateLayout           string
+	lastModLayout        string
+	maxSectionDepth      int
+	strict               bool
+	strictValidation     bool
+	warnings             []string
 	pageGeneratorFactory pageGeneratorFactory
 	sections             []section.Section
+	pageConfigs          []pageConfig
 	pagesGenerators      []PageGenerator
+	pages                []generatedPage
+	excludedPages        map[string]string
+	feed                 *feedConfig
+	sectionFeeds         *sectionFeedsConfig
+	baseURL              string
+	pathPrefix           string
+	maxImageSize         int64
+	sitemap              bool
+	robots               bool
+	robotsRules          string
+	incremental          bool
+	binaryModTime        time.Time
+	configModTime        time.Time
+	linkCache            *link.Cache
+	sectionTemplates     map[string]string
+	template             string
+	templateFile         string
+	includeDrafts        bool
+	listingExcerpts      bool
+	navConfig            section.NavConfig
+	navConfigFile        string
+	navExcluded          []string
+	notFoundPage         string
+	homeLabel            string
+	defaultImage         string
+	headExtra            string
+	copyrightStartYear   int
+	navActiveClass       string
+	siteVariables        map[string]string
+	siteVariablesFile    string
+	strictSiteVariables  bool
+	resolvedSiteVars     map[string]string
+	markdownExtensions   []string
+	substituters         []htmlsubstitutions.Substituer
+	validators           []validation.Validator
+	report               BuildReport
+	syntaxTheme          string
+	converter            *markdown.Converter
+	unsafeHTML           bool
+	emoji                bool
+	noAutolink           bool
+	htmlPolicy           *markdown.Policy
+	minify               bool
+	math                 bool
+	elementAttributes    markdown.ElementAttributes
+	lazyImages           bool
+	tableWrapper         string
+	headingAnchorClass   string
+	headingAnchorSymbol  string
+	codeBlocks           *markdown.CodeBlocks
+	conversionCache      *markdown.Cache
+	linkIcon             string
+	linkIconNoMailto     bool
+	imageDimensions      bool
+	externalLinks        bool
+	externalLinksNewTab  bool
+	fingerprint          bool
+	fingerprints         map[string]string
+	imageFormats         []ImageFormat
+	imageFormatsSkip     []string
+	imageEncoder         ImageEncoder
+	imageVariants        map[string][]imageVariant
+	ignorePatterns       []string
+	nonMarkdownFiles     NonMarkdownFiles
+	searchIndex          bool
+	manifestPath         string
+	outputs              map[string]bool
+	tagPages             bool
+	pathStrategy         PathStrategy
+	homePage             string
+	cleanURLs            bool
+	lowercaseOutputPaths bool
+	unusedAssets         bool
+	failOnUnusedAssets   bool
+	logger               *slog.Logger
+	dryRun               bool
+	minWords             int
+	maxWords             int
+	classAllowlist       []string
+	classAllowlistFile   string
+	allowedClasses       []string
+	fileMode             os.FileMode
+	dirMode              os.FileMode
 	fs                   filesystem.FileSystem
 }
 
-// WithSkipURLValidation returns an Option that disables external URL validation.
+// WithAssetsOutDir returns an Option that sets the directory the assets are copied to, relative to the build directory,
+// e.g. "static". It is "assets" by default.
+func WithAssetsOutDir(dir string) Option {
+	return func(g *Generator) { g.assetsOutDir = filepath.Clean(dir) }
+}
+
+// WithScriptsOutDir returns an Option that sets the directory the scripts are copied to, relative to the build directory.
+// It is "scripts" by default.
+func WithScriptsOutDir(dir string) Option {
+	return func(g *Generator) { g.scriptsOutDir = filepath.Clean(dir) }
+}
+
+// WithFileMode returns an Option that sets the permissions of the written files, e.g. 0664 for group-writable files.
+// It is 0644 by default. A zero mode is ignored.
+func WithFileMode(mode os.FileMode) Option {
+	return func(g *Generator) {
+		if mode != 0 {
+			g.fileMode = mode
+		}
+	}
+}
+
+// WithDirMode returns an Option that sets the permissions of the created directories. It is 0755 by default.
+// A zero mode is ignored.
+func WithDirMode(mode os.FileMode) Option {
+	return func(g *Generator) {
+		if mode != 0 {
+			g.dirMode = mode
+		}
+	}
+}
+
+// WithSkipURLValidation returns an Option that skips the checks of the external links and images,
+// e.g. in an offline CI.
 func WithSkipURLValidation(skip bool) Option {
 	return func(g *Generator) { g.skipURLValidation = skip }
 }
 
+// WithExternalTimeout returns an Option that sets the timeout of the checks of the external links and images,
+// 10 seconds by default.
+func WithExternalTimeout(timeout time.Duration) Option {
+	return func(g *Generator) { g.externalTimeout = timeout }
+}
+
+// WithMaxImageSize returns an Option that warns about the local images of the pages heavier than maxBytes, e.g. 500*1024,
+// and the empty ones, failing strict validations. Image sizes are not checked by default.
+func WithMaxImageSize(maxBytes int64) Option {
+	return func(g *Generator) { g.maxImageSize = maxBytes }
+}
+
+// WithNoscriptFallbacks returns an Option that adds <noscript> fallbacks for script dependent features to every page.
+func WithNoscriptFallbacks(fallbacks ...noscript.Fallback) Option {
+	return func(g *Generator) { g.noscriptFallbacks = append(g.noscriptFallbacks, fallbacks...) }
+}
+
+// WithFeed returns an Option that writes a feed of the pages of section to path, relative to the build directory.
+func WithFeed(section, path string, format FeedFormat) Option {
+	return func(g *Generator) { g.feed = &feedConfig{section: section, path: path, format: format} }
+}
+
+// WithSectionFeeds returns an Option that writes a feed of the pages of each section to name in its output directory,
+// e.g. posts/feed.xml, and a feed of the pages of all of them to name at the build root.
+// All the top-level sections get a feed when no section is given. Sections without pages get no feed.
+func WithSectionFeeds(name string, format FeedFormat, sections ...string) Option {
+	return func(g *Generator) {
+		g.sectionFeeds = &sectionFeedsConfig{name: name, format: format, sections: sections}
+	}
+}
+
+// WithPathPrefix returns an Option that serves the site under a path of its host, e.g. "/blog" for https://example.org/blog/.
+// The root-relative references of the pages, e.g. /assets/photo.png, are prefixed with it, and so are the absolute
+// URLs of the pages, which are set with WithBaseURL without the prefix. The site is served from the host root by default.
+func WithPathPrefix(prefix string) Option {
+	return func(g *Generator) { g.pathPrefix = normalizePathPrefix(prefix) }
+}
+
+// WithBaseURL returns an Option that sets the absolute URL the site is served from, e.g. "https://example.org".
+// It is used to build absolute links in feeds and the sitemap.
+func WithBaseURL(url string) Option {
+	return func(g *Generator) { g.baseURL = strings.TrimSuffix(url, "/") }
+}
+
+// WithDefaultImage returns an Option that sets the link preview image of pages without image,
+// e.g. "/assets/images/logo.png". Previews need a base URL to reference images.
+func WithDefaultImage(src string) Option {
+	return func(g *Generator) { g.defaultImage = src }
+}
+
+// WithUnsafeHTML returns an Option that renders the raw HTML of the markdown instead of omitting it,
+// sanitized with the policy set by WithHTMLSanitizer if any. Only enable it without sanitizer for trusted content.
+func WithUnsafeHTML(enabled bool) Option {
+	return func(g *Generator) { g.unsafeHTML = enabled }
+}
+
+// WithHTMLSanitizer returns an Option that sanitizes the raw HTML rendered with WithUnsafeHTML with policy,
+// e.g. markdown.DefaultPolicy(). Without classes set, the policy allows the classes of the class allowlist when configured.
+func WithHTMLSanitizer(policy *markdown.Policy) Option {
+	return func(g *Generator) { g.htmlPolicy = policy }
+}
+
+// WithHeadExtra returns an Option that inserts html in the <head> of the pages, at the {{head_extra}} placeholder,
+// e.g. an analytics snippet. Pages setting head_extra in their front matter get theirs instead.
+func WithHeadExtra(html string) Option {
+	return func(g *Generator) { g.headExtra = html }
+}
+
+// WithMarkdownExtensions returns an Option that sets the extensions of the markdown sources, e.g. ".md" and ".mdown".
+// They are ".md" and ".markdown" by default. The other files follow the ignore and non-markdown files rules.
+func WithMarkdownExtensions(extensions ...string) Option {
+	return func(g *Generator) { g.markdownExtensions = extensions }
+}
+
+// WithWordCountLimits returns an Option that fails the build when a page other than the section index pages
+// has fewer than minWords or more than maxWords words in its main content. A zero limit is not checked.
+func WithWordCountLimits(minWords, maxWords int) Option {
+	return func(g *Generator) { g.minWords, g.maxWords = minWords, maxWords }
+}
+
+// WithSubstituter returns an Option that registers s in the HTML substitution registry of every page,
+// e.g. a {{year}} substituter for a copyright notice. Substituters are applied after the built-in ones,
+// in the order they are registered.
+func WithSubstituter(s htmlsubstitutions.Substituer) Option {
+	return func(g *Generator) { g.substituters = append(g.substituters, s) }
+}
+
+// WithValidator returns an Option that registers v in the validation registry of every page and of ValidateBuild,
+// e.g. a check that the pages have a footer. Validators run after the built-in ones, in the order they are registered.
+func WithValidator(v validation.Validator) Option {
+	return func(g *Generator) { g.validators = append(g.validators, v) }
+}
+
+// WithSyntaxTheme returns an Option that sets the chroma style highlighting fenced code blocks, e.g. "monokai".
+func WithSyntaxTheme(name string) Option {
+	return func(g *Generator) { g.syntaxTheme = name }
+}
+
+// WithMath returns an Option that renders the $...$ and $$...$$ LaTeX math of the pages with KaTeX.
+// The renderer is only loaded by the pages containing math.
+func WithMath(enabled bool) Option {
+	return func(g *Generator) { g.math = enabled }
+}
+
+// WithAutolink returns an Option that turns the bare URLs of the pages, e.g. https://example.com, into links.
+// It is enabled by default.
+func WithAutolink(enabled bool) Option {
+	return func(g *Generator) { g.noAutolink = !enabled }
+}
+
+// WithEmoji returns an Option that replaces the emoji shortcodes of the pages, e.g. :tada:, with their emoji.
+// See markdown.Emojis for the recognized shortcodes.
+func WithEmoji(enabled bool) Option {
+	return func(g *Generator) { g.emoji = enabled }
+}
+
+// WithMinify returns an Option that minifies the generated pages.
+func WithMinify(enabled bool) Option {
+	return func(g *Generator) { g.minify = enabled }
+}
+
+// WithElementAttributes returns an Option that sets attributes on the elements converted from markdown,
+// e.g. {"img": {"loading": "lazy"}}. Attributes set inline with {...} take precedence.
+func WithElementAttributes(attributes markdown.ElementAttributes) Option {
+	return func(g *Generator) { g.elementAttributes = attributes }
+}
+
+// WithLazyImages returns an Option that sets loading="lazy" and decoding="async" on the images of the pages,
+// unless set inline, e.g. ![Photo](photo.png){loading=eager}. It is enabled by default.
+func WithLazyImages(enabled bool) Option {
+	return func(g *Generator) { g.lazyImages = enabled }
+}
+
+// WithImageDimensions returns an Option that sets width and height on the local images of the pages, unless set inline,
+// read from the PNG, JPEG, GIF or WebP files, so that browsers reserve their space while loading them.
+func WithImageDimensions(enabled bool) Option {
+	return func(g *Generator) { g.imageDimensions = enabled }
+}
+
+// WithHeadingAnchor returns an Option that sets the class and the text of the anchor links appended to the headings
+// of the pages, markdown.DefaultHeadingAnchorClass and markdown.DefaultHeadingAnchorSymbol when empty, e.g. ("anchor", "¶").
+// The class is allowed by the class allowlist.
+func WithHeadingAnchor(class, symbol string) Option {
+	return func(g *Generator) { g.headingAnchorClass, g.headingAnchorSymbol = class, symbol }
+}
+
+// WithTableWrapper returns an Option that wraps the tables of the pages in a <div> with class,
+// markdown.DefaultTableWrapperClass when empty, to style it to scroll horizontally on narrow screens,
+// e.g. .table-wrapper { overflow-x: auto; }. The class is allowed by the class allowlist.
+func WithTableWrapper(class string) Option {
+	return func(g *Generator) {
+		if class == "" {
+			class = markdown.DefaultTableWrapperClass
+		}
+		g.tableWrapper = class
+	}
+}
+
+// WithConversionCache returns an Option that keeps the HTML of the converted markdown from a build to the next,
+// so that the unchanged pages are not converted again when regenerating the site, e.g. in watch mode.
+func WithConversionCache(enabled bool) Option {
+	return func(g *Generator) {
+		g.conversionCache = nil
+		if enabled {
+			g.conversionCache = markdown.NewCache(markdown.DefaultCacheSize)
+		}
+	}
+}
+
+// WithCodeBlocks returns an Option that wraps the fenced code blocks of the pages in a <div> with a copy <button>
+// and, when configured, line numbers, for a script of the scripts directory to copy the code.
+// The classes of the markup are allowed by the class allowlist.
+func WithCodeBlocks(codeBlocks markdown.CodeBlocks) Option {
+	return func(g *Generator) { g.codeBlocks = &codeBlocks }
+}
+
+// WithExternalLinkIcon returns an Option that appends an empty <span> with class, markdown.DefaultExternalLinkIconClass
+// when empty, to the links of the pages to other hosts than the base URL one, to style it as an external link icon.
+// The mailto: and tel: links are marked too, unless excludeMailto is set. The class is allowed by the class allowlist.
+func WithExternalLinkIcon(class string, excludeMailto bool) Option {
+	return func(g *Generator) {
+		if class == "" {
+			class = markdown.DefaultExternalLinkIconClass
+		}
+		g.linkIcon, g.linkIconNoMailto = class, excludeMailto
+	}
+}
+
+// WithExternalLinks returns an Option that sets rel="noopener noreferrer" on the links to other hosts than the base URL one,
+// and target="_blank" when newTab is set. Relative links are internal.
+func WithExternalLinks(newTab bool) Option {
+	return func(g *Generator) { g.externalLinks, g.externalLinksNewTab = true, newTab }
+}
+
+// WithFingerprint returns an Option that inserts a hash of their content in the names of the copied assets and scripts,
+// e.g. dark-mode.1a2b3c4d.js, so that they can be cached for long. Every copied file is renamed, referenced or not,
+// and the references of the generated pages to them are rewritten.
+// Pages are always regenerated when enabled, as the names change with the files content.
+func WithFingerprint(enabled bool) Option {
+	return func(g *Generator) { g.fingerprint = enabled }
+}
+
+// WithImageFormats returns an Option that converts the PNG and JPEG assets to the formats, in order of preference,
+// and rewrites the images of the pages referencing them into picture elements with a source per format,
+// the original image being the fallback. SVG and WebP assets are copied as they are.
+// Conversion uses cwebp and avifenc unless another encoder is set with WithImageEncoder.
+func WithImageFormats(formats ...ImageFormat) Option {
+	return func(g *Generator) { g.imageFormats = append(g.imageFormats, formats...) }
+}
+
+// WithImageFormatsSkip returns an Option that does not convert the assets matching one of the glob patterns,
+// e.g. "icons/" or "*.raw.png". Patterns are matched against the path relative to the assets directory,
+// patterns without "/" against the file and directory names.
+func WithImageFormatsSkip(patterns ...string) Option {
+	return func(g *Generator) { g.imageFormatsSkip = append(g.imageFormatsSkip, patterns...) }
+}
+
+// WithImageEncoder returns an Option that sets the encoder converting the assets to the image formats.
+func WithImageEncoder(encoder ImageEncoder) Option {
+	return func(g *Generator) { g.imageEncoder = encoder }
+}
+
+// WithIgnore returns an Option that skips the content files and directories matching one of the glob patterns,
+// e.g. "_drafts/" or "*.txt". Patterns are matched against the path relative to the content directory,
+// patterns without "/" against the file and directory names.
+func WithIgnore(patterns ...string) Option {
+	return func(g *Generator) { g.ignorePatterns = append(g.ignorePatterns, patterns...) }
+}
+
+// WithNonMarkdownFiles returns an Option that sets how the non markdown files of the content directory are handled.
+// They are copied to the build directory by default, see NonMarkdownError to fail the generation instead.
+func WithNonMarkdownFiles(handling NonMarkdownFiles) Option {
+	return func(g *Generator) { g.nonMarkdownFiles = handling }
+}
+
+// WithSitemap returns an Option that writes a sitemap.xml of all generated pages at the build root.
+func WithSitemap(enabled bool) Option {
+	return func(g *Generator) { g.sitemap = enabled }
+}
+
+// WithRobots returns an Option that writes a robots.txt with rules at the build root, e.g. "User-agent: *\nDisallow: /drafts/".
+// Empty rules allow every crawler. The generated sitemap is referenced when a base URL is configured.
+// No robots.txt is written by default.
+func WithRobots(rules string) Option {
+	return func(g *Generator) { g.robots, g.robotsRules = true, rules }
+}
+
+// WithSearchIndex returns an Option that writes a search-index.json of all generated pages at the build root,
+// listing the url, title and an excerpt of the text of each page for client-side search.
+func WithSearchIndex(enabled bool) Option {
+	return func(g *Generator) { g.searchIndex = enabled }
+}
+
+// WithManifest returns an Option that writes a JSON manifest at path listing every file of the build directory,
+// with its size, its SHA-256 hash and, for pages, their markdown source. It is written at the end of Generate,
+// except in dry-run mode. No manifest is written by default.
+func WithManifest(path string) Option {
+	return func(g *Generator) { g.manifestPath = path }
+}
+
+// WithTagPages returns an Option that generates a tags/index.html page listing the tags of the pages front matter,
+// and a tags/<tag>.html page listing the pages of each tag.
+func WithTagPages(enabled bool) Option {
+	return func(g *Generator) { g.tagPages = enabled }
+}
+
+// WithPathStrategy returns an Option that sets how content paths map to build paths.
+// MirrorPathStrategy is used by default.
+func WithPathStrategy(strategy PathStrategy) Option {
+	return func(g *Generator) { g.pathStrategy = strategy }
+}
+
+// WithHomePage returns an Option that outputs the markdown file at relPath, at the root of the content directory,
+// e.g. landing.md, as the index.html home page, on top of the path strategy. The other root files keep their name,
+// home.md included, instead of the path strategy picking the home page by file name.
+func WithHomePage(relPath string) Option {
+	return func(g *Generator) { g.homePage = relPath }
+}
+
+// WithCleanURLs returns an Option that outputs the pages other than the index pages in a directory of their own,
+// e.g. posts/hello.md as posts/hello/index.html, on top of the path strategy, and links to the index pages
+// through their directory, e.g. posts/hello/, so that hosts serve the pages at clean URLs.
+func WithCleanURLs(enabled bool) Option {
+	return func(g *Generator) { g.cleanURLs = enabled }
+}
+
+// WithLowercaseOutputPaths returns an Option that outputs the content files at lowercase paths, on top of the path strategy,
+// e.g. About.md as about.html, so that the URLs do not depend on the case of the sources. The case is kept by default.
+func WithLowercaseOutputPaths(enabled bool) Option {
+	return func(g *Generator) { g.lowercaseOutputPaths = enabled }
+}
+
+// WithUnusedAssets returns an Option that warns about the assets no generated page references.
+func WithUnusedAssets(enabled bool) Option {
+	return func(g *Generator) { g.unusedAssets = enabled }
+}
+
+// WithFailOnUnusedAssets returns an Option that fails the build when an asset is not referenced by any generated page,
+// instead of warning about it.
+func WithFailOnUnusedAssets(fail bool) Option {
+	return func(g *Generator) { g.failOnUnusedAssets = fail }
+}
+
+// WithClassAllowlist returns an Option that validates the classes of the generated pages against classes,
+// reporting unknown ones, e.g. Tailwind utility typos such as text-4xs. Classes are not validated by default.
+func WithClassAllowlist(classes ...string) Option {
+	return func(g *Generator) { g.classAllowlist = append(g.classAllowlist, classes...) }
+}
+
+// WithClassAllowlistFile returns an Option that validates the classes of the generated pages against the classes
+// of a safelist file, separated by white space, read again on every build. They add to the WithClassAllowlist ones.
+func WithClassAllowlistFile(path string) Option {
+	return func(g *Generator) { g.classAllowlistFile = path }
+}
+
+// WithNavigationActiveClass returns an Option that sets the class of the navigation link to the section of the page,
+// e.g. "text-blue-600 font-bold", instead of navigation.DefaultActiveClass. The link is marked with aria-current="page" either way.
+// The class is allowed by the class allowlist.
+func WithNavigationActiveClass(class string) Option {
+	return func(g *Generator) { g.navActiveClass = class }
+}
+
+// WithCopyrightStartYear returns an Option that resolves the {{copyright_year}} placeholder of the pages with the range
+// from year to the current year, e.g. 2019–2025. It resolves to the current year by default.
+func WithCopyrightStartYear(year int) Option {
+	return func(g *Generator) { g.copyrightStartYear = year }
+}
+
+// WithSiteVariables returns an Option that resolves the {{site.name}} placeholders of the pages with vars,
+// e.g. {{site.author}} with vars["author"].
+func WithSiteVariables(vars map[string]string) Option {
+	return func(g *Generator) {
+		if g.siteVariables == nil {
+			g.siteVariables = make(map[string]string, len(vars))
+		}
+		maps.Copy(g.siteVariables, vars)
+	}
+}
+
+// WithSiteVariablesFile returns an Option that reads the site variables from a JSON object of strings,
+// e.g. a site.json with {"title": "My blog", "author": "Me"}, read again on every build.
+// Its variables override the WithSiteVariables ones.
+func WithSiteVariablesFile(path string) Option {
+	return func(g *Generator) { g.siteVariablesFile = path }
+}
+
+// WithStrictSiteVariables returns an Option that fails the build on the {{site.name}} placeholders of unknown variables,
+// which are removed by default.
+func WithStrictSiteVariables(strict bool) Option {
+	return func(g *Generator) { g.strictSiteVariables = strict }
+}
+
+// WithLogger returns an Option that sets the logger reporting the build progress, e.g. the generated files at debug level.
+// By default only warnings and errors are logged, to stderr.
+func WithLogger(logger *slog.Logger) Option {
+	return func(g *Generator) {
+		if logger != nil {
+			g.logger = logger
+		}
+	}
+}
+
+// WithFileSystem returns an Option that sets the filesystem the sources are read from and the build is written to.
+// The OS filesystem is used by default.
+func WithFileSystem(fs filesystem.FileSystem) Option {
+	return func(g *Generator) {
+		if fs != nil {
+			g.fs = fs
+		}
+	}
+}
+
+// WithDryRun returns an Option that runs the build without writing to disk: the generated and copied files
+// are kept in memory, and listed by PlannedFiles after Generate. Sources are still read from disk.
+func WithDryRun(dryRun bool) Option {
+	return func(g *Generator) { g.dryRun = dryRun }
+}
+
+// WithIncremental returns an Option that skips generating pages whose output is newer than their markdown source.
+// Pages are still regenerated when the generator binary, embedding the default page template, the template file,
+// the site variables file, the navigation config file or the class allowlist file is newer than their output;
+// other option changes need a full build.
+func WithIncremental(incremental bool) Option {
+	return func(g *Generator) { g.incremental = incremental }
+}
+
+// WithDateLayout returns an Option that sets the layout used to render the {{date}} placeholder.
+func WithDateLayout(layout string) Option {
+	return func(g *Generator) { g.dateLayout = layout }
+}
+
+// WithLastModLayout returns an Option that sets the layout used to render the {{lastmod}} placeholder,
+// the date of the last commit changing the page, or its modification time outside git.
+func WithLastModLayout(layout string) Option {
+	return func(g *Generator) { g.lastModLayout = layout }
+}
+
+// WithTemplate returns an Option that projects the pages in tmpl instead of the embedded page template,
+// e.g. to load other stylesheets or scripts. Section templates take precedence.
+func WithTemplate(tmpl string) Option {
+	return func(g *Generator) { g.template = tmpl }
+}
+
+// WithTemplateFile returns an Option that reads the page template from a file, read again on every build.
+// It takes precedence over WithTemplate.
+func WithTemplateFile(path string) Option {
+	return func(g *Generator) { g.templateFile = path }
+}
+
+// WithSectionTemplate returns an Option that projects the pages of section, and of its sub-sections,
+// in tmpl instead of the default page template. The home section is "".
+func WithSectionTemplate(section, tmpl string) Option {
+	return func(g *Generator) {
+		if g.sectionTemplates == nil {
+			g.sectionTemplates = make(map[string]string)
+		}
+		g.sectionTemplates[section] = tmpl
+	}
+}
+
+// WithIncludeDrafts returns an Option that builds the pages whose front matter sets draft: true,
+// which are skipped by default. Useful for preview builds.
+func WithIncludeDrafts(include bool) Option {
+	return func(g *Generator) { g.includeDrafts = include }
+}
+
+// WithListingExcerpts returns an Option that shows the excerpt of each article listed by {{list-child-articles}},
+// as resolved by {{excerpt}} on its page, under its link. Articles are listed without excerpt by default.
+func WithListingExcerpts(enabled bool) Option {
+	return func(g *Generator) { g.listingExcerpts = enabled }
+}
+
+// WithNavConfig returns an Option that sets the navigation display names and ordering of sections.
+func WithNavConfig(cfg section.NavConfig) Option {
+	return func(g *Generator) { g.navConfig = cfg }
+}
+
+// WithNavConfigFile returns an Option that reads the navigation configuration from a JSON file, e.g. nav.json.
+// It takes precedence over WithNavConfig.
+func WithNavConfigFile(path string) Option {
+	return func(g *Generator) { g.navConfigFile = path }
+}
+
+// WithExcludeFromNav returns an Option that hides sections, by directory name, from the navigation.
+// Their pages are still generated.
+func WithExcludeFromNav(sections ...string) Option {
+	return func(g *Generator) { g.navExcluded = append(g.navExcluded, sections...) }
+}
+
+// WithNotFoundPage returns an Option that generates 404.html at the build root from the markdown file at markdownPath,
+// with the page template and navigation. Its references are root-relative, so the site is expected at the domain root.
+// No not-found page is generated by default.
+func WithNotFoundPage(markdownPath string) Option {
+	return func(g *Generator) { g.notFoundPage = markdownPath }
+}
+
+// WithHomeLabel returns an Option that sets the label of the home link in the navigation.
+// By default the home page title is used.
+func WithHomeLabel(label string) Option {
+	return func(g *Generator) { g.homeLabel = label }
+}
+
+// WithMaxSectionDepth returns an Option that sets the maximum section nesting depth.
+// Pages nested deeper trigger a warning, or an error in strict mode.
+func WithMaxSectionDepth(depth int) Option {
+	return func(g *Generator) { g.maxSectionDepth = depth }
+}
+
+// WithStrict returns an Option that turns build warnings into errors.
+func WithStrict(strict bool) Option {
+	return func(g *Generator) { g.strict = strict }
+}
+
+// WithStrictValidation returns an Option that fails Validate on validation warnings too.
+// Warnings are only logged by default.
+func WithStrictValidation(strict bool) Option {
+	return func(g *Generator) { g.strictValidation = strict }
+}
+
 func NewGenerator(opts ...Option) (*Generator, error) {
 	g := &Generator{
 		contentDir:           "./content/markdown",
 		buildDir:             "./target/build",
 		assetsDir:            "./content/assets",
-		assetsOutDir:         "./target/build/assets",
+		assetsOutDir:         "assets",
 		scriptsDir:           "./scripts",
-		scriptsOutDir:        "./target/build/scripts",
+		scriptsOutDir:        "scripts",
+		fileMode:             filesystem.DefaultFileMode,
+		dirMode:              filesystem.DefaultDirMode,
+		maxSectionDepth:      defaultMaxSectionDepth,
+		nonMarkdownFiles:     NonMarkdownCopy,
+		pathStrategy:         MirrorPathStrategy{},
+		lazyImages:           true,
+		binaryModTime:        executableModTime(),
+		logger:               defaultLogger(),
 		sections:             make([]section.Section, 0),
 		pagesGenerators:      make([]PageGenerator, 0),
+		pages:                make([]generatedPage, 0),
 		pageGeneratorFactory: defaultPageGeneratorFactory,
 		fs:                   filesystem.NewOSFileSystem(),
+		imageEncoder:         CommandEncoder{},
 	}
 
 	for _, opt := range opts {
 		opt(g)
 	}
 
+	if err := g.checkIgnorePatterns(); err != nil {
+		return nil, err
+	}
+
+	if err := g.checkImageFormats(); err != nil {
+		return nil, err
+	}
+
+	if err := g.checkHomePage(); err != nil {
+		return nil, err
+	}
+
+	if err := g.elementAttributes.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid element attributes: %w", err)
+	}
+
 	return g, nil
 }
 
 func (g *Generator) Generate() error {
+	// Start from a clean state so that a generator can build the site several times
+	g.sections = make([]section.Section, 0)
+	g.pageConfigs = make([]pageConfig, 0)
+	g.pagesGenerators = make([]PageGenerator, 0)
+	g.pages = make([]generatedPage, 0)
+	g.excludedPages = make(map[string]string)
+	g.warnings = nil
+	g.fingerprints = make(map[string]string)
+	g.imageVariants = make(map[string][]imageVariant)
+	g.outputs = make(map[string]bool)
+	// External link results are shared by the pages of one build only
+	g.linkCache = link.NewCache()
+	if dry, ok := g.fs.(*filesystem.DryRunFileSystem); ok {
+		dry.Reset()
+	} else if g.dryRun {
+		g.fs = filesystem.NewDryRunFileSystem(g.fs)
+	}
+
+	if err := g.checkOutputPaths(); err != nil {
+		return fmt.Errorf("invalid output paths: %w", err)
+	}
+
 	if err := g.makeAllDirectories(); err != nil {
 		return fmt.Errorf("failed to create output directories: %w", err)
 	}
 
+	if err := g.loadTemplate(); err != nil {
+		return fmt.Errorf("failed to load page template: %w", err)
+	}
+
+	if err := g.loadClassAllowlist(); err != nil {
+		return fmt.Errorf("failed to load class allowlist: %w", err)
+	}
+
+	if err := g.loadSiteVariables(); err != nil {
+		return fmt.Errorf("failed to load site variables: %w", err)
+	}
+	g.loadConfigModTime()
+	g.converter = g.newConverter()
+
 	if err := g.listSections(); err != nil {
 		return fmt.Errorf("failed to list site sections: %w", err)
 	}
 
+	if err := g.applyNavConfig(); err != nil {
+		return fmt.Errorf("failed to configure navigation: %w", err)
+	}
+
+	if err := g.checkSectionIndexes(); err != nil {
+		return fmt.Errorf("invalid site sections: %w", err)
+	}
+
 	if err := g.copyAssets(); err != nil {
 		return fmt.Errorf("failed to copy assets: %w", err)
 	}
 
+	if err := g.convertImages(); err != nil {
+		return fmt.Errorf("failed to convert images: %w", err)
+	}
+
 	if err := g.copyScripts(); err != nil {
 		return fmt.Errorf("failed to copy scripts: %w", err)
 	}
@@ -82,9 +837,55 @@ func (g *Generator) Generate() error {
 		return fmt.Errorf("failed to generate pages: %w", err)
 	}
 
+	if err := g.checkUnusedAssets(); err != nil {
+		return fmt.Errorf("unused assets: %w", err)
+	}
+
+	if err := g.checkWordCounts(); err != nil {
+		return fmt.Errorf("invalid word counts: %w", err)
+	}
+
+	if err := g.generateFeed(); err != nil {
+		return fmt.Errorf("failed to generate feed: %w", err)
+	}
+
+	if err := g.generateSectionFeeds(); err != nil {
+		return fmt.Errorf("failed to generate section feeds: %w", err)
+	}
+
+	if err := g.generateSitemap(); err != nil {
+		return fmt.Errorf("failed to generate sitemap: %w", err)
+	}
+
+	if err := g.generateRobots(); err != nil {
+		return fmt.Errorf("failed to generate robots.txt: %w", err)
+	}
+
+	if err := g.generateSearchIndex(); err != nil {
+		return fmt.Errorf("failed to generate search index: %w", err)
+	}
+
+	if err := g.generateManifest(); err != nil {
+		return fmt.Errorf("failed to generate manifest: %w", err)
+	}
+
+	for _, path := range g.PlannedFiles() {
+		g.logger.Info("would write", "output", path)
+	}
+
 	return nil
 }
 
+// PlannedFiles returns the sorted paths of the files the last dry run would have written,
+// or nil when the generator is not in dry-run mode.
+func (g *Generator) PlannedFiles() []string {
+	dry, ok := g.fs.(*filesystem.DryRunFileSystem)
+	if !ok {
+		return nil
+	}
+	return dry.Written()
+}
+
 func (g *Generator) Validate() error {
 	errs := make([]error, 0)
 	for _, pg := range g.pagesGenerators {
@@ -93,9 +894,7 @@ func (g *Generator) Validate() error {
 		}
 	}
 
-	if len(errs) > 0 {
-		return errors.Join(errs...)
-	}
-
-	return nil
+	err := errors.Join(errs...)
+	g.report = newBuildReport(err)
+	return err
 }
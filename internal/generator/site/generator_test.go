@@ -1,13 +1,27 @@
 package site
 
 import (
+	"bytes"
 	"fmt"
+	"image"
+	"image/png"
+	"log/slog"
+	"maps"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/tjnvr/blog/internal/generator/page/filesystem"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/noscript"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/link"
+	"github.com/tjnvr/blog/internal/generator/page/markdown"
 	"github.com/tjnvr/blog/internal/generator/section"
 )
 
@@ -62,6 +76,21 @@ func createTestGenerator(contentDir, buildDir string) *Generator {
 	return g
 }
 
+// newIntegrationTestGenerator creates a generator over files with empty assets and scripts directories
+func newIntegrationTestGenerator(t *testing.T, files map[string]string, opts ...Option) (*Generator, string) {
+	t.Helper()
+	contentDir, buildDir := setupTestContent(t, files)
+	gen := createTestGenerator(contentDir, buildDir).
+		withAssetsDir(filepath.Join(t.TempDir(), "empty-assets")).
+		withScriptsDir(filepath.Join(t.TempDir(), "empty-scripts"))
+	for _, opt := range opts {
+		opt(gen)
+	}
+	_ = os.MkdirAll(gen.assetsDir, 0755)
+	_ = os.MkdirAll(gen.scriptsDir, 0755)
+	return gen, buildDir
+}
+
 func TestNewGenerator(t *testing.T) {
 	g, err := NewGenerator()
 	assert.Nil(t, err)
@@ -105,14 +134,14 @@ func TestWithBuilders(t *testing.T) {
 
 func TestWithPageGeneratorFactory(t *testing.T) {
 	called := false
-	factory := func(markdownPath, htmlOutPath, buildDir, pageSection string, assetsPathTranslater, linksPathTranslater newPathResolver, sections []section.Section, skipURLValidation bool) PageGenerator {
+	factory := func(cfg pageConfig) PageGenerator {
 		called = true
 		return &fakePageGenerator{}
 	}
 
 	g, _ := NewGenerator()
 	g.withPageGeneratorFactory(factory)
-	g.pageGeneratorFactory("test.md", "test.html", "/build", "", newPathResolver{}, newPathResolver{}, nil, false)
+	g.pageGeneratorFactory(pageConfig{sourceMDPath: "test.md", destinationHTMLPath: "test.html", buildDir: "/build"})
 
 	if !called {
 		t.Error("custom factory should have been called")
@@ -184,6 +213,10 @@ func (f *fakePageGenerator) Generate() error {
 	return f.generateErr
 }
 
+func (f *fakePageGenerator) Load() error {
+	return nil
+}
+
 func (f *fakePageGenerator) Validate() error {
 	f.validated = true
 	return f.validateErr
@@ -261,6 +294,111 @@ func TestListSections_FallbackToDirectoryName(t *testing.T) {
 	}
 }
 
+func TestListSections_NestedSections(t *testing.T) {
+	contentDir, _ := setupTestContent(t, map[string]string{
+		"index.md":           "# Accueil\n",
+		"blog/index.md":      "# Blog\n",
+		"blog/2024/index.md": "# Year 2024\n",
+		"blog/2025/index.md": "Untitled.\n",
+		// blog/images/ has no index page, it is not a section
+		"blog/images/photo.png": "png",
+	})
+
+	g, _ := NewGenerator()
+	g.withContentDir(contentDir)
+
+	assert.NoError(t, g.listSections())
+	assert.Equal(t, []section.Section{
+		{DirName: "", DisplayName: "Accueil"},
+		{DirName: "blog", DisplayName: "Blog", Children: []section.Section{
+			{DirName: "blog/2024", DisplayName: "Year 2024"},
+			{DirName: "blog/2025", DisplayName: "2025"},
+		}},
+	}, g.sections)
+}
+
+// reversedWalkFileSystem walks the directories of its file system listing their entries in reverse order.
+type reversedWalkFileSystem struct {
+	filesystem.FileSystem
+}
+
+func (r reversedWalkFileSystem) Walk(root string, fn filepath.WalkFunc) error {
+	type entry struct {
+		path string
+		info os.FileInfo
+		err  error
+	}
+	var entries []entry
+	if err := r.FileSystem.Walk(root, func(path string, info os.FileInfo, err error) error {
+		entries = append(entries, entry{path, info, err})
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	// Parents still come before their entries, siblings are listed in reverse order
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := strings.Split(entries[i].path, "/"), strings.Split(entries[j].path, "/")
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] > b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	skipped := ""
+	for _, e := range entries {
+		if skipped != "" && strings.HasPrefix(e.path, skipped+"/") {
+			continue
+		}
+		err := fn(e.path, e.info, e.err)
+		switch {
+		case err == filepath.SkipDir && e.info != nil && e.info.IsDir():
+			skipped = e.path
+		case err == filepath.SkipAll:
+			return nil
+		case err != nil && err != filepath.SkipDir:
+			return err
+		}
+	}
+	return nil
+}
+
+func TestListSections_SortedWhateverTheWalkOrder(t *testing.T) {
+	fs := filesystem.NewMemoryFileSystem()
+	fs.AddFile("/content/index.md", []byte("# Home\n"))
+	fs.AddFile("/content/about/index.md", []byte("# About\n"))
+	fs.AddFile("/content/posts/index.md", []byte("# Posts\n"))
+	fs.AddFile("/content/posts/2024/index.md", []byte("# 2024\n"))
+	fs.AddFile("/content/posts/2025/index.md", []byte("# 2025\n"))
+	fs.AddFile("/content/posts/2025/first.md", []byte("# First\n"))
+	fs.AddFile("/assets/logo.png", []byte("png"))
+	fs.AddFile("/scripts/app.js", []byte("console.log('app')"))
+
+	gen, err := NewGenerator(WithFileSystem(reversedWalkFileSystem{fs}))
+	assert.NoError(t, err)
+	gen.withContentDir("/content").
+		withBuildDir("/build").
+		withAssetsDir("/assets").
+		withScriptsDir("/scripts")
+
+	assert.NoError(t, gen.Generate())
+	assert.Equal(t, []section.Section{
+		{DirName: "", DisplayName: "Home"},
+		{DirName: "about", DisplayName: "About"},
+		{DirName: "posts", DisplayName: "Posts", Children: []section.Section{
+			{DirName: "posts/2024", DisplayName: "2024"},
+			{DirName: "posts/2025", DisplayName: "2025"},
+		}},
+	}, gen.sections)
+
+	home, ok := fs.GetFile("/build/index.html")
+	assert.True(t, ok)
+	about := strings.Index(string(home), `href="about/index.html"`)
+	posts := strings.Index(string(home), `href="posts/index.html"`)
+	assert.True(t, about >= 0 && posts > about, "navigation links should be sorted, got %s", home)
+}
+
 func TestGenerate_NonExistentContentDirError(t *testing.T) {
 	buildDir := t.TempDir()
 	gen := createTestGenerator("/nonexistent/content", buildDir).
@@ -282,6 +420,7 @@ func TestGenerate_NonMdFilesReportError(t *testing.T) {
 	gen := createTestGenerator(contentDir, buildDir).
 		withAssetsDir(filepath.Join(t.TempDir(), "empty-assets")).
 		withScriptsDir(filepath.Join(t.TempDir(), "empty-scripts"))
+	WithNonMarkdownFiles(NonMarkdownError)(gen)
 
 	// Create empty assets/scripts dirs so copyDir doesn't fail
 	_ = os.MkdirAll(gen.assetsDir, 0755)
@@ -301,7 +440,7 @@ func TestGenerate_PageGeneratorError(t *testing.T) {
 		"index.md": "# Title",
 	})
 
-	factory := func(markdownPath, htmlOutPath, buildDir, pageSection string, assetsPathTranslater, linksPathTranslater newPathResolver, sections []section.Section, skipURLValidation bool) PageGenerator {
+	factory := func(cfg pageConfig) PageGenerator {
 		return &fakePageGenerator{generateErr: fmt.Errorf("page generation failed")}
 	}
 
@@ -321,12 +460,30 @@ func TestGenerate_PageGeneratorError(t *testing.T) {
 	}
 }
 
+func TestGenerate_ReportsEveryBrokenPage(t *testing.T) {
+	gen, _ := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":  "# Home\n",
+		"first.md":  "---\ntitle: [\n---\n# First\n",
+		"second.md": "---\ndate: [\n---\n# Second\n",
+	}, WithSkipURLValidation(true))
+
+	err := gen.Generate()
+	if err == nil {
+		t.Fatal("expected error for broken pages")
+	}
+	for _, page := range []string{"first.md", "second.md"} {
+		if !strings.Contains(err.Error(), "generating "+filepath.Join(gen.contentDir, page)) {
+			t.Errorf("error should identify %s, got %q", page, err.Error())
+		}
+	}
+}
+
 func TestValidate_ValidationError(t *testing.T) {
 	contentDir, buildDir := setupTestContent(t, map[string]string{
 		"index.md": "# Title",
 	})
 
-	factory := func(markdownPath, htmlOutPath, buildDir, pageSection string, assetsPathTranslater, linksPathTranslater newPathResolver, sections []section.Section, skipURLValidation bool) PageGenerator {
+	factory := func(cfg pageConfig) PageGenerator {
 		return &fakePageGenerator{validateErr: fmt.Errorf("validation failed")}
 	}
 
@@ -802,3 +959,1827 @@ func TestIntegration_AssetPathConversion(t *testing.T) {
 		t.Errorf("expected src=\"../assets/images/photo.png\" in output, got:\n%s", postHTML)
 	}
 }
+
+func TestGenerate_SectionDepth(t *testing.T) {
+	tests := []struct {
+		name         string
+		strict       bool
+		pagePath     string
+		wantErr      bool
+		wantWarnings int
+	}{
+		{
+			name:     "page within the depth limit",
+			pagePath: "a/b/page.md",
+		},
+		{
+			name:         "page beyond the depth limit is warned",
+			pagePath:     "a/b/c/page.md",
+			wantWarnings: 1,
+		},
+		{
+			name:     "page beyond the depth limit errors in strict mode",
+			strict:   true,
+			pagePath: "a/b/c/page.md",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir, buildDir := setupTestContent(t, map[string]string{
+				"index.md":   "# Home\n",
+				"a/index.md": "# A\n",
+				tt.pagePath:  "# Deep page\n",
+			})
+
+			gen := createTestGenerator(contentDir, buildDir).
+				withAssetsDir(filepath.Join(t.TempDir(), "empty-assets")).
+				withScriptsDir(filepath.Join(t.TempDir(), "empty-scripts"))
+			WithMaxSectionDepth(2)(gen)
+			WithStrict(tt.strict)(gen)
+			_ = os.MkdirAll(gen.assetsDir, 0755)
+			_ = os.MkdirAll(gen.scriptsDir, 0755)
+
+			err := gen.Generate()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected error for page nested too deep")
+				}
+				if !strings.Contains(err.Error(), "sections deep") {
+					t.Errorf("error should mention nesting depth, got %q", err.Error())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+			if len(gen.warnings) != tt.wantWarnings {
+				t.Errorf("expected %d warnings, got %d: %v", tt.wantWarnings, len(gen.warnings), gen.warnings)
+			}
+			want := filepath.Join(buildDir, strings.TrimSuffix(tt.pagePath, ".md")+".html")
+			if _, err := os.Stat(want); err != nil {
+				t.Errorf("expected %s to be generated: %v", want, err)
+			}
+		})
+	}
+}
+
+func TestSectionDepth(t *testing.T) {
+	for section, want := range map[string]int{"": 0, "posts": 1, "blog/2024": 2, "a/b/c": 3} {
+		if got := sectionDepth(section); got != want {
+			t.Errorf("sectionDepth(%q) = %d, want %d", section, got, want)
+		}
+	}
+}
+
+func TestIntegration_NoscriptFallback(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      []Option
+		wantFound bool
+	}{
+		{
+			name: "no fallback by default",
+		},
+		{
+			name:      "dark mode fallback when enabled",
+			opts:      []Option{WithNoscriptFallbacks(noscript.DarkMode)},
+			wantFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir, buildDir := setupTestContent(t, map[string]string{
+				"index.md": "# Home\n",
+			})
+
+			gen := createTestGenerator(contentDir, buildDir).
+				withAssetsDir(filepath.Join(t.TempDir(), "empty-assets")).
+				withScriptsDir(filepath.Join(t.TempDir(), "empty-scripts"))
+			for _, opt := range tt.opts {
+				opt(gen)
+			}
+			_ = os.MkdirAll(gen.assetsDir, 0755)
+			_ = os.MkdirAll(gen.scriptsDir, 0755)
+
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			content, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+			if err != nil {
+				t.Fatalf("failed to read index.html: %v", err)
+			}
+			html := string(content)
+			if strings.Contains(html, "{{noscript}}") {
+				t.Error("{{noscript}} placeholder should have been replaced")
+			}
+			if got := strings.Contains(html, "<noscript><style>.theme-toggle{display:none}</style></noscript>"); got != tt.wantFound {
+				t.Errorf("noscript fallback present = %v, want %v", got, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestGenerate_SectionIndexCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]string
+		wantErr string
+	}{
+		{
+			name: "every section has an index page",
+			files: map[string]string{
+				"index.md":       "# Home\n",
+				"posts/index.md": "# Posts\n",
+				"posts/first.md": "# First\n",
+			},
+		},
+		{
+			name: "section without index page",
+			files: map[string]string{
+				"index.md":       "# Home\n",
+				"posts/index.md": "# Posts\n",
+				"about/me.md":    "# Me\n",
+			},
+			wantErr: `section "about" has no index page`,
+		},
+		{
+			name: "home without index page",
+			files: map[string]string{
+				"page.md": "# Page\n",
+			},
+			wantErr: `section "home" has no index page`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir, buildDir := setupTestContent(t, tt.files)
+			factory := func(cfg pageConfig) PageGenerator {
+				return &fakePageGenerator{}
+			}
+			gen := createTestGenerator(contentDir, buildDir).
+				withPageGeneratorFactory(factory).
+				withAssetsDir(filepath.Join(t.TempDir(), "empty-assets")).
+				withScriptsDir(filepath.Join(t.TempDir(), "empty-scripts"))
+			_ = os.MkdirAll(gen.assetsDir, 0755)
+			_ = os.MkdirAll(gen.scriptsDir, 0755)
+
+			err := gen.Generate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Generate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("expected error for section without index page")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("error should contain %q, got %q", tt.wantErr, err.Error())
+			}
+			if len(gen.pagesGenerators) != 0 {
+				t.Error("no page should be generated when a section index is missing")
+			}
+		})
+	}
+}
+
+func TestIntegration_DateFromFrontmatter(t *testing.T) {
+	contentDir, buildDir := setupTestContent(t, map[string]string{
+		// The default template does not render the date, the placeholder is used in the page body instead
+		"index.md": "---\ndate: 2026-01-24\n---\n# Home\n\nPublished {{date}}\n",
+	})
+
+	gen := createTestGenerator(contentDir, buildDir).
+		withAssetsDir(filepath.Join(t.TempDir(), "empty-assets")).
+		withScriptsDir(filepath.Join(t.TempDir(), "empty-scripts"))
+	WithDateLayout("02 Jan 2006")(gen)
+	_ = os.MkdirAll(gen.assetsDir, 0755)
+	_ = os.MkdirAll(gen.scriptsDir, 0755)
+
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	if err != nil {
+		t.Fatalf("failed to read index.html: %v", err)
+	}
+	if !strings.Contains(string(content), "Published 24 Jan 2026") {
+		t.Errorf("expected formatted front matter date, got:\n%s", content)
+	}
+}
+
+func TestGenerate_LinkCachePerRun(t *testing.T) {
+	gen, _ := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+	})
+
+	caches := make([]*link.Cache, 0)
+	factory := func(cfg pageConfig) PageGenerator {
+		caches = append(caches, cfg.linkCache)
+		return &fakePageGenerator{}
+	}
+	gen.withPageGeneratorFactory(factory)
+
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Generate())
+
+	assert.Len(t, caches, 4)
+	assert.NotNil(t, caches[0])
+	assert.Same(t, caches[0], caches[1], "pages of one build should share the link cache")
+	assert.NotSame(t, caches[0], caches[2], "separate builds should not share the link cache")
+}
+
+func TestIntegration_SectionTemplate(t *testing.T) {
+	postTemplate := `<html><body class="post-layout"><nav>{{navigation}}</nav><article>{{content}}</article></body></html>`
+
+	tests := []struct {
+		name        string
+		opts        []Option
+		page        string
+		contains    string
+		notContains string
+	}{
+		{
+			name:     "posts page gets the post template",
+			opts:     []Option{WithSectionTemplate("posts", postTemplate)},
+			page:     "posts/first.html",
+			contains: `<body class="post-layout">`,
+		},
+		{
+			name:     "nested section page gets its parent section template",
+			opts:     []Option{WithSectionTemplate("posts", postTemplate)},
+			page:     "posts/2024/recap.html",
+			contains: `<body class="post-layout">`,
+		},
+		{
+			name:        "root page gets the default template",
+			opts:        []Option{WithSectionTemplate("posts", postTemplate)},
+			page:        "index.html",
+			contains:    "<!DOCTYPE html>",
+			notContains: "post-layout",
+		},
+		{
+			name:        "home template does not apply to sections",
+			opts:        []Option{WithSectionTemplate("", postTemplate)},
+			page:        "posts/first.html",
+			contains:    "<!DOCTYPE html>",
+			notContains: "post-layout",
+		},
+		{
+			name:     "no section template keeps the default template",
+			page:     "posts/first.html",
+			contains: "<!DOCTYPE html>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+				"index.md":            "# Home",
+				"posts/index.md":      "# Posts",
+				"posts/first.md":      "# First\n\nHello.",
+				"posts/2024/index.md": "# 2024",
+				"posts/2024/recap.md": "# Recap",
+			}, tt.opts...)
+
+			if err := gen.Generate(); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			output, err := os.ReadFile(filepath.Join(buildDir, tt.page))
+			if err != nil {
+				t.Fatalf("failed to read %s: %v", tt.page, err)
+			}
+			html := string(output)
+			assert.Contains(t, html, tt.contains)
+			if tt.notContains != "" {
+				assert.NotContains(t, html, tt.notContains)
+			}
+			assert.NotContains(t, html, "{{", "placeholders should all be resolved")
+		})
+	}
+}
+
+func TestIntegration_ElementAttributes(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home\n\n![Photo](photo.png)\n",
+	}, WithElementAttributes(markdown.ElementAttributes{"img": {"class": "rounded"}}), WithSkipURLValidation(true))
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `class="rounded">`)
+
+	_, err = NewGenerator(WithElementAttributes(markdown.ElementAttributes{"image": {"loading": "lazy"}}))
+	assert.ErrorContains(t, err, `invalid element attributes: attributes of unknown element "image"`)
+}
+
+func TestIntegration_LazyImages(t *testing.T) {
+	files := map[string]string{"index.md": "# Home\n\n![Hero](hero.png){loading=eager}\n\n![Photo](photo.png)\n"}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithSkipURLValidation(true))
+	assert.NoError(t, gen.Generate())
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<img src="photo.png" alt="Photo" decoding="async" loading="lazy">`)
+	assert.Contains(t, string(output), `<img src="hero.png" alt="Hero" decoding="async" loading="eager">`)
+
+	gen, buildDir = newIntegrationTestGenerator(t, files, WithLazyImages(false), WithSkipURLValidation(true))
+	assert.NoError(t, gen.Generate())
+	output, err = os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<img src="photo.png" alt="Photo">`)
+}
+
+func TestIntegration_ExternalLinks(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Home\n\n[Go](https://go.dev) [About](https://example.com/about.html) [Posts](posts/index.md)\n",
+		"posts/index.md": "# Posts",
+	}, WithBaseURL("https://example.com"), WithExternalLinks(true), WithSkipURLValidation(true))
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<a href="https://go.dev" rel="noopener noreferrer" target="_blank">Go</a>`)
+	assert.Contains(t, string(output), `<a href="https://example.com/about.html">About</a>`)
+	assert.Contains(t, string(output), `<a href="posts/index.html">Posts</a>`)
+}
+
+func TestIntegration_Template(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+	}
+	siteTemplate := `<html><head><link href="/local.css" rel="stylesheet"></head><body>{{navigation}}{{content}}</body></html>`
+
+	t.Run("site template applies to every page", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithTemplate(siteTemplate))
+		assert.NoError(t, gen.Generate())
+
+		for _, page := range []string{"index.html", "posts/index.html"} {
+			output, err := os.ReadFile(filepath.Join(buildDir, page))
+			assert.NoError(t, err)
+			assert.Contains(t, string(output), `<link href="/local.css" rel="stylesheet">`)
+		}
+	})
+
+	t.Run("section template takes precedence", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithTemplate(siteTemplate),
+			WithSectionTemplate("posts", `<html><body class="post-layout">{{navigation}}{{content}}</body></html>`))
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), "post-layout")
+	})
+
+	t.Run("template file", func(t *testing.T) {
+		templateFile := filepath.Join(t.TempDir(), "page.html")
+		assert.NoError(t, os.WriteFile(templateFile, []byte(siteTemplate), 0644))
+
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithTemplate("{{content}}"), WithTemplateFile(templateFile))
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), `<link href="/local.css" rel="stylesheet">`)
+	})
+
+	t.Run("missing template file", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithTemplateFile(filepath.Join(t.TempDir(), "missing.html")))
+		assert.ErrorContains(t, gen.Generate(), "failed to load page template")
+	})
+}
+
+func TestIntegration_Drafts(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts\n\n{{list-child-articles}}",
+		"posts/first.md": "# First\n\nPublished.",
+		"posts/draft.md": "---\ndraft: true\n---\n# Draft\n\nNot ready.",
+	}
+
+	t.Run("drafts are skipped by default", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files,
+			WithSitemap(true), WithFeed("posts", "rss.xml", FeedRSS), WithBaseURL("https://example.org"))
+
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+
+		assert.NoFileExists(t, filepath.Join(buildDir, "posts", "draft.html"))
+		assert.FileExists(t, filepath.Join(buildDir, "posts", "first.html"))
+
+		for _, name := range []string{"sitemap.xml", "rss.xml", filepath.Join("posts", "index.html")} {
+			output, err := os.ReadFile(filepath.Join(buildDir, name))
+			assert.NoError(t, err)
+			assert.NotContains(t, string(output), "draft", "%s should not reference the draft", name)
+		}
+	})
+
+	t.Run("drafts are built when included", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithIncludeDrafts(true), WithSitemap(true))
+
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+
+		assert.FileExists(t, filepath.Join(buildDir, "posts", "draft.html"))
+		index, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(index), `href="draft.html"`)
+		sitemap, err := os.ReadFile(filepath.Join(buildDir, "sitemap.xml"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(sitemap), "posts/draft.html")
+	})
+}
+
+func TestIntegration_DraftSection(t *testing.T) {
+	files := map[string]string{
+		"index.md":            "# Home",
+		"posts/index.md":      "# Posts",
+		"posts/first.md":      "# First\n\nPublished.",
+		"wip/index.md":        "---\ndraft: true\n---\n# Work in progress",
+		"posts/next/index.md": "---\ndraft: true\n---\n# Next",
+	}
+
+	t.Run("draft section indexes are not listed", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files)
+
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+
+		assert.NoFileExists(t, filepath.Join(buildDir, "wip", "index.html"))
+		assert.False(t, section.Contains(gen.sections, "wip"))
+		assert.False(t, section.Contains(gen.sections, "posts/next"))
+		output, err := os.ReadFile(filepath.Join(buildDir, "posts", "first.html"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(output), "wip/index.html")
+		assert.NotContains(t, string(output), "next/index.html")
+	})
+
+	t.Run("draft sections are listed when drafts are included", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithIncludeDrafts(true))
+
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+
+		assert.FileExists(t, filepath.Join(buildDir, "wip", "index.html"))
+		assert.True(t, section.Contains(gen.sections, "wip"))
+		assert.True(t, section.Contains(gen.sections, "posts/next"))
+	})
+}
+
+func TestIntegration_NavConfig(t *testing.T) {
+	files := map[string]string{
+		"index.md":          "# Accueil",
+		"a-propos/index.md": "---\ntitle: Qui suis-je ?\n---\nBonjour.",
+		"posts/index.md":    "# Posts",
+		"projets/index.md":  "# Projets",
+	}
+
+	t.Run("configured names and order", func(t *testing.T) {
+		navFile := filepath.Join(t.TempDir(), "nav.json")
+		assert.NoError(t, os.WriteFile(navFile, []byte(`{"order": ["", "posts"], "display_names": {"a-propos": "À propos"}}`), 0644))
+
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithNavConfigFile(navFile))
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate(), "navigation validator should accept the configured display names")
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+		assert.NoError(t, err)
+		html := string(output)
+
+		home := strings.Index(html, ">Accueil</a>")
+		posts := strings.Index(html, ">Posts</a>")
+		about := strings.Index(html, ">À propos</a>")
+		projects := strings.Index(html, ">Projets</a>")
+		assert.True(t, home >= 0 && posts > home && about > posts && projects > about,
+			"expected configured sections first then the others in directory order, got %q", html)
+	})
+
+	t.Run("sections fall back to their capitalized directory name", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithNavConfig(section.NavConfig{Order: []string{"projets"}}))
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), ">A-propos</a>")
+	})
+
+	t.Run("malformed config file", func(t *testing.T) {
+		navFile := filepath.Join(t.TempDir(), "nav.json")
+		assert.NoError(t, os.WriteFile(navFile, []byte(`{"order": "posts"}`), 0644))
+
+		gen, _ := newIntegrationTestGenerator(t, files, WithNavConfigFile(navFile))
+		assert.ErrorContains(t, gen.Generate(), "malformed navigation config")
+	})
+
+	t.Run("unknown ordered section errors in strict mode", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithStrict(true), WithNavConfig(section.NavConfig{Order: []string{"blog"}}))
+		assert.ErrorContains(t, gen.Generate(), `unknown section "blog"`)
+	})
+}
+
+func TestIntegration_ExcludeFromNav(t *testing.T) {
+	files := map[string]string{
+		"index.md":         "# Home",
+		"posts/index.md":   "# Posts",
+		"legal/index.md":   "# Legal",
+		"legal/privacy.md": "# Privacy",
+	}
+
+	t.Run("hidden section is generated without navigation link", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithExcludeFromNav("legal"))
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate(), "navigation validator should not expect links to hidden sections")
+
+		privacy, err := os.ReadFile(filepath.Join(buildDir, "legal", "privacy.html"))
+		assert.NoError(t, err)
+		nav, _, _ := strings.Cut(string(privacy), "</nav>")
+		assert.Contains(t, nav, ">Posts</a>")
+		assert.NotContains(t, nav, "legal/index.html")
+
+		home, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(home), ">Legal</a>")
+	})
+
+	t.Run("unknown excluded section errors in strict mode", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithStrict(true), WithExcludeFromNav("drafts"))
+		assert.ErrorContains(t, gen.Generate(), `navigation exclusion references unknown section "drafts"`)
+	})
+}
+
+func TestIntegration_NavigationActiveClass(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "# First",
+	},
+		WithTemplate("<html><body>{{navigation}}{{content}}</body></html>"),
+		WithNavigationActiveClass("active"),
+		WithClassAllowlist("bg-white"),
+	)
+	assert.NoError(t, gen.Generate())
+	// The active class is allowed, the other classes of the navigation are not
+	err := gen.Validate()
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), `unknown class "active"`)
+
+	tests := []struct {
+		page     string
+		active   string
+		inactive string
+	}{
+		{"index.html", `<a href="index.html" aria-current="page" class="active">Home</a>`, `<a href="posts/index.html" class="hover:underline">Posts</a>`},
+		{"posts/index.html", `<a href="../posts/index.html" aria-current="page" class="active">Posts</a>`, `<a href="../index.html" class="hover:underline">Home</a>`},
+		{"posts/first.html", `<a href="../posts/index.html" aria-current="true" class="active">Posts</a>`, `<a href="../index.html" class="hover:underline">Home</a>`},
+	}
+	for _, tt := range tests {
+		output, err := os.ReadFile(filepath.Join(buildDir, tt.page))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), tt.active, tt.page)
+		assert.Contains(t, string(output), tt.inactive, tt.page)
+	}
+}
+
+func TestIntegration_BodyClass(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "# First",
+	},
+		WithTemplate(`<html><body class="{{body_class}}">{{navigation}}{{content}}</body></html>`),
+		WithClassAllowlist("bg-white"),
+	)
+	assert.NoError(t, gen.Generate())
+
+	for page, want := range map[string]string{
+		"index.html":       `<body class="section-home">`,
+		"posts/index.html": `<body class="section-posts">`,
+		"posts/first.html": `<body class="section-posts">`,
+	} {
+		output, err := os.ReadFile(filepath.Join(buildDir, page))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), want, page)
+	}
+
+	// The section classes are allowed, the other classes of the navigation are not
+	for _, err := range []error{gen.Validate(), gen.ValidateBuild(buildDir)} {
+		assert.ErrorContains(t, err, `unknown class "flex"`)
+		assert.NotContains(t, err.Error(), `unknown class "section-`)
+	}
+}
+
+func TestIntegration_NestedSections(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":           "# Home",
+		"blog/index.md":      "# Blog",
+		"blog/2024/index.md": "# 2024",
+		"blog/2024/post.md":  "# Post",
+	}, WithSkipURLValidation(true))
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate(), "navigation validator should accept the nested menu")
+
+	post, err := os.ReadFile(filepath.Join(buildDir, "blog", "2024", "post.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(post), `href="../../blog/index.html"`)
+	assert.Contains(t, string(post), `<a href="../../blog/2024/index.html" aria-current="true" class="font-semibold underline">2024</a>`)
+}
+
+func TestIntegration_HomeLabel(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Accueil",
+		"posts/index.md": "# Posts",
+	}, WithHomeLabel("Home"))
+
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<a href="../index.html" class="hover:underline">Home</a>`)
+}
+
+func TestIntegration_OpenGraph(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n\nWelcome home.",
+		"posts/index.md": "---\ndescription: All my posts\n---\n# Posts",
+	}
+
+	t.Run("absolute urls with a base url", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files,
+			WithBaseURL("https://example.org/"), WithDefaultImage("/assets/images/logo.png"))
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+		assert.NoError(t, err)
+		html := string(output)
+		assert.Contains(t, html, `<meta property="og:title" content="Posts">`)
+		assert.Contains(t, html, `<meta property="og:description" content="All my posts">`)
+		assert.Contains(t, html, `<meta name="description" content="All my posts">`)
+		assert.Contains(t, html, `<meta property="og:url" content="https://example.org/posts/">`)
+		assert.Contains(t, html, `<meta property="og:image" content="https://example.org/assets/images/logo.png">`)
+		assert.Contains(t, html, `<meta name="twitter:card" content="summary_large_image">`)
+	})
+
+	t.Run("no image tag without base url", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithDefaultImage("/assets/images/logo.png"))
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+		assert.NoError(t, err)
+		html := string(output)
+		assert.Contains(t, html, `<meta property="og:description" content="Welcome home.">`)
+		assert.NotContains(t, html, "og:image")
+		assert.NotContains(t, html, "{{og_meta}}")
+	})
+}
+
+func TestIntegration_Canonical(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "# First",
+	}
+
+	t.Run("absolute urls with a base url", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithBaseURL("https://example.org/"))
+		assert.NoError(t, gen.Generate())
+
+		for page, canonical := range map[string]string{
+			"index.html":       "https://example.org/",
+			"posts/index.html": "https://example.org/posts/",
+			"posts/first.html": "https://example.org/posts/first.html",
+		} {
+			output, err := os.ReadFile(filepath.Join(buildDir, page))
+			assert.NoError(t, err)
+			assert.Contains(t, string(output), `<link rel="canonical" href="`+canonical+`">`)
+		}
+	})
+
+	t.Run("no canonical link without base url", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files)
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "posts", "first.html"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(output), "canonical")
+	})
+}
+
+func TestIntegration_SyntaxTheme(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home\n\n```go\nfunc main() {}\n```",
+	}, WithSyntaxTheme("monokai"))
+
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<code class="language-go">`)
+	assert.Contains(t, string(output), "background-color:#272822")
+}
+
+func TestIntegration_Minify(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home\n\nWelcome home.",
+	}, WithMinify(true))
+
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<title>Home</title>`)
+	assert.NotContains(t, string(output), "\n    <meta")
+}
+
+func TestIntegration_Fingerprint(t *testing.T) {
+	rootDir := t.TempDir()
+	contentDir := filepath.Join(rootDir, "content", "markdown")
+	assetsDir := filepath.Join(rootDir, "content", "assets")
+	scriptsDir := filepath.Join(rootDir, "scripts")
+	buildDir := filepath.Join(rootDir, "target", "build")
+
+	for path, content := range map[string]string{
+		filepath.Join(contentDir, "index.md"):             "# Home\n\nWelcome.\n",
+		filepath.Join(contentDir, "posts/index.md"):       "# Posts\n",
+		filepath.Join(contentDir, "posts/second-post.md"): "# Second Post\n\n![Image](../../assets/images/photo.png)\n",
+		filepath.Join(assetsDir, "images/photo.png"):      "fake png data",
+		filepath.Join(assetsDir, "unused.txt"):            "not referenced",
+		filepath.Join(scriptsDir, "dark-mode.js"):         "setTheme()",
+	} {
+		_ = os.MkdirAll(filepath.Dir(path), 0755)
+		_ = os.WriteFile(path, []byte(content), 0644)
+	}
+
+	gen := createTestGenerator(contentDir, buildDir).
+		withAssetsDir(assetsDir).
+		withScriptsDir(scriptsDir)
+	WithFingerprint(true)(gen)
+
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate())
+
+	photo := fingerprintName("photo.png", []byte("fake png data"))
+	script := fingerprintName("dark-mode.js", []byte("setTheme()"))
+	assert.Regexp(t, `^photo\.[0-9a-f]{8}\.png$`, photo)
+
+	for _, path := range []string{"assets/images/" + photo, "assets/" + fingerprintName("unused.txt", []byte("not referenced")), "scripts/" + script} {
+		assert.FileExists(t, filepath.Join(buildDir, path))
+	}
+	assert.NoFileExists(t, filepath.Join(buildDir, "assets/images/photo.png"))
+	assert.NoFileExists(t, filepath.Join(buildDir, "assets/unused.txt"))
+
+	post, err := os.ReadFile(filepath.Join(buildDir, "posts/second-post.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(post), `src="../assets/images/`+photo+`"`)
+	assert.Contains(t, string(post), `<script src="/scripts/`+script+`"></script>`)
+
+	// Files which were not copied keep their reference
+	assert.Contains(t, string(post), `href="/assets/images/favicon.svg"`)
+}
+
+func TestIntegration_Ignore(t *testing.T) {
+	files := map[string]string{
+		"index.md":          "# Home",
+		"posts/index.md":    "# Posts",
+		"posts/notes.txt":   "not a page",
+		"_drafts/idea.md":   "# Idea",
+		"_drafts/index.md":  "# Drafts",
+		"posts/picture.png": "not a page either",
+	}
+
+	t.Run("non markdown files fail the generation in strict mode", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithIgnore("_drafts/", "*.txt"), WithNonMarkdownFiles(NonMarkdownError))
+		err := gen.Generate()
+		assert.ErrorContains(t, err, "wrong extension")
+		assert.ErrorContains(t, err, "picture.png")
+		assert.NotContains(t, err.Error(), "notes.txt")
+	})
+
+	t.Run("ignored files and non markdown files are skipped", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithIgnore("_drafts/", "*.txt"), WithNonMarkdownFiles(NonMarkdownIgnore))
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+
+		assert.FileExists(t, filepath.Join(buildDir, "posts", "index.html"))
+		assert.NoDirExists(t, filepath.Join(buildDir, "_drafts"))
+		assert.NoFileExists(t, filepath.Join(buildDir, "posts", "notes.html"))
+		assert.NoFileExists(t, filepath.Join(buildDir, "posts", "picture.png"))
+		for _, s := range gen.sections {
+			assert.NotEqual(t, "_drafts", s.DirName)
+		}
+	})
+}
+
+func TestIntegration_ColocatedFiles(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":          "# Home",
+		"posts/index.md":    "# Posts",
+		"posts/post.md":     "# Post\n\n![Photo](photo.png)\n",
+		"posts/photo.png":   "fake png data",
+		"posts/notes.txt":   "ignored",
+		"posts/index.md.bk": "copied too",
+	}, WithIgnore("*.txt"))
+
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate())
+
+	photo, err := os.ReadFile(filepath.Join(buildDir, "posts", "photo.png"))
+	assert.NoError(t, err)
+	assert.Equal(t, "fake png data", string(photo))
+	assert.FileExists(t, filepath.Join(buildDir, "posts", "index.md.bk"))
+	assert.NoFileExists(t, filepath.Join(buildDir, "posts", "notes.txt"))
+
+	post, err := os.ReadFile(filepath.Join(buildDir, "posts", "post.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(post), `<img src="photo.png" alt="Photo"`)
+}
+
+func TestIntegration_MaxImageSize(t *testing.T) {
+	files := map[string]string{
+		"index.md":        "# Home",
+		"posts/index.md":  "# Posts",
+		"posts/post.md":   "# Post\n\n![Small](small.png)\n\n![Large](large.png)\n",
+		"posts/small.png": strings.Repeat("x", 100),
+		"posts/large.png": strings.Repeat("x", 2048),
+	}
+
+	t.Run("warns about oversized images", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithMaxImageSize(1024))
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+	})
+
+	t.Run("fails strict validations", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithMaxImageSize(1024), WithStrictValidation(true))
+		assert.NoError(t, gen.Generate())
+		err := gen.Validate()
+		assert.ErrorContains(t, err, "image of 2048 bytes exceeds 1024 bytes: large.png")
+		assert.NotContains(t, err.Error(), "small.png")
+		assert.ErrorContains(t, gen.ValidateBuild(buildDir), "exceeds 1024 bytes")
+	})
+}
+
+func TestIntegration_Math(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Home\n\nNo math, $5.\n",
+		"posts/index.md": "# Posts\n\nInline $x^2$.\n",
+	}, WithMath(true), WithSkipURLValidation(true))
+
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate())
+
+	posts, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(posts), `<span class="math inline">\(x^2\)</span>`)
+	assert.Contains(t, string(posts), "katex.min.js")
+
+	home, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(home), "katex")
+	assert.NotContains(t, string(home), "{{math}}")
+}
+
+func TestIntegration_Logger(t *testing.T) {
+	tests := []struct {
+		name        string
+		level       slog.Level
+		contains    []string
+		notContains []string
+	}{
+		{
+			name:        "debug logs every file",
+			level:       slog.LevelDebug,
+			contains:    []string{"msg=generated source=", "index.html", "msg=copied"},
+			notContains: []string{},
+		},
+		{
+			name:        "info skips files",
+			level:       slog.LevelInfo,
+			contains:    []string{},
+			notContains: []string{"msg=generated", "msg=copied"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: tt.level}))
+
+			gen, _ := newIntegrationTestGenerator(t, map[string]string{
+				"index.md":        "# Home\n",
+				"posts/index.md":  "# Posts\n\n![Photo](photo.png)\n",
+				"posts/photo.png": "png",
+			}, WithLogger(logger), WithSkipURLValidation(true))
+
+			assert.NoError(t, gen.Generate())
+			for _, want := range tt.contains {
+				assert.Contains(t, buf.String(), want)
+			}
+			for _, unwanted := range tt.notContains {
+				assert.NotContains(t, buf.String(), unwanted)
+			}
+		})
+	}
+}
+
+func TestIntegration_DryRun(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":        "# Home\n",
+		"posts/index.md":  "# Posts\n\n![Photo](photo.png)\n",
+		"posts/photo.png": "png",
+	}, WithDryRun(true), WithSitemap(true), WithBaseURL("https://example.org"))
+
+	assert.NoError(t, gen.Generate())
+
+	entries, err := os.ReadDir(buildDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "a dry run must not write to the build directory")
+
+	assert.Equal(t, []string{
+		filepath.Join(buildDir, "index.html"),
+		filepath.Join(buildDir, "posts", "index.html"),
+		filepath.Join(buildDir, "posts", "photo.png"),
+		filepath.Join(buildDir, "sitemap.xml"),
+	}, gen.PlannedFiles())
+
+	// A second run plans the same files instead of accumulating them
+	assert.NoError(t, gen.Generate())
+	assert.Len(t, gen.PlannedFiles(), 4)
+}
+
+func TestIntegration_MemoryFileSystem(t *testing.T) {
+	fs := filesystem.NewMemoryFileSystem()
+	fs.AddFile("/content/index.md", []byte("# Home\n\n[Posts](posts/index.md)\n"))
+	fs.AddFile("/content/posts/index.md", []byte("# Posts\n\n{{list-child-articles}}\n"))
+	fs.AddFile("/content/posts/first.md", []byte("# First\n\n![Logo](../../assets/logo.png)\n"))
+	fs.AddFile("/assets/logo.png", []byte("png"))
+	fs.AddFile("/scripts/app.js", []byte("console.log('app')"))
+
+	gen, err := NewGenerator(WithFileSystem(fs), WithSitemap(true), WithBaseURL("https://example.org"))
+	assert.NoError(t, err)
+	gen.withContentDir("/content").
+		withBuildDir("/build").
+		withAssetsDir("/assets").
+		withScriptsDir("/scripts")
+
+	assert.NoError(t, gen.Generate())
+
+	for _, path := range []string{
+		"/build/index.html",
+		"/build/posts/index.html",
+		"/build/posts/first.html",
+		"/build/assets/logo.png",
+		"/build/scripts/app.js",
+		"/build/sitemap.xml",
+	} {
+		_, ok := fs.GetFile(path)
+		assert.True(t, ok, "expected %s to be written", path)
+	}
+
+	posts, _ := fs.GetFile("/build/posts/index.html")
+	assert.Contains(t, string(posts), `href="first.html"`)
+	assert.Contains(t, string(posts), "Posts")
+}
+
+func TestIntegration_AdjacentPosts(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":        "# Home\n",
+		"about.md":        "# About\n",
+		"contact.md":      "# Contact\n",
+		"posts/index.md":  "# Posts\n",
+		"posts/first.md":  "---\ndate: 2024-01-01\n---\n# First\n",
+		"posts/second.md": "# Second\n\n<!-- creation-date: 2024-02-01 -->\n",
+		"posts/third.md":  "---\ntitle: Third & last\ndate: 2024-03-01\n---\n# Third\n",
+	}, WithSkipURLValidation(true))
+
+	assert.NoError(t, gen.Generate())
+
+	read := func(name string) string {
+		data, err := os.ReadFile(filepath.Join(buildDir, "posts", name))
+		assert.NoError(t, err)
+		return string(data)
+	}
+
+	third := read("third.html")
+	assert.NotContains(t, third, `rel="next"`, "the most recent post has no newer post")
+	assert.Contains(t, third, `<a href="second.html" rel="prev" class="ml-auto hover:underline">Second →</a>`)
+
+	second := read("second.html")
+	assert.Contains(t, second, `<a href="third.html" rel="next" class="hover:underline">← Third &amp; last</a>`)
+	assert.Contains(t, second, `<a href="first.html" rel="prev" class="ml-auto hover:underline">First →</a>`)
+
+	first := read("first.html")
+	assert.Contains(t, first, `<a href="second.html" rel="next" class="hover:underline">← Second</a>`)
+	assert.NotContains(t, first, `rel="prev"`, "the oldest post has no older post")
+
+	assert.NotContains(t, read("index.html"), `aria-label="posts"`, "section indexes are not posts")
+
+	about, err := os.ReadFile(filepath.Join(buildDir, "about.html"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(about), `aria-label="posts"`, "home pages are not posts")
+}
+
+func TestIntegration_EscapedTitle(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":     "# Home\n",
+		"quotes.md":    "# A < B & \"C\"\n",
+		"frontmatt.md": "---\ntitle: Fish & Chips\n---\n# Menu\n",
+	}, WithSkipURLValidation(true))
+
+	assert.NoError(t, gen.Generate())
+
+	quotes, err := os.ReadFile(filepath.Join(buildDir, "quotes.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(quotes), "<title>A &lt; B &amp; &#34;C&#34;</title>")
+	assert.Contains(t, string(quotes), `>A &lt; B &amp; &quot;C&quot;<a href="#a-b-c"`, "the body heading keeps the intended characters")
+
+	fm, err := os.ReadFile(filepath.Join(buildDir, "frontmatt.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(fm), "<title>Fish &amp; Chips</title>")
+}
+
+func TestIntegration_ContentDirs(t *testing.T) {
+	blogDir, buildDir := setupTestContent(t, map[string]string{
+		// Links to the other root are relative to the merged content
+		"index.md":       "# Home\n\n[Guide](guide/index.md)\n",
+		"posts/index.md": "# Posts\n\n[First](first.md)\n",
+		"posts/first.md": "# First\n",
+	})
+	docsDir, _ := setupTestContent(t, map[string]string{
+		"guide/index.md":   "# Guide\n\n[Install](install.md)\n",
+		"guide/install.md": "# Install\n\n![Shot](shot.png)\n",
+		"guide/shot.png":   "png",
+	})
+
+	gen, _ := newIntegrationTestGenerator(t, map[string]string{}, WithContentDirs(blogDir, docsDir), WithSkipURLValidation(true))
+	gen.withBuildDir(buildDir)
+
+	assert.NoError(t, gen.Generate())
+
+	for _, file := range []string{"index.html", "posts/index.html", "posts/first.html", "guide/index.html", "guide/install.html", "guide/shot.png"} {
+		_, err := os.Stat(filepath.Join(buildDir, file))
+		assert.NoError(t, err, "expected %s to be generated", file)
+	}
+
+	// The navigation spans the sections of both roots
+	home, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(home), `href="posts/index.html"`)
+	assert.Contains(t, string(home), `href="guide/index.html"`)
+}
+
+func TestIntegration_ContentMount(t *testing.T) {
+	blogDir, buildDir := setupTestContent(t, map[string]string{
+		"index.md": "# Home\n",
+	})
+	docsDir, _ := setupTestContent(t, map[string]string{
+		"index.md":   "# Docs\n\n[Install](install.md)\n",
+		"install.md": "# Install\n\n[Back](index.md)\n",
+	})
+
+	gen, _ := newIntegrationTestGenerator(t, map[string]string{},
+		WithContentDirs(blogDir, docsDir), WithContentMount(docsDir, "docs"), WithSkipURLValidation(true))
+	gen.withBuildDir(buildDir)
+
+	assert.NoError(t, gen.Generate())
+
+	install, err := os.ReadFile(filepath.Join(buildDir, "docs", "install.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(install), `href="index.html">Back</a>`)
+	assert.Contains(t, string(install), `href="../docs/index.html"`, "the mounted root is a section")
+}
+
+func TestGenerate_ContentDirsCollision(t *testing.T) {
+	blogDir, buildDir := setupTestContent(t, map[string]string{
+		"index.md":       "# Home\n",
+		"about/index.md": "# About\n",
+	})
+	docsDir, _ := setupTestContent(t, map[string]string{
+		"about/index.md": "# About the docs\n",
+	})
+
+	gen, _ := newIntegrationTestGenerator(t, map[string]string{}, WithContentDirs(blogDir, docsDir), WithSkipURLValidation(true))
+	gen.withBuildDir(buildDir)
+
+	err := gen.Generate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), fmt.Sprintf("%s is output from several sources: %s, %s",
+		filepath.Join(buildDir, "about", "index.html"), filepath.Join(blogDir, "about", "index.md"), filepath.Join(docsDir, "about", "index.md")))
+}
+
+func TestIntegration_AssetsAndScriptsOutDirs(t *testing.T) {
+	// Use a shared root so that relative paths from markdown to assets resolve correctly
+	rootDir := t.TempDir()
+	contentDir := filepath.Join(rootDir, "content", "markdown")
+	assetsDir := filepath.Join(rootDir, "content", "assets")
+	scriptsDir := filepath.Join(rootDir, "scripts")
+	buildDir := filepath.Join(rootDir, "target", "build")
+
+	_ = os.MkdirAll(contentDir, 0755)
+	_ = os.MkdirAll(assetsDir, 0755)
+	_ = os.MkdirAll(scriptsDir, 0755)
+	_ = os.WriteFile(filepath.Join(contentDir, "index.md"), []byte("# Home\n\n![Logo](../assets/logo.png)\n"), 0644)
+	_ = os.WriteFile(filepath.Join(assetsDir, "logo.png"), []byte("png"), 0644)
+	_ = os.WriteFile(filepath.Join(scriptsDir, "app.js"), []byte("console.log('app')"), 0644)
+
+	gen := createTestGenerator(contentDir, buildDir).
+		withAssetsDir(assetsDir).
+		withScriptsDir(scriptsDir)
+	WithAssetsOutDir("static")(gen)
+	WithScriptsOutDir("static/js")(gen)
+
+	assert.NoError(t, gen.Generate())
+
+	assert.FileExists(t, filepath.Join(buildDir, "static", "logo.png"))
+	assert.FileExists(t, filepath.Join(buildDir, "static", "js", "app.js"))
+	assert.NoDirExists(t, filepath.Join(buildDir, "assets"))
+	assert.NoDirExists(t, filepath.Join(buildDir, "scripts"))
+
+	home, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(home), `src="static/logo.png"`)
+}
+
+func TestIntegration_ClassAllowlist(t *testing.T) {
+	files := map[string]string{
+		"index.md": "# Home",
+	}
+	template := `<html><body class="bg-white dark:bg-gray-900">{{navigation}}{{content}}<p class="text-4xs">Small print</p></body></html>`
+	allowed := []string{"bg-white", "bg-gray-900"}
+
+	t.Run("unknown classes are reported", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithTemplate(template), WithClassAllowlist(allowed...))
+		assert.NoError(t, gen.Generate())
+
+		err := gen.Validate()
+		assert.ErrorContains(t, err, `unknown class "text-4xs"`)
+		assert.NotContains(t, err.Error(), `"bg-white"`)
+		assert.NotContains(t, err.Error(), `"dark:bg-gray-900"`)
+	})
+
+	t.Run("allowlist file", func(t *testing.T) {
+		allowlist := filepath.Join(t.TempDir(), "classes.txt")
+		assert.NoError(t, os.WriteFile(allowlist, []byte("# safelist\ntext-4xs\n"), 0644))
+
+		gen, _ := newIntegrationTestGenerator(t, files, WithTemplate(template),
+			WithClassAllowlist(allowed...), WithClassAllowlistFile(allowlist))
+		assert.NoError(t, gen.Generate())
+
+		// The navigation classes are still unknown, the listed ones are not
+		err := gen.Validate()
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), `"text-4xs"`)
+	})
+
+	t.Run("missing allowlist file", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithClassAllowlistFile(filepath.Join(t.TempDir(), "missing.txt")))
+		assert.ErrorContains(t, gen.Generate(), "failed to load class allowlist")
+	})
+
+	t.Run("classes are not validated by default", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithTemplate(template))
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+	})
+}
+
+func TestIntegration_Excerpt(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+		"posts/more.md":  "# More\n\nIntro with a [link](short.md).\n\nSecond paragraph.\n\n<!--more-->\n\nThe rest.\n",
+		"posts/short.md": "# Short\n\nOnly paragraph.\n\nAnother one.\n",
+	}
+	template := `<html><body>{{navigation}}<section class="excerpt">{{excerpt}}</section>{{content}}</body></html>`
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithSectionTemplate("posts", template))
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "posts", "more.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<section class="excerpt"><p>Intro with a <a href="short.html">link</a>.</p>`+"\n"+`<p>Second paragraph.</p></section>`)
+	assert.Contains(t, string(output), "<p>The rest.</p>")
+	assert.NotContains(t, string(output), "<!--more-->")
+
+	output, err = os.ReadFile(filepath.Join(buildDir, "posts", "short.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<section class="excerpt"><p>Only paragraph.</p></section>`)
+	assert.Contains(t, string(output), "<p>Another one.</p>")
+}
+
+func TestIntegration_ListingExcerpts(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts\n\n{{list-child-articles}}",
+		"posts/more.md":  "# More\n\nIntro with a [link](short.md).\n\nSecond paragraph.\n\n<!--more-->\n\nThe rest.\n",
+		"posts/short.md": "# Short\n\nOnly paragraph.\n\nAnother one.\n",
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithListingExcerpts(true))
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), `<li><a href="more.html">More</a><br>`+"\n"+`Intro with a link. Second paragraph.</li>`)
+		assert.Contains(t, string(output), `<li><a href="short.html">Short</a><br>`+"\n"+`Only paragraph.</li>`)
+		assert.NotContains(t, string(output), "The rest.")
+		assert.NotContains(t, string(output), "Another one.")
+	})
+
+	t.Run("with the site converter options", func(t *testing.T) {
+		withEmoji := map[string]string{"posts/emoji.md": "# Emoji\n\nShipped :rocket:\n"}
+		maps.Copy(withEmoji, files)
+		gen, buildDir := newIntegrationTestGenerator(t, withEmoji, WithListingExcerpts(true), WithEmoji(true))
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), `<li><a href="emoji.html">Emoji</a><br>`+"\n"+`Shipped 🚀</li>`)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files)
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), `<li><a href="more.html">More</a></li>`)
+		assert.NotContains(t, string(output), "Intro with a link.")
+	})
+}
+
+func TestIntegration_FileModes(t *testing.T) {
+	files := map[string]string{
+		"index.md":        "# Home",
+		"posts/index.md":  "# Posts",
+		"posts/photo.png": "png",
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithFileMode(0664), WithDirMode(0775))
+	assert.NoError(t, gen.Generate())
+
+	for _, path := range []string{"index.html", "posts/index.html", "posts/photo.png"} {
+		info, err := os.Stat(filepath.Join(buildDir, path))
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0664), info.Mode().Perm(), path)
+	}
+	for _, dir := range []string{"posts", "assets", "scripts"} {
+		info, err := os.Stat(filepath.Join(buildDir, dir))
+		assert.NoError(t, err)
+		assert.Equal(t, os.FileMode(0775), info.Mode().Perm(), dir)
+	}
+}
+
+func TestWithFileModes_IgnoreZero(t *testing.T) {
+	gen := createTestGenerator("content", "build")
+	WithFileMode(0)(gen)
+	WithDirMode(0)(gen)
+	assert.Equal(t, filesystem.DefaultFileMode, gen.fileMode)
+	assert.Equal(t, filesystem.DefaultDirMode, gen.dirMode)
+}
+
+func TestIntegration_Landmarks(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "# First\n\nHello.",
+	})
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "posts", "first.html"))
+	assert.NoError(t, err)
+	html := string(output)
+	assert.Contains(t, html, `<body class="section-posts bg-white`)
+	assert.Contains(t, html, `<a href="#main-content"`)
+	assert.Contains(t, html, `<nav role="navigation" aria-label="Main"`)
+	assert.Contains(t, html, `<main id="main-content">`)
+	assert.Less(t, strings.Index(html, `href="#main-content"`), strings.Index(html, `role="navigation"`))
+	assert.Less(t, strings.Index(html, `role="navigation"`), strings.Index(html, "<main"))
+	assert.Less(t, strings.Index(html, `<main id="main-content">`), strings.Index(html, "<article"))
+	assert.Less(t, strings.Index(html, "</article>"), strings.Index(html, "</main>"))
+	assert.Less(t, strings.Index(html, `<main id="main-content">`), strings.Index(html, "<p>Hello.</p>"))
+}
+
+func TestIntegration_ImageDimensions(t *testing.T) {
+	var photo bytes.Buffer
+	assert.NoError(t, png.Encode(&photo, image.NewRGBA(image.Rect(0, 0, 640, 480))))
+	files := map[string]string{
+		"index.md":        "# Home",
+		"posts/index.md":  "# Posts",
+		"posts/first.md":  "# First\n\n![Photo](photo.png)\n\n![Remote](https://example.org/remote.png)\n",
+		"posts/photo.png": photo.String(),
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithImageDimensions(true))
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "posts", "first.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<img src="photo.png" alt="Photo" decoding="async" loading="lazy" width="640" height="480">`)
+	assert.Contains(t, string(output), `<img src="https://example.org/remote.png" alt="Remote" decoding="async" loading="lazy">`)
+}
+
+func TestIntegration_LastMod(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home",
+	}, WithLastModLayout("January 2, 2006"))
+	// The test content is outside any git repository, the modification time is used
+	modTime := time.Date(2026, 3, 15, 8, 30, 0, 0, time.UTC)
+	assert.NoError(t, os.Chtimes(filepath.Join(gen.contentDir, "index.md"), modTime, modTime))
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `Last updated <time datetime="`)
+	assert.Contains(t, string(output), `">March 15, 2026</time></footer>`)
+}
+
+func TestIntegration_LinksToExcludedPages(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n\n- [Draft](posts/draft.md)\n- [Notes](posts/notes.md)\n",
+		"posts/index.md": "# Posts",
+		"posts/draft.md": "---\ndraft: true\n---\n# Draft",
+		"posts/notes.md": "# Notes",
+	}
+
+	t.Run("links to drafts and ignored pages are reported", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithIgnore("notes.md"))
+		assert.NoError(t, gen.Generate())
+
+		err := gen.Validate()
+		assert.ErrorContains(t, err, "links to excluded page posts/draft.html (draft)")
+		assert.ErrorContains(t, err, "links to excluded page posts/notes.html (ignored)")
+		assert.NotContains(t, err.Error(), "local link not found")
+	})
+
+	t.Run("drafts included in the build", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithIncludeDrafts(true))
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+	})
+}
+
+func TestGenerate_StrictValidation(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+	}
+
+	for _, strict := range []bool{false, true} {
+		t.Run(fmt.Sprintf("strict %v", strict), func(t *testing.T) {
+			gen, _ := newIntegrationTestGenerator(t, files, WithStrictValidation(strict))
+			var configs []pageConfig
+			gen.pageGeneratorFactory = func(cfg pageConfig) PageGenerator {
+				configs = append(configs, cfg)
+				return &fakePageGenerator{}
+			}
+			assert.NoError(t, gen.Generate())
+
+			assert.Len(t, configs, 2)
+			for _, cfg := range configs {
+				assert.Equal(t, strict, cfg.strictValidation, cfg.sourceMDPath)
+			}
+		})
+	}
+}
+
+func TestIntegration_HeadExtra(t *testing.T) {
+	const (
+		siteHead = `<script defer src="/scripts/analytics.js"></script>`
+		pageHead = `<link rel="preload" href="/assets/hero.webp" as="image">`
+	)
+	files := map[string]string{
+		"index.md":       "---\nhead_extra: '" + pageHead + "'\n---\n# Home",
+		"posts/index.md": "# Posts",
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithHeadExtra(siteHead))
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), pageHead+"\n</head>")
+	assert.NotContains(t, string(output), siteHead)
+
+	output, err = os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), siteHead+"\n</head>")
+	assert.NotContains(t, string(output), "{{head_extra}}")
+}
+
+func TestIntegration_ExternalValidation(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		select {
+		case <-time.After(time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+
+	files := map[string]string{
+		"index.md":       "# Home\n\n[External](" + server.URL + "/page)\n\n![Image](" + server.URL + "/image.png)\n",
+		"posts/index.md": "# Posts",
+	}
+
+	t.Run("skipped", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithSkipURLValidation(true))
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+		assert.Equal(t, int32(0), requests.Load())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithSkipURLValidation(false), WithExternalTimeout(50*time.Millisecond))
+		assert.NoError(t, gen.Generate())
+		start := time.Now()
+		assert.Error(t, gen.Validate())
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.NotZero(t, requests.Load())
+	})
+}
+
+func TestIntegration_TableWrapper(t *testing.T) {
+	files := map[string]string{
+		"index.md": "# Home\n\n| Name | Value |\n| ---- | ----- |\n| a    | 1     |\n",
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithTableWrapper("overflow-x-auto"),
+		WithTemplate(`<html><body>{{navigation}}{{content}}</body></html>`), WithClassAllowlist("bg-white"))
+	assert.NoError(t, gen.Generate())
+	// The navigation classes are unknown, the wrapper class is allowed
+	err := gen.Validate()
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), `"overflow-x-auto"`)
+	assert.NotContains(t, err.Error(), "table")
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "<div class=\"overflow-x-auto\">\n<table>")
+	assert.Contains(t, string(output), "</table>\n</div>")
+}
+
+func TestIntegration_HeadingAnchor(t *testing.T) {
+	files := map[string]string{
+		"index.md": "# Home\n\n## Getting Started\n",
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithHeadingAnchor("anchor-link", "¶"),
+		WithTemplate(`<html><body>{{navigation}}{{content}}</body></html>`), WithClassAllowlist("bg-white"))
+	assert.NoError(t, gen.Generate())
+	// The navigation classes are unknown, the anchor class is allowed
+	err := gen.Validate()
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), `"anchor-link"`)
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<h2 id="getting-started">Getting Started<a href="#getting-started" class="anchor-link">¶</a></h2>`)
+}
+
+func TestIntegration_ExternalLinkIcon(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n\n[Go](https://go.dev)\n\n[Self](https://example.com/index.html)\n\n[Posts](posts/index.md)\n\n[Mail](mailto:me@example.com)\n",
+		"posts/index.md": "# Posts",
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithBaseURL("https://example.com"), WithExternalLinkIcon("", true))
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<a href="https://go.dev">Go<span class="external-link-icon" aria-hidden="true"></span></a>`)
+	assert.Contains(t, string(output), `<a href="https://example.com/index.html">Self</a>`)
+	assert.Contains(t, string(output), `<a href="posts/index.html">Posts</a>`)
+	assert.Contains(t, string(output), `<a href="mailto:me@example.com">Mail</a>`)
+}
+
+func TestIntegration_CodeBlocks(t *testing.T) {
+	files := map[string]string{
+		"index.md": "# Home\n\n```go\nfmt.Println(\"hello\")\n```\n",
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithCodeBlocks(markdown.CodeBlocks{LineNumbers: true}),
+		WithTemplate(`<html><body>{{navigation}}{{content}}</body></html>`), WithClassAllowlist("bg-white"))
+	assert.NoError(t, gen.Generate())
+	// The navigation classes are unknown, the code block classes are allowed
+	err := gen.Validate()
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), `"code-block"`)
+	assert.NotContains(t, err.Error(), `"copy"`)
+	assert.NotContains(t, err.Error(), `"line-number"`)
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<div class="code-block"><button class="copy" type="button" aria-label="Copy code">Copy</button>`)
+	assert.Contains(t, string(output), `<span class="line-number">1</span>`)
+}
+
+// staticSubstituter resolves its placeholder with a fixed value.
+type staticSubstituter struct{ placeholder, value string }
+
+func (s staticSubstituter) Placeholder() string { return s.placeholder }
+
+func (s staticSubstituter) Resolve(_ string) (string, error) { return s.value, nil }
+
+func TestIntegration_CustomSubstituter(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "# First",
+	}
+
+	// {{copyright}} resolves to {{year}}, which is only resolved when registered after it
+	gen, buildDir := newIntegrationTestGenerator(t, files,
+		WithTemplate("<main>{{content}}</main><footer>{{copyright}}</footer>"),
+		WithSubstituter(staticSubstituter{placeholder: "{{copyright}}", value: "&copy; {{year}}"}),
+		WithSubstituter(staticSubstituter{placeholder: "{{year}}", value: "2026"}),
+	)
+	assert.NoError(t, gen.Generate())
+
+	for _, page := range []string{"index.html", "posts/index.html", "posts/first.html"} {
+		output, err := os.ReadFile(filepath.Join(buildDir, page))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), "<footer>&copy; 2026</footer>", page)
+	}
+}
+
+func TestIntegration_SiteVariables(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "# First\n\nWritten by {{site.author}}.",
+	}
+	template := "<title>{{site.title}}</title><main>{{content}}</main><footer>{{site.author}}{{site.missing}}</footer>"
+
+	siteFile := filepath.Join(t.TempDir(), "site.json")
+	assert.NoError(t, os.WriteFile(siteFile, []byte(`{"title": "Tom & Jerry", "author": "Tim"}`), 0644))
+
+	t.Run("variables", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files,
+			WithTemplate(template),
+			WithSiteVariables(map[string]string{"title": "Overridden", "lang": "en"}),
+			WithSiteVariablesFile(siteFile),
+		)
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "posts/first.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), "<title>Tom &amp; Jerry</title>")
+		assert.Contains(t, string(output), "Written by Tim.")
+		assert.Contains(t, string(output), "<footer>Tim</footer>")
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files,
+			WithTemplate(template),
+			WithSiteVariablesFile(siteFile),
+			WithStrictSiteVariables(true),
+		)
+		err := gen.Generate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown site variable "missing"`)
+	})
+
+	t.Run("invalid file", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "site.json")
+		assert.NoError(t, os.WriteFile(invalid, []byte(`{"year": 2026}`), 0644))
+		gen, _ := newIntegrationTestGenerator(t, files, WithSiteVariablesFile(invalid))
+		assert.ErrorContains(t, gen.Generate(), "failed to load site variables")
+	})
+}
+
+func TestIntegration_FrontmatterFields(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "---\nauthor: Tim\nfeatured: true\n---\n# First",
+	}
+	template := `<main data-featured="{{fm.featured}}">{{content}}</main><footer>By {{fm.author}}{{fm.missing}}</footer>`
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithTemplate(template))
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "posts/first.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<main data-featured="true">`)
+	assert.Contains(t, string(output), "<footer>By Tim</footer>")
+
+	// Pages without the fields resolve them to empty strings
+	output, err = os.ReadFile(filepath.Join(buildDir, "posts/index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<main data-featured="">`)
+	assert.Contains(t, string(output), "<footer>By </footer>")
+}
+
+func TestIntegration_CopyrightYear(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+	}
+	year := time.Now().Year()
+
+	gen, buildDir := newIntegrationTestGenerator(t, files,
+		WithTemplate("<main>{{content}}</main><footer>&copy; {{copyright_year}}</footer>"),
+		WithCopyrightStartYear(2019),
+	)
+	assert.NoError(t, gen.Generate())
+	output, err := os.ReadFile(filepath.Join(buildDir, "posts/index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), fmt.Sprintf("<footer>&copy; 2019–%d</footer>", year))
+
+	gen, buildDir = newIntegrationTestGenerator(t, files,
+		WithTemplate("<main>{{content}}</main><footer>&copy; {{copyright_year}}</footer>"),
+	)
+	assert.NoError(t, gen.Generate())
+	output, err = os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), fmt.Sprintf("<footer>&copy; %d</footer>", year))
+}
+
+// copyrightValidator requires the pages to have a copyright notice.
+type copyrightValidator struct{}
+
+func (copyrightValidator) Validate(htmlPath, _ string, content []byte) []error {
+	if !strings.Contains(string(content), "&copy;") {
+		return []error{fmt.Errorf("%s: missing copyright notice", htmlPath)}
+	}
+	return nil
+}
+
+func TestIntegration_CustomValidator(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+	}
+
+	gen, _ := newIntegrationTestGenerator(t, files, WithValidator(copyrightValidator{}))
+	assert.NoError(t, gen.Generate())
+	err := gen.Validate()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "index.html: missing copyright notice")
+	}
+}
+
+func TestGenerate_SharesConverter(t *testing.T) {
+	gen, _ := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "# First",
+	})
+	assert.NoError(t, gen.Generate())
+
+	assert.NotNil(t, gen.converter)
+	for _, cfg := range gen.pageConfigs {
+		assert.Same(t, gen.converter, cfg.converter, cfg.sourceMDPath)
+	}
+}
+
+func TestIntegration_DirectoryLinks(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n\n[Posts](posts/)\n",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "# First\n\n[About](../about/) and [home](..)\n",
+		"about/index.md": "# About",
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files)
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<a href="posts/index.html">Posts</a>`)
+
+	output, err = os.ReadFile(filepath.Join(buildDir, "posts", "first.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<a href="../about/index.html">About</a>`)
+	assert.Contains(t, string(output), `<a href="../index.html">home</a>`)
+}
+
+func TestIntegration_UnsafeHTML(t *testing.T) {
+	files := map[string]string{
+		"index.md": "# Home\n\n<div class=\"callout shadow-xl\" onclick=\"steal()\">\n<script>steal()</script>\nNote\n</div>\n",
+	}
+
+	t.Run("omitted by default", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files)
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), "<!-- raw HTML omitted -->")
+		assert.NotContains(t, string(output), "steal()")
+	})
+
+	t.Run("sanitized with the class allowlist", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files,
+			WithUnsafeHTML(true), WithHTMLSanitizer(markdown.DefaultPolicy()), WithClassAllowlist("callout"))
+		assert.NoError(t, gen.Generate())
+
+		output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(output), "<div class=\"callout\">\n\nNote\n</div>")
+		assert.NotContains(t, string(output), "steal()")
+	})
+}
+
+func TestIntegration_Emoji(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home :wave:\n\nShipped :rocket: with `:rocket:`\n",
+	}, WithEmoji(true))
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "<p>Shipped 🚀 with <code>:rocket:</code></p>")
+	assert.Contains(t, string(output), "<title>Home 👋</title>")
+}
+
+func TestIntegration_ConversionCache(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Home\n",
+		"posts/index.md": "# Posts\n",
+		"posts/first.md": "# First\n\nDraft text.\n",
+	}, WithConversionCache(true))
+	assert.NoError(t, gen.Generate())
+	first, err := os.ReadFile(filepath.Join(buildDir, "posts/first.html"))
+	assert.NoError(t, err)
+	cached := gen.conversionCache.Len()
+	assert.Positive(t, cached)
+
+	// Unchanged pages hit the cache
+	assert.NoError(t, gen.Generate())
+	again, err := os.ReadFile(filepath.Join(buildDir, "posts/first.html"))
+	assert.NoError(t, err)
+	assert.Equal(t, string(first), string(again))
+	assert.Equal(t, cached, gen.conversionCache.Len())
+
+	// Edited pages are converted again
+	assert.NoError(t, os.WriteFile(filepath.Join(gen.contentDir, "posts/first.md"), []byte("# First\n\nFinal text.\n"), 0644))
+	assert.NoError(t, gen.Generate())
+	edited, err := os.ReadFile(filepath.Join(buildDir, "posts/first.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(edited), "<p>Final text.</p>")
+}
+
+func TestIntegration_Autolink(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home\n\nSee https://example.com for details.\n",
+	}, WithAutolink(false))
+	assert.NoError(t, gen.Generate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "<p>See https://example.com for details.</p>")
+}
+
+func TestIntegration_DuplicateIDs(t *testing.T) {
+	gen, _ := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home\n\n## Introduction {#intro}\n\n## Getting started {#intro}\n",
+	}, WithSkipURLValidation(true))
+	assert.NoError(t, gen.Generate())
+
+	err := gen.Validate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate id "intro"`)
+}
+
+func TestIntegration_RootMarkdownLinks(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n\n[About](/about.md)\n",
+		"about.md":       "# About\n\n[First](/posts/first.md)\n",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "# First\n\n[About](/about.md)\n",
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files, WithSkipURLValidation(true))
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate())
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<a href="/about.html">About</a>`)
+
+	output, err = os.ReadFile(filepath.Join(buildDir, "posts", "first.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<a href="/about.html">About</a>`)
+
+	output, err = os.ReadFile(filepath.Join(buildDir, "about.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), `<a href="/posts/first.html">First</a>`)
+}
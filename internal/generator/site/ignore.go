@@ -0,0 +1,44 @@
+package site
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// checkIgnorePatterns ensures every ignore pattern is a valid glob pattern.
+func (g *Generator) checkIgnorePatterns() error {
+	for _, pattern := range g.ignorePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid ignore pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// isIgnored reports whether the file or directory at relPath, relative to the content directory,
+// matches one of the ignore patterns. A pattern matching a directory ignores everything in it,
+// and a pattern without "/" is matched against the name of the file and of each of its parent directories.
+func (g *Generator) isIgnored(relPath string) bool {
+	return matchesPatterns(g.ignorePatterns, relPath)
+}
+
+// matchesPatterns reports whether relPath, or one of its parent directories, matches one of the glob patterns,
+// patterns without "/" being also matched against the names.
+func matchesPatterns(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.TrimSuffix(pattern, "/")
+		for p := filepath.ToSlash(relPath); p != "." && p != "/"; p = path.Dir(p) {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+			if !strings.Contains(pattern, "/") {
+				if ok, _ := path.Match(pattern, path.Base(p)); ok {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,39 @@
+package site
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_IsIgnored(t *testing.T) {
+	g := &Generator{ignorePatterns: []string{"_drafts/", "*.txt", "posts/old-*.md", "README.md"}}
+
+	tests := []struct {
+		relPath string
+		want    bool
+	}{
+		{relPath: "_drafts", want: true},
+		{relPath: "_drafts/idea.md", want: true},
+		{relPath: "_drafts/nested/idea.md", want: true},
+		{relPath: "notes.txt", want: true},
+		{relPath: "posts/assets/notes.txt", want: true},
+		{relPath: "posts/old-post.md", want: true},
+		{relPath: "posts/nested/old-post.md", want: false},
+		{relPath: "posts/README.md", want: true},
+		{relPath: "posts/new-post.md", want: false},
+		{relPath: "index.md", want: false},
+		{relPath: ".", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relPath, func(t *testing.T) {
+			assert.Equal(t, tt.want, g.isIgnored(tt.relPath))
+		})
+	}
+}
+
+func TestNewGenerator_InvalidIgnorePattern(t *testing.T) {
+	_, err := NewGenerator(WithIgnore("[drafts"))
+	assert.ErrorContains(t, err, `invalid ignore pattern "[drafts"`)
+}
@@ -0,0 +1,221 @@
+package site
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ImageFormat is a format the PNG and JPEG assets can be converted to, in addition to being copied.
+type ImageFormat string
+
+const (
+	ImageFormatAVIF ImageFormat = "avif"
+	ImageFormatWebP ImageFormat = "webp"
+)
+
+type (
+	// ImageEncoder converts the image named name, with the content data, to format.
+	// The name only tells the format of data through its extension.
+	ImageEncoder interface {
+		Encode(name string, data []byte, format ImageFormat) ([]byte, error)
+	}
+
+	// CommandEncoder converts images with the cwebp and avifenc commands, which must be installed.
+	CommandEncoder struct{}
+
+	// imageVariant is a converted copy of an asset, at buildPath relative to the build directory.
+	imageVariant struct {
+		buildPath string
+		format    ImageFormat
+	}
+)
+
+var (
+	// pictureOrImgRe matches the picture elements, left untouched, and the img elements of the generated pages.
+	pictureOrImgRe = regexp.MustCompile(`(?s)<picture[\s>].*?</picture>|<img\s[^>]*>`)
+	pictureSrcRe   = regexp.MustCompile(`\ssrc="([^"]*)"`)
+)
+
+// Encode writes data to a temporary file and converts it with cwebp or avifenc.
+func (CommandEncoder) Encode(name string, data []byte, format ImageFormat) ([]byte, error) {
+	dir, err := os.MkdirTemp("", "blog-image-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	in := filepath.Join(dir, "image"+filepath.Ext(name))
+	out := filepath.Join(dir, "image."+string(format))
+	if err := os.WriteFile(in, data, 0600); err != nil {
+		return nil, err
+	}
+
+	var cmd *exec.Cmd
+	switch format {
+	case ImageFormatWebP:
+		cmd = exec.Command("cwebp", "-quiet", in, "-o", out)
+	case ImageFormatAVIF:
+		cmd = exec.Command("avifenc", in, out)
+	default:
+		return nil, fmt.Errorf("unsupported image format %q", format)
+	}
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", cmd.Path, err, strings.TrimSpace(string(output)))
+	}
+	return os.ReadFile(out)
+}
+
+// checkImageFormats ensures the image formats are supported and the patterns of the images not to convert are valid.
+func (g *Generator) checkImageFormats() error {
+	for _, format := range g.imageFormats {
+		if format != ImageFormatAVIF && format != ImageFormatWebP {
+			return fmt.Errorf("unsupported image format %q", format)
+		}
+	}
+	for _, pattern := range g.imageFormatsSkip {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid image conversion skip pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// isConvertible reports whether the asset at relPath, relative to the assets directory, is converted to the image formats.
+// Only PNG and JPEG images are, SVG, WebP and other files are left as they are.
+func (g *Generator) isConvertible(relPath string) bool {
+	switch strings.ToLower(filepath.Ext(relPath)) {
+	case ".png", ".jpg", ".jpeg":
+		return !matchesPatterns(g.imageFormatsSkip, relPath)
+	default:
+		return false
+	}
+}
+
+// convertImages writes the variants of the PNG and JPEG assets in the image formats next to their copy,
+// and records them to rewrite the images of the pages into picture elements.
+// A variant is not generated when the assets directory already has a file at its path, e.g. a hand-made photo.webp,
+// which is used instead.
+func (g *Generator) convertImages() error {
+	if len(g.imageFormats) == 0 {
+		return nil
+	}
+
+	outDir := filepath.Join(g.buildDir, g.assetsOutDir)
+	return g.fs.Walk(g.assetsDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(g.assetsDir, p)
+		if err != nil {
+			return err
+		}
+		if !g.isConvertible(relPath) {
+			return nil
+		}
+
+		data, err := g.fs.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", p, err)
+		}
+
+		copyPath := path.Join(filepath.ToSlash(g.assetsOutDir), filepath.ToSlash(relPath))
+		if renamed, ok := g.fingerprints[copyPath]; ok {
+			copyPath = renamed
+		}
+
+		for _, format := range g.imageFormats {
+			variantRelPath := strings.TrimSuffix(relPath, filepath.Ext(relPath)) + "." + string(format)
+			if _, err := g.fs.Stat(filepath.Join(g.assetsDir, variantRelPath)); err == nil {
+				// The existing file has been copied with the other assets
+				variantPath := path.Join(filepath.ToSlash(g.assetsOutDir), filepath.ToSlash(variantRelPath))
+				if renamed, ok := g.fingerprints[variantPath]; ok {
+					variantPath = renamed
+				}
+				g.imageVariants[copyPath] = append(g.imageVariants[copyPath], imageVariant{buildPath: variantPath, format: format})
+				continue
+			}
+
+			converted, err := g.imageEncoder.Encode(p, data, format)
+			if err != nil {
+				return fmt.Errorf("converting %s to %s: %w", p, format, err)
+			}
+			if g.fingerprint {
+				variantRelPath = fingerprintName(variantRelPath, converted)
+			}
+
+			outPath := filepath.Join(outDir, variantRelPath)
+			if err := g.writeOutput(outPath, converted); err != nil {
+				return fmt.Errorf("writing %s: %w", outPath, err)
+			}
+			g.logger.Debug("converted", "source", p, "output", outPath)
+
+			g.imageVariants[copyPath] = append(g.imageVariants[copyPath], imageVariant{
+				buildPath: path.Join(filepath.ToSlash(g.assetsOutDir), filepath.ToSlash(variantRelPath)),
+				format:    format,
+			})
+		}
+		return nil
+	})
+}
+
+// pictureRewriter returns a function rewriting the images of the page generated at htmlPath which have converted
+// variants into picture elements. It returns nil when no image format is configured.
+func (g *Generator) pictureRewriter(htmlPath string) func(content string) string {
+	if len(g.imageFormats) == 0 {
+		return nil
+	}
+
+	pageDir := g.pageDir(htmlPath)
+	return func(content string) string {
+		return rewritePictures(content, func(src string) []imageVariant {
+			buildPath, ok := g.referencedBuildPath(src, pageDir)
+			if !ok {
+				return nil
+			}
+			return g.imageVariants[buildPath]
+		})
+	}
+}
+
+// rewritePictures wraps the img elements of content for which variants returns variants in picture elements,
+// with a source per variant, in order, before the img as fallback. Images already in a picture element are left untouched.
+// Variants are referenced from the directory of the img src, without its query or fragment.
+func rewritePictures(content string, variants func(src string) []imageVariant) string {
+	return pictureOrImgRe.ReplaceAllStringFunc(content, func(element string) string {
+		if strings.HasPrefix(element, "<picture") {
+			return element
+		}
+		m := pictureSrcRe.FindStringSubmatch(element)
+		if m == nil {
+			return element
+		}
+
+		src := m[1]
+		if i := strings.IndexAny(src, "?#"); i >= 0 {
+			src = src[:i]
+		}
+		found := variants(src)
+		if len(found) == 0 {
+			return element
+		}
+
+		var b strings.Builder
+		b.WriteString("<picture>")
+		for _, variant := range found {
+			srcset := strings.TrimSuffix(src, path.Base(src)) + path.Base(variant.buildPath)
+			fmt.Fprintf(&b, `<source srcset="%s" type="image/%s">`, srcset, variant.format)
+		}
+		b.WriteString(element)
+		b.WriteString("</picture>")
+		return b.String()
+	})
+}
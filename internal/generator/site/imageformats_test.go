@@ -0,0 +1,143 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeEncoder "converts" images by prefixing their content with the format.
+type fakeEncoder struct{}
+
+func (fakeEncoder) Encode(_ string, data []byte, format ImageFormat) ([]byte, error) {
+	return append([]byte(string(format)+":"), data...), nil
+}
+
+func TestRewritePictures(t *testing.T) {
+	variants := func(src string) []imageVariant {
+		if src != "/assets/photo.png" && src != "../assets/photo.png" {
+			return nil
+		}
+		return []imageVariant{
+			{buildPath: "assets/photo.avif", format: ImageFormatAVIF},
+			{buildPath: "assets/photo.webp", format: ImageFormatWebP},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "root-relative image",
+			content: `<p><img src="/assets/photo.png" alt="Photo"></p>`,
+			want:    `<p><picture><source srcset="/assets/photo.avif" type="image/avif"><source srcset="/assets/photo.webp" type="image/webp"><img src="/assets/photo.png" alt="Photo"></picture></p>`,
+		},
+		{
+			name:    "relative image with query",
+			content: `<img src="../assets/photo.png?v=2" alt="Photo" loading="lazy">`,
+			want:    `<picture><source srcset="../assets/photo.avif" type="image/avif"><source srcset="../assets/photo.webp" type="image/webp"><img src="../assets/photo.png?v=2" alt="Photo" loading="lazy"></picture>`,
+		},
+		{
+			name:    "image without variants",
+			content: `<img src="/assets/logo.svg" alt="Logo">`,
+			want:    `<img src="/assets/logo.svg" alt="Logo">`,
+		},
+		{
+			name:    "image already in a picture",
+			content: "<picture>\n<source srcset=\"/assets/photo.jxl\" type=\"image/jxl\">\n<img src=\"/assets/photo.png\" alt=\"Photo\">\n</picture>",
+			want:    "<picture>\n<source srcset=\"/assets/photo.jxl\" type=\"image/jxl\">\n<img src=\"/assets/photo.png\" alt=\"Photo\">\n</picture>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rewritePictures(tt.content, variants); got != tt.want {
+				t.Errorf("rewritePictures() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerator_IsConvertible(t *testing.T) {
+	g := &Generator{imageFormatsSkip: []string{"icons", "*.raw.png"}}
+
+	tests := map[string]bool{
+		"photo.png":          true,
+		"posts/photo.JPG":    true,
+		"photo.jpeg":         true,
+		"logo.svg":           false,
+		"photo.webp":         false,
+		"icons/home.png":     false,
+		"posts/scan.raw.png": false,
+	}
+	for relPath, want := range tests {
+		if got := g.isConvertible(relPath); got != want {
+			t.Errorf("isConvertible(%q) = %v, want %v", relPath, got, want)
+		}
+	}
+}
+
+func TestIntegration_ImageFormats(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home\n\n![Photo](/assets/photo.png)\n\n![Cover](/assets/cover.png)\n\n![Icon](/assets/icons/home.png)\n\n![Logo](/assets/logo.svg)\n",
+	}, WithImageFormats(ImageFormatAVIF, ImageFormatWebP), WithImageFormatsSkip("icons/"), WithImageEncoder(fakeEncoder{}))
+	assets := map[string]string{
+		"photo.png":      "photo",
+		"cover.png":      "cover",
+		"cover.webp":     "hand-made",
+		"icons/home.png": "home",
+		"logo.svg":       "<svg></svg>",
+	}
+	for name, content := range assets {
+		p := filepath.Join(gen.assetsDir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	outAssets := filepath.Join(buildDir, gen.assetsOutDir)
+	for name, want := range map[string]string{
+		"photo.avif": "avif:photo",
+		"photo.webp": "webp:photo",
+		"cover.avif": "avif:cover",
+		"cover.webp": "hand-made",
+	} {
+		got, err := os.ReadFile(filepath.Join(outAssets, name))
+		if err != nil {
+			t.Errorf("reading %s: %v", name, err)
+		} else if string(got) != want {
+			t.Errorf("%s = %q, want %q", name, got, want)
+		}
+	}
+	for _, name := range []string{"icons/home.webp", "logo.webp"} {
+		if _, err := os.Stat(filepath.Join(outAssets, name)); err == nil {
+			t.Errorf("%s should not be generated", name)
+		}
+	}
+
+	output, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(output)
+	for _, want := range []string{
+		`<picture><source srcset="/assets/photo.avif" type="image/avif"><source srcset="/assets/photo.webp" type="image/webp"><img src="/assets/photo.png"`,
+		`<picture><source srcset="/assets/cover.avif" type="image/avif"><source srcset="/assets/cover.webp" type="image/webp"><img src="/assets/cover.png"`,
+		`<p><img src="/assets/icons/home.png"`,
+		`<p><img src="/assets/logo.svg"`,
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("index.html should contain %q", want)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package site
+
+import (
+	"os"
+	"time"
+)
+
+// isUpToDate reports whether the output of p is newer than both its markdown source and the configuration files.
+func (g *Generator) isUpToDate(p generatedPage) bool {
+	sourceInfo, err := g.fs.Stat(p.sourceMDPath)
+	if err != nil {
+		return false
+	}
+
+	outputInfo, err := g.fs.Stat(p.destinationHTMLPath)
+	if err != nil {
+		return false
+	}
+
+	return outputInfo.ModTime().After(sourceInfo.ModTime()) && outputInfo.ModTime().After(g.configModTime)
+}
+
+// loadConfigModTime records the latest modification time of the files the pages are generated with:
+// the generator binary, which embeds the default page template, the page template, the site variables,
+// the navigation config and the class allowlist.
+// A file that cannot be stated counts as modified now, so that every page is regenerated.
+func (g *Generator) loadConfigModTime() {
+	g.configModTime = g.binaryModTime
+	for _, path := range []string{g.templateFile, g.siteVariablesFile, g.navConfigFile, g.classAllowlistFile} {
+		if path == "" {
+			continue
+		}
+
+		modTime := time.Now()
+		if info, err := g.fs.Stat(path); err == nil {
+			modTime = info.ModTime()
+		}
+		if modTime.After(g.configModTime) {
+			g.configModTime = modTime
+		}
+	}
+}
+
+// executableModTime returns the modification time of the running binary, which embeds the default page template.
+// When it cannot be determined, the current time is returned so that every page is regenerated.
+func executableModTime() time.Time {
+	path, err := os.Executable()
+	if err != nil {
+		return time.Now()
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Now()
+	}
+
+	return info.ModTime()
+}
@@ -0,0 +1,96 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGenerate_Incremental(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home\n",
+		"page.md":  "# Page\n",
+	})
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("first Generate() error = %v", err)
+	}
+
+	var (
+		past       = time.Now().Add(-time.Hour)
+		indexHTML  = filepath.Join(buildDir, "index.html")
+		pageHTML   = filepath.Join(buildDir, "page.html")
+		pageSource = filepath.Join(gen.contentDir, "page.md")
+	)
+
+	// Both outputs are from the past, only page.md is edited after them
+	for _, p := range []string{filepath.Join(gen.contentDir, "index.md"), pageSource} {
+		_ = os.Chtimes(p, past.Add(-time.Hour), past.Add(-time.Hour))
+	}
+	for _, p := range []string{indexHTML, pageHTML} {
+		_ = os.WriteFile(p, []byte("<html>previous build</html>"), 0644)
+		_ = os.Chtimes(p, past, past)
+	}
+	_ = os.WriteFile(pageSource, []byte("# Edited page\n"), 0644)
+
+	configDir := t.TempDir()
+	templateFile := filepath.Join(configDir, "page.html")
+	siteFile := filepath.Join(configDir, "site.json")
+	_ = os.WriteFile(templateFile, []byte("<main>{{content}}</main>"), 0644)
+	_ = os.WriteFile(siteFile, []byte(`{"title": "Blog"}`), 0644)
+	for _, p := range []string{templateFile, siteFile} {
+		_ = os.Chtimes(p, past.Add(-2*time.Hour), past.Add(-2*time.Hour))
+	}
+
+	rebuild, _ := newIntegrationTestGenerator(t, nil,
+		WithIncremental(true),
+		WithTemplateFile(templateFile),
+		WithSiteVariablesFile(siteFile),
+	)
+	rebuild.withContentDir(gen.contentDir).withBuildDir(buildDir)
+	rebuild.binaryModTime = past.Add(-2 * time.Hour)
+	if err := rebuild.Generate(); err != nil {
+		t.Fatalf("incremental Generate() error = %v", err)
+	}
+
+	index, _ := os.ReadFile(indexHTML)
+	if string(index) != "<html>previous build</html>" {
+		t.Errorf("unchanged index.md should be skipped, got:\n%s", index)
+	}
+
+	page, _ := os.ReadFile(pageHTML)
+	if string(page) == "<html>previous build</html>" {
+		t.Error("touched page.md should be regenerated")
+	}
+
+	for name, configFile := range map[string]string{"template": templateFile, "site variables": siteFile} {
+		t.Run("newer "+name+" file forces a full rebuild", func(t *testing.T) {
+			_ = os.WriteFile(indexHTML, []byte("<html>previous build</html>"), 0644)
+			_ = os.Chtimes(indexHTML, past, past)
+			_ = os.Chtimes(configFile, time.Now(), time.Now())
+			if err := rebuild.Generate(); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			index, _ := os.ReadFile(indexHTML)
+			if string(index) == "<html>previous build</html>" {
+				t.Errorf("index.md should be regenerated when the %s file is newer", name)
+			}
+			_ = os.Chtimes(configFile, past.Add(-2*time.Hour), past.Add(-2*time.Hour))
+		})
+	}
+
+	t.Run("newer generator binary forces a full rebuild", func(t *testing.T) {
+		_ = os.WriteFile(indexHTML, []byte("<html>previous build</html>"), 0644)
+		_ = os.Chtimes(indexHTML, past, past)
+		rebuild.binaryModTime = time.Now().Add(time.Hour)
+		if err := rebuild.Generate(); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		index, _ := os.ReadFile(indexHTML)
+		if string(index) == "<html>previous build</html>" {
+			t.Error("index.md should be regenerated when the generator binary, embedding the default template, is newer")
+		}
+	})
+}
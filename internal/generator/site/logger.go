@@ -0,0 +1,11 @@
+package site
+
+import (
+	"log/slog"
+	"os"
+)
+
+// defaultLogger only reports warnings and errors, so that builds are quiet unless something needs attention.
+func defaultLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+}
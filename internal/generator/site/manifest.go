@@ -0,0 +1,99 @@
+package site
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// manifestEntry is a file of the build directory listed in the manifest
+type manifestEntry struct {
+	// Path is relative to the build directory, with forward slashes
+	Path string `json:"path"`
+	// Source is the markdown source of a page, empty for the other files
+	Source string `json:"source,omitempty"`
+	Size   int64  `json:"size"`
+	// Hash is the hexadecimal SHA-256 of the file content
+	Hash string `json:"hash"`
+}
+
+// generateManifest writes the manifest of the build directory at the manifest path.
+func (g *Generator) generateManifest() error {
+	if g.manifestPath == "" || g.dryRun {
+		return nil
+	}
+
+	entries, err := g.manifestEntries()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding manifest: %w", err)
+	}
+
+	if err := g.fs.MkdirAll(filepath.Dir(g.manifestPath), g.dirMode); err != nil {
+		return fmt.Errorf("creating directory for %s: %w", g.manifestPath, err)
+	}
+	if err := g.fs.WriteFile(g.manifestPath, data, g.fileMode); err != nil {
+		return fmt.Errorf("writing %s: %w", g.manifestPath, err)
+	}
+	g.logger.Info("generated manifest", "path", g.manifestPath, "files", len(entries))
+	return nil
+}
+
+// writeOutput writes data at path with the file mode, recording path as an output of the build for the manifest.
+func (g *Generator) writeOutput(path string, data []byte) error {
+	if err := g.fs.WriteFile(path, data, g.fileMode); err != nil {
+		return err
+	}
+	if g.outputs == nil {
+		g.outputs = make(map[string]bool)
+	}
+	g.outputs[filepath.Clean(path)] = true
+	return nil
+}
+
+// manifestEntries lists the files of the build directory output by this build, sorted by path, pages with their
+// markdown source. Pages skipped by incremental builds are listed, leftovers of previous builds are not.
+func (g *Generator) manifestEntries() ([]manifestEntry, error) {
+	sources := make(map[string]string, len(g.pages))
+	paths := make([]string, 0, len(g.pages)+len(g.outputs))
+	for _, p := range g.pages {
+		path := filepath.Clean(p.destinationHTMLPath)
+		sources[path] = p.sourceMDPath
+		paths = append(paths, path)
+	}
+	for path := range g.outputs {
+		if _, ok := sources[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+
+	entries := make([]manifestEntry, 0, len(paths))
+	for _, path := range paths {
+		relPath, err := filepath.Rel(g.buildDir, path)
+		if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			continue
+		}
+		data, err := g.fs.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+
+		sum := sha256.Sum256(data)
+		entries = append(entries, manifestEntry{
+			Path:   filepath.ToSlash(relPath),
+			Source: filepath.ToSlash(sources[path]),
+			Size:   int64(len(data)),
+			Hash:   hex.EncodeToString(sum[:]),
+		})
+	}
+	slices.SortFunc(entries, func(a, b manifestEntry) int { return strings.Compare(a.Path, b.Path) })
+	return entries, nil
+}
@@ -0,0 +1,123 @@
+package site
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestGenerateManifest(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n",
+		"posts/index.md": "# Posts\n",
+		"posts/first.md": "# First\n",
+	}
+
+	contentDir, buildDir := setupTestContent(t, files)
+	assetsDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(assetsDir, "logo.svg"), []byte("<svg></svg>"), 0644)
+	scriptsDir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(scriptsDir, "app.js"), []byte("var x = 1;"), 0644)
+
+	gen := createTestGenerator(contentDir, buildDir).
+		withAssetsDir(assetsDir).
+		withScriptsDir(scriptsDir)
+	manifestPath := filepath.Join(buildDir, "manifest.json")
+	WithManifest(manifestPath)(gen)
+
+	// A second build must not list the manifest of the first one
+	for i := 0; i < 2; i++ {
+		if err := gen.Generate(); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("manifest.json should be written: %v", err)
+	}
+	var entries []manifestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("manifest.json is not valid JSON: %v", err)
+	}
+
+	byPath := make(map[string]manifestEntry)
+	for _, e := range entries {
+		byPath[e.Path] = e
+	}
+
+	page, ok := byPath["posts/first.html"]
+	if !ok {
+		t.Fatalf("manifest should list posts/first.html, got %+v", entries)
+	}
+	if page.Hash == "" {
+		t.Error("page hash should not be empty")
+	}
+	if page.Size == 0 {
+		t.Error("page size should not be zero")
+	}
+	if page.Source != filepath.ToSlash(filepath.Join(contentDir, "posts", "first.md")) {
+		t.Errorf("page source = %q, want the markdown source", page.Source)
+	}
+
+	for _, path := range []string{"assets/logo.svg", "scripts/app.js"} {
+		e, ok := byPath[path]
+		if !ok {
+			t.Errorf("manifest should list %s", path)
+			continue
+		}
+		if e.Source != "" {
+			t.Errorf("%s should have no source, got %q", path, e.Source)
+		}
+	}
+	if _, ok := byPath["manifest.json"]; ok {
+		t.Error("manifest should not list itself")
+	}
+}
+
+func TestGenerateManifest_DeletedPost(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":        "# Home\n",
+		"posts/index.md":  "# Posts\n",
+		"posts/first.md":  "# First\n",
+		"posts/second.md": "# Second\n",
+	})
+	manifestPath := filepath.Join(buildDir, "manifest.json")
+	WithManifest(manifestPath)(gen)
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("first Generate() error = %v", err)
+	}
+
+	// posts/second.html is left in the build directory, which is not cleaned between builds
+	if err := os.Remove(filepath.Join(gen.contentDir, "posts", "second.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := gen.Generate(); err != nil {
+		t.Fatalf("second Generate() error = %v", err)
+	}
+
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		t.Fatalf("manifest.json should be written: %v", err)
+	}
+	var entries []manifestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatalf("manifest.json is not valid JSON: %v", err)
+	}
+
+	paths := make([]string, 0, len(entries))
+	for _, e := range entries {
+		paths = append(paths, e.Path)
+	}
+	if !slices.Contains(paths, "posts/first.html") {
+		t.Errorf("manifest should list posts/first.html, got %v", paths)
+	}
+	if slices.Contains(paths, "posts/second.html") {
+		t.Errorf("manifest should not list the deleted post, got %v", paths)
+	}
+	if !slices.IsSorted(paths) {
+		t.Errorf("manifest should be sorted by path, got %v", paths)
+	}
+}
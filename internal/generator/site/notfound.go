@@ -0,0 +1,46 @@
+package site
+
+import (
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// notFoundPageName is the file name of the not-found page at the build root, served by most hosts for missing URLs.
+const notFoundPageName = "404.html"
+
+// referenceAttributeRe matches the href and src attributes of a page.
+var referenceAttributeRe = regexp.MustCompile(`(\s(?:href|src)=")([^"]*)(")`)
+
+// isNotFoundPage reports whether the markdown file at markDownFilePath is the configured not-found page.
+func (g *Generator) isNotFoundPage(markDownFilePath string) bool {
+	return g.notFoundPage != "" && filepath.Clean(markDownFilePath) == filepath.Clean(g.notFoundPage)
+}
+
+// addNotFoundPage registers the generation of the not-found page, if configured.
+// It is a home page which is neither a section nor listed in the sitemap, feed or search index.
+func (g *Generator) addNotFoundPage() {
+	if g.notFoundPage == "" {
+		return
+	}
+	g.addPage(g.notFoundPage, filepath.Join(g.buildDir, notFoundPageName), "", nil)
+	g.pages[len(g.pages)-1].unlisted = true
+}
+
+// rootRelativeRewriter returns a function rewriting the relative references of the page to root-relative ones.
+// The not-found page is served for any missing URL, where its relative references would not resolve.
+func rootRelativeRewriter(content string) string {
+	return referenceAttributeRe.ReplaceAllStringFunc(content, func(attr string) string {
+		m := referenceAttributeRe.FindStringSubmatch(attr)
+		ref := m[2]
+		if ref == "" || strings.HasPrefix(ref, "#") || strings.HasPrefix(ref, "/") || strings.Contains(ref, ":") {
+			return attr
+		}
+		target, suffix := ref, ""
+		if i := strings.IndexAny(ref, "?#"); i >= 0 {
+			target, suffix = ref[:i], ref[i:]
+		}
+		return m[1] + "/" + path.Clean(target) + suffix + m[3]
+	})
+}
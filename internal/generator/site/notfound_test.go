@@ -0,0 +1,65 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRootRelativeRewriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"relative link", `<a href="posts/index.html">`, `<a href="/posts/index.html">`},
+		{"relative link with fragment", `<a href="./posts/index.html#top">`, `<a href="/posts/index.html#top">`},
+		{"relative source", `<script src="scripts/dark-mode.js">`, `<script src="/scripts/dark-mode.js">`},
+		{"root-relative link", `<a href="/index.html">`, `<a href="/index.html">`},
+		{"fragment", `<a href="#top">`, `<a href="#top">`},
+		{"external link", `<a href="https://example.com/a">`, `<a href="https://example.com/a">`},
+		{"mailto link", `<a href="mailto:me@example.com">`, `<a href="mailto:me@example.com">`},
+		{"meta content", `<meta name="description" content="Page not found">`, `<meta name="description" content="Page not found">`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rootRelativeRewriter(tt.content))
+		})
+	}
+}
+
+func TestIntegration_NotFoundPage(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home",
+		"posts/index.md": "# Posts",
+		"404.md":         "# Page not found\n\nBack to [home](index.md).\n",
+	}
+
+	t.Run("generated at the build root", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithBaseURL("https://example.com"), WithSitemap(true), WithSkipURLValidation(true))
+		WithNotFoundPage(filepath.Join(gen.contentDir, "404.md"))(gen)
+
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+
+		notFound, err := os.ReadFile(filepath.Join(buildDir, "404.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(notFound), "<nav")
+		assert.Contains(t, string(notFound), `href="/posts/index.html"`)
+		assert.Contains(t, string(notFound), `<a href="/index.html">home</a>`)
+
+		sitemap, err := os.ReadFile(filepath.Join(buildDir, "sitemap.xml"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(sitemap), "404")
+	})
+
+	t.Run("not generated by default", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, map[string]string{"index.md": "# Home"})
+
+		assert.NoError(t, gen.Generate())
+		assert.NoFileExists(t, filepath.Join(buildDir, "404.html"))
+	})
+}
@@ -0,0 +1,99 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// checkOutputPaths ensures no two content files are output to the same path, e.g. home.md and index.md
+// both output as index.html by the legacy path strategy, or files of several content roots.
+// Output paths differing only in case, e.g. of About.md and about.md, are reported too.
+// The feeds are checked too, against the content files and against each other, e.g. a WithFeed feed written at the
+// path of the aggregate section feed. It runs before anything is written, so that no output silently overwrites another.
+func (g *Generator) checkOutputPaths() error {
+	sources := make(map[string][]string)
+	outPaths := make(map[string][]string)
+	err := g.walkContent(func(root contentRoot, path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+
+		pathRelToRoot, err := filepath.Rel(root.dir, path)
+		if err != nil {
+			return fmt.Errorf("cannot compute relative path of %s from %s: %w", path, root.dir, err)
+		}
+		if !g.isOutput(path, pathRelToRoot) {
+			return nil
+		}
+
+		// Paths differing only in case are the same file on case-insensitive file systems, e.g. on macOS
+		outPath := g.outputPath(filepath.Join(root.mount, pathRelToRoot))
+		key := strings.ToLower(outPath)
+		outPaths[key] = append(outPaths[key], outPath)
+		sources[key] = append(sources[key], path)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing content files: %w", err)
+	}
+	for outPath, feeds := range g.feedPaths() {
+		key := strings.ToLower(outPath)
+		for _, feed := range feeds {
+			outPaths[key] = append(outPaths[key], outPath)
+			sources[key] = append(sources[key], feed)
+		}
+	}
+
+	errs := make([]error, 0)
+	for _, key := range slices.Sorted(maps.Keys(sources)) {
+		paths := sources[key]
+		if len(paths) < 2 {
+			continue
+		}
+		if distinct := slices.Compact(slices.Sorted(slices.Values(outPaths[key]))); len(distinct) > 1 {
+			errs = append(errs, fmt.Errorf("%s are output at paths differing only in case: %s", strings.Join(paths, ", "), strings.Join(distinct, ", ")))
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s is output from several sources: %s", outPaths[key][0], strings.Join(paths, ", ")))
+	}
+	return errors.Join(errs...)
+}
+
+// isOutput reports whether the content file at path, at relPath relative to its content root,
+// is output to the build directory as a page or a copied file.
+func (g *Generator) isOutput(path, relPath string) bool {
+	if g.isIgnored(relPath) {
+		return false
+	}
+	if !g.isMarkdown(path) {
+		return g.nonMarkdownFiles == NonMarkdownCopy
+	}
+	if g.isNotFoundPage(path) {
+		return false
+	}
+	return g.includeDrafts || !g.isDraft(path)
+}
+
+// feedPaths returns the paths of the configured feeds, with the feeds written at each of them.
+// The feeds of the sections are known when they are listed in WithSectionFeeds.
+func (g *Generator) feedPaths() map[string][]string {
+	paths := make(map[string][]string)
+	if g.feed != nil {
+		path := filepath.Join(g.buildDir, g.feed.path)
+		paths[path] = append(paths[path], "the feed")
+	}
+	if g.sectionFeeds != nil {
+		path := filepath.Join(g.buildDir, g.sectionFeeds.name)
+		paths[path] = append(paths[path], "the aggregate section feed")
+		for _, section := range g.sectionFeeds.sections {
+			path := filepath.Join(g.buildDir, g.outputSection(section), g.sectionFeeds.name)
+			paths[path] = append(paths[path], "the feed of section "+section)
+		}
+	}
+	return paths
+}
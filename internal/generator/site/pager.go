@@ -0,0 +1,84 @@
+package site
+
+import (
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/pager"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/metadata"
+)
+
+// sectionPost is a post of a section, ordered among the other posts of its section
+type sectionPost struct {
+	cfg   *pageConfig
+	title string
+	date  string
+}
+
+// linkAdjacentPosts sets the newer and older posts of every post, among the posts of its section.
+// Posts are ordered by their front matter date, or creation date, most recent first, then by file name.
+// Section indexes, home pages and the pages generated by the site, such as tag pages, are not posts.
+func (g *Generator) linkAdjacentPosts() {
+	bySection := make(map[string][]sectionPost)
+	for i := range g.pageConfigs {
+		cfg := &g.pageConfigs[i]
+		if g.pages[i].section == "" || g.pages[i].unlisted || cfg.source != nil || g.isSectionIndex(cfg.destinationHTMLPath, g.pages[i].section) {
+			continue
+		}
+		post, ok := g.readSectionPost(cfg)
+		if !ok {
+			continue
+		}
+		bySection[g.pages[i].section] = append(bySection[g.pages[i].section], post)
+	}
+
+	for _, posts := range bySection {
+		sort.SliceStable(posts, func(i, j int) bool {
+			if posts[i].date != posts[j].date {
+				return posts[i].date > posts[j].date
+			}
+			return filepath.Base(posts[i].cfg.sourceMDPath) < filepath.Base(posts[j].cfg.sourceMDPath)
+		})
+		for i, post := range posts {
+			if i > 0 {
+				post.cfg.newerPost = adjacentLink(post.cfg, posts[i-1])
+			}
+			if i < len(posts)-1 {
+				post.cfg.olderPost = adjacentLink(post.cfg, posts[i+1])
+			}
+		}
+	}
+}
+
+// readSectionPost reads the title and date of the post generated with cfg.
+// Posts whose front matter cannot be parsed are left out, their page reports the error.
+func (g *Generator) readSectionPost(cfg *pageConfig) (sectionPost, bool) {
+	source, err := g.fs.ReadFile(cfg.sourceMDPath)
+	if err != nil {
+		return sectionPost{}, false
+	}
+	fm, body, err := frontmatter.Parse(source)
+	if err != nil {
+		return sectionPost{}, false
+	}
+
+	post := sectionPost{cfg: cfg, title: fm.Title, date: metadata.Extract(source).CreationDate}
+	if !fm.Date.IsZero() {
+		post.date = fm.Date.Format(time.DateOnly)
+	}
+	if post.title == "" {
+		post.title = markdownTitle(body, sourceName(cfg.sourceMDPath))
+	}
+	return post, true
+}
+
+// adjacentLink returns the link from the page generated with from to post.
+func adjacentLink(from *pageConfig, post sectionPost) pager.Link {
+	href, err := filepath.Rel(filepath.Dir(from.destinationHTMLPath), post.cfg.destinationHTMLPath)
+	if err != nil {
+		return pager.Link{}
+	}
+	return pager.Link{Href: filepath.ToSlash(href), Title: post.title}
+}
@@ -5,22 +5,23 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/tjnvr/blog/internal/generator/page"
 	"github.com/tjnvr/blog/internal/generator/page/filesystem"
 	htmlsubstitutions "github.com/tjnvr/blog/internal/generator/page/html/substitution"
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/bodyclass"
 	"github.com/tjnvr/blog/internal/generator/page/html/validation"
+	"github.com/tjnvr/blog/internal/generator/page/markdown"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
 	mdsubstitutions "github.com/tjnvr/blog/internal/generator/page/markdown/substitution"
 	"github.com/tjnvr/blog/internal/generator/section"
 )
 
 func (g *Generator) generatePages() error {
-	assetsPathTranslater := NewPathResolver(g.assetsDir, filepath.Join(g.buildDir, "assets"))
-	linksPathTranslater := NewPathResolver(g.contentDir, g.buildDir)
-
 	errs := make([]error, 0)
-	err := g.fs.Walk(g.contentDir, func(markDownFilePath string, info os.FileInfo, err error) error {
+	err := g.walkContent(func(root contentRoot, markDownFilePath string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -30,26 +31,64 @@ func (g *Generator) generatePages() error {
 			return nil
 		}
 
+		pathRelToRoot, err := filepath.Rel(root.dir, markDownFilePath)
+		if err != nil {
+			return fmt.Errorf("cannot compute relative path of %s from %s: %w", markDownFilePath, root.dir, err)
+		}
+
+		pageFilePathRelToContentDir := filepath.Join(root.mount, pathRelToRoot)
+
+		if g.isIgnored(pathRelToRoot) {
+			if g.isMarkdown(markDownFilePath) {
+				g.excludedPages[g.outputPath(pageFilePathRelToContentDir)] = "ignored"
+			}
+			return nil
+		}
+
 		// Only Handling markdown files
-		if !strings.HasSuffix(markDownFilePath, ".md") {
-			errs = append(errs, fmt.Errorf("wrong extension for file in %s", markDownFilePath))
+		if !g.isMarkdown(markDownFilePath) {
+			switch g.nonMarkdownFiles {
+			case NonMarkdownError:
+				errs = append(errs, fmt.Errorf("wrong extension for file in %s", markDownFilePath))
+			case NonMarkdownCopy:
+				if err := g.copyColocated(markDownFilePath, pageFilePathRelToContentDir); err != nil {
+					errs = append(errs, err)
+				}
+			}
+			return nil
+		}
+
+		// The not-found page is generated apart from the content pages
+		if g.isNotFoundPage(markDownFilePath) {
+			return nil
+		}
+
+		if !g.includeDrafts && g.isDraft(markDownFilePath) {
+			g.logger.Debug("skipped draft", "source", markDownFilePath)
+			g.excludedPages[g.outputPath(pageFilePathRelToContentDir)] = "draft"
 			return nil
 		}
 
 		// Page section is the directory between content dir and file name
-		pageSection, err := extractSection(g.contentDir, markDownFilePath)
+		pageSection, err := extractSection(root.dir, markDownFilePath)
 		if err != nil {
 			errs = append(errs, err)
 			return nil
 		}
+		pageSection = filepath.ToSlash(filepath.Join(root.mount, pageSection))
+		if pageSection == "." {
+			pageSection = ""
+		}
 
-		pageFilePathRelToContentDir, err := filepath.Rel(g.contentDir, markDownFilePath)
-		if err != nil {
-			return fmt.Errorf("cannot compute relative path of %s from %s: %w", markDownFilePath, g.contentDir, err)
+		if depth := sectionDepth(pageSection); depth > g.maxSectionDepth {
+			if err := g.warn("%s is nested %d sections deep, maximum is %d", markDownFilePath, depth, g.maxSectionDepth); err != nil {
+				errs = append(errs, err)
+				return nil
+			}
 		}
 
-		htmlOutputPath := filepath.Join(g.buildDir, strings.TrimSuffix(pageFilePathRelToContentDir, ".md")+".html")
-		g.pagesGenerators = append(g.pagesGenerators, g.pageGeneratorFactory(markDownFilePath, htmlOutputPath, g.buildDir, pageSection, assetsPathTranslater, linksPathTranslater, g.sections, g.skipURLValidation))
+		htmlOutputPath := g.outputPath(pageFilePathRelToContentDir)
+		g.addPage(markDownFilePath, htmlOutputPath, pageSection, nil)
 		return nil
 	})
 
@@ -57,28 +96,292 @@ func (g *Generator) generatePages() error {
 		errs = append(errs, err)
 	}
 
-	for _, generator := range g.pagesGenerators {
+	if err := g.addTagPages(); err != nil {
+		errs = append(errs, err)
+	}
+	g.addNotFoundPage()
+
+	g.linkAdjacentPosts()
+	for _, cfg := range g.pageConfigs {
+		g.pagesGenerators = append(g.pagesGenerators, g.pageGeneratorFactory(cfg))
+	}
+
+	for i, generator := range g.pagesGenerators {
+		if g.incremental && !g.fingerprint && g.isUpToDate(g.pages[i]) {
+			g.logger.Debug("up to date", "output", g.pages[i].destinationHTMLPath)
+			if err := generator.Load(); err != nil {
+				errs = append(errs, fmt.Errorf("loading %s: %w", g.pages[i].destinationHTMLPath, err))
+			}
+			continue
+		}
+
+		// Keep generating the other pages so that every broken page is reported at once
 		if err := generator.Generate(); err != nil {
-			errs = append(errs, err)
+			errs = append(errs, fmt.Errorf("generating %s: %w", g.pages[i].sourceMDPath, err))
 		}
 	}
 
 	if len(errs) != 0 {
 		// Empty the page generators
+		g.pageConfigs = make([]pageConfig, 0)
 		g.pagesGenerators = make([]PageGenerator, 0)
+		g.pages = make([]generatedPage, 0)
 		return errors.Join(errs...)
 	}
 
 	return nil
 }
 
-func defaultPageGeneratorFactory(sourceMDPath, destinationHTMLPath, buildDir, pageSection string, assetsPathTranslater, linksPathTranslater newPathResolver, sections []section.Section, skipURLValidation bool) PageGenerator {
+// addPage registers the page built from the markdown file at markDownFilePath to htmlOutputPath.
+// Pages without markdown file, such as tag pages, are built from source instead.
+// The page is linked to the sections at their build path, following the path strategy,
+// except to the sections excluded from the navigation.
+func (g *Generator) addPage(markDownFilePath, htmlOutputPath, pageSection string, source []byte) {
+	root := g.rootOf(markDownFilePath)
+	linksPathTranslater := NewPathResolver(root.dir, g.buildDir)
+	linksPathTranslater.mount = root.mount
+	linksPathTranslater.strategy = g.strategy()
+
+	outputSections := g.navSections(g.sections)
+
+	rewrite := g.fingerprintRewriter(htmlOutputPath)
+	if g.isNotFoundPage(markDownFilePath) {
+		fingerprintRewrite := rewrite
+		rewrite = func(content string) string {
+			content = rootRelativeRewriter(content)
+			if fingerprintRewrite != nil {
+				content = fingerprintRewrite(content)
+			}
+			return content
+		}
+	}
+	for _, next := range []func(string) string{g.pictureRewriter(htmlOutputPath), g.cleanURLRewriter(), g.pathPrefixRewriter()} {
+		if next == nil {
+			continue
+		}
+		previous := rewrite
+		rewrite = func(content string) string {
+			if previous != nil {
+				content = previous(content)
+			}
+			return next(content)
+		}
+	}
+
+	g.pageConfigs = append(g.pageConfigs, pageConfig{
+		sourceMDPath:         markDownFilePath,
+		source:               source,
+		destinationHTMLPath:  htmlOutputPath,
+		buildDir:             g.buildDir,
+		pageSection:          g.outputSection(pageSection),
+		pageDepth:            g.pageDepth(htmlOutputPath, pageSection),
+		assetsPathTranslater: NewPathResolver(g.assetsDir, filepath.Join(g.buildDir, g.assetsOutDir)),
+		linksPathTranslater:  linksPathTranslater,
+		sections:             outputSections,
+		skipURLValidation:    g.skipURLValidation,
+		externalTimeout:      g.externalTimeout,
+		noscriptFallbacks:    g.noscriptFallbacks,
+		dateLayout:           g.dateLayout,
+		lastModLayout:        g.lastModLayout,
+		linkCache:            g.linkCache,
+		template:             g.sectionTemplate(pageSection),
+		includeDrafts:        g.includeDrafts,
+		listingExcerpts:      g.listingExcerpts,
+		homeLabel:            g.homeLabel,
+		pageURL:              g.pageLocation(htmlOutputPath),
+		defaultImage:         g.defaultImage,
+		headExtra:            g.headExtra,
+		copyrightStartYear:   g.copyrightStartYear,
+		pathPrefix:           g.pathPrefix,
+		maxImageSize:         g.maxImageSize,
+		navActiveClass:       g.navActiveClass,
+		siteVariables:        g.resolvedSiteVars,
+		strictSiteVariables:  g.strictSiteVariables,
+		markdownExtensions:   g.markdownExtensions,
+		substituters:         g.substituters,
+		validators:           g.validators,
+		converter:            g.converter,
+		converterOptions:     g.converterOptions(),
+		minify:               g.minify,
+		imageDimensions:      g.imageDimensions,
+		rewrite:              rewrite,
+		logger:               g.logger,
+		fs:                   g.fs,
+		allowedClasses:       g.allowedClasses,
+		excludedPages:        g.excludedPages,
+		strictValidation:     g.strictValidation,
+		fileMode:             g.fileMode,
+		dirMode:              g.dirMode,
+	})
+	g.pages = append(g.pages, generatedPage{
+		sourceMDPath:        markDownFilePath,
+		destinationHTMLPath: htmlOutputPath,
+		section:             pageSection,
+	})
+}
+
+// navSections returns the sections shown in the navigation, at their build path.
+func (g *Generator) navSections(sections []section.Section) []section.Section {
+	result := make([]section.Section, 0, len(sections))
+	for _, s := range sections {
+		if slices.Contains(g.navExcluded, s.DirName) {
+			continue
+		}
+		s.DirName = g.outputSection(s.DirName)
+		s.Children = g.navSections(s.Children)
+		result = append(result, s)
+	}
+	return result
+}
+
+// newConverter creates the markdown converter shared by the pages, which is safe for concurrent use.
+func (g *Generator) newConverter() *markdown.Converter {
+	return markdown.NewConverter(g.converterOptions()...)
+}
+
+// converterOptions returns the options of the markdown converter of the pages, from the generator options.
+func (g *Generator) converterOptions() []markdown.Option {
+	opts := []markdown.Option{
+		markdown.WithSyntaxTheme(g.syntaxTheme),
+		markdown.WithMath(g.math),
+		markdown.WithEmoji(g.emoji),
+		markdown.WithAutolink(!g.noAutolink),
+		markdown.WithElementAttributes(g.elementAttributes),
+		markdown.WithLazyImages(g.lazyImages),
+		markdown.WithUnsafeHTML(g.unsafeHTML),
+	}
+	if policy := g.htmlPolicy; policy != nil {
+		if policy.Classes == nil && g.allowedClasses != nil {
+			withClasses := *policy
+			withClasses.Classes = g.allowedClasses
+			policy = &withClasses
+		}
+		opts = append(opts, markdown.WithSanitizer(policy))
+	}
+	if g.externalLinks {
+		opts = append(opts, markdown.WithExternalLinks(g.siteHost(), g.externalLinksNewTab))
+	}
+	if g.headingAnchorClass != "" || g.headingAnchorSymbol != "" {
+		opts = append(opts, markdown.WithHeadingAnchor(g.headingAnchorClass, g.headingAnchorSymbol))
+	}
+	if g.tableWrapper != "" {
+		opts = append(opts, markdown.WithTableWrapper(g.tableWrapper))
+	}
+	if g.codeBlocks != nil {
+		opts = append(opts, markdown.WithCodeBlocks(*g.codeBlocks))
+	}
+	if g.linkIcon != "" {
+		opts = append(opts, markdown.WithExternalLinkIcon(g.siteHost(), g.linkIcon, g.linkIconNoMailto))
+	}
+	if g.conversionCache != nil {
+		opts = append(opts, markdown.WithCache(g.conversionCache))
+	}
+	return opts
+}
+
+func defaultPageGeneratorFactory(cfg pageConfig) PageGenerator {
+	fs := cfg.fs
+	if fs == nil {
+		fs = filesystem.NewOSFileSystem()
+	}
+	converter := cfg.converter
+	if converter == nil {
+		converter = markdown.NewConverter(cfg.converterOptions...)
+	}
+
+	markdownOptions := []mdsubstitutions.Option{
+		mdsubstitutions.WithIncludeDrafts(cfg.includeDrafts),
+		mdsubstitutions.WithFileSystem(fs),
+		mdsubstitutions.WithMarkdownExtensions(cfg.markdownExtensions...),
+	}
+	if cfg.listingExcerpts {
+		markdownOptions = append(markdownOptions, mdsubstitutions.WithExcerpts(converter))
+	}
+	htmlOptions := []htmlsubstitutions.Option{
+		htmlsubstitutions.WithNoscriptFallbacks(cfg.noscriptFallbacks...),
+		htmlsubstitutions.WithDateLayout(cfg.dateLayout),
+		htmlsubstitutions.WithLastModLayout(cfg.lastModLayout),
+		htmlsubstitutions.WithFileSystem(fs),
+		htmlsubstitutions.WithHomeLabel(cfg.homeLabel),
+		htmlsubstitutions.WithPageURL(cfg.pageURL),
+		htmlsubstitutions.WithDefaultImage(cfg.defaultImage),
+		htmlsubstitutions.WithHeadExtra(cfg.headExtra),
+		htmlsubstitutions.WithCopyrightStartYear(cfg.copyrightStartYear),
+		htmlsubstitutions.WithNavigationActiveClass(cfg.navActiveClass),
+		htmlsubstitutions.WithSiteVariables(cfg.siteVariables, cfg.strictSiteVariables),
+		htmlsubstitutions.WithPageDepth(cfg.pageDepth),
+		htmlsubstitutions.WithMarkdownExtensions(cfg.markdownExtensions...),
+		htmlsubstitutions.WithSubstituters(cfg.substituters...),
+		htmlsubstitutions.WithAdjacentPosts(cfg.newerPost, cfg.olderPost),
+	}
+	// The {{body_class}} class of the page is allowed
+	if cfg.allowedClasses != nil {
+		cfg.allowedClasses = append(slices.Clip(cfg.allowedClasses), bodyclass.Class(cfg.pageSection))
+	}
+
 	var (
-		fs                    = filesystem.NewOSFileSystem()
-		markdownSubstitutions = mdsubstitutions.NewRegistry(sourceMDPath)
-		HTMLSubstitutions     = htmlsubstitutions.NewRegistry(destinationHTMLPath, sourceMDPath, assetsPathTranslater, linksPathTranslater, sections, pageSection)
-		validations           = validation.NewRegistry(sections, skipURLValidation)
+		markdownSubstitutions = mdsubstitutions.NewRegistry(cfg.sourceMDPath, markdownOptions...)
+		HTMLSubstitutions     = htmlsubstitutions.NewRegistry(cfg.destinationHTMLPath, cfg.sourceMDPath, cfg.assetsPathTranslater, cfg.linksPathTranslater, cfg.sections, cfg.pageSection, htmlOptions...)
+		validations           = validation.NewRegistry(cfg.sections, cfg.skipURLValidation, cfg.validationOptions()...)
 	)
 
-	return page.NewGenerator(sourceMDPath, destinationHTMLPath, buildDir, pageSection, fs, markdownSubstitutions, HTMLSubstitutions, validations)
+	opts := []page.Option{
+		page.WithTemplate(cfg.template),
+		page.WithConverter(converter),
+		page.WithMinify(cfg.minify),
+		page.WithImageDimensions(cfg.imageDimensions),
+		page.WithRewrite(cfg.rewrite),
+		page.WithSource(cfg.source),
+		page.WithLogger(cfg.logger),
+		page.WithFileMode(cfg.fileMode),
+		page.WithDirMode(cfg.dirMode),
+	}
+
+	return page.NewGenerator(cfg.sourceMDPath, cfg.destinationHTMLPath, cfg.buildDir, cfg.pageSection, fs, markdownSubstitutions, HTMLSubstitutions, validations, opts...)
+}
+
+// validationOptions returns the options of the validators of the page, also used to validate an existing build.
+func (cfg pageConfig) validationOptions() []validation.Option {
+	return []validation.Option{
+		validation.WithLinkCache(cfg.linkCache),
+		validation.WithHomeLabel(cfg.homeLabel),
+		validation.WithClassAllowlist(cfg.allowedClasses),
+		validation.WithExcludedPages(cfg.excludedPages),
+		validation.WithStrictValidation(cfg.strictValidation),
+		validation.WithLogger(cfg.logger),
+		validation.WithExternalTimeout(cfg.externalTimeout),
+		validation.WithPathPrefix(cfg.pathPrefix),
+		validation.WithMaxImageSize(cfg.maxImageSize),
+		validation.WithValidators(cfg.validators...),
+	}
+}
+
+// isDraft reports whether the front matter of the markdown file at path marks it as a draft.
+// Files whose front matter cannot be parsed are not drafts, so that their page reports the error.
+func (g *Generator) isDraft(path string) bool {
+	data, err := g.fs.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	fm, _, err := frontmatter.Parse(data)
+	return err == nil && fm.Draft
+}
+
+// isMarkdown reports whether the content file at path is a markdown source, with one of the markdown extensions.
+func (g *Generator) isMarkdown(path string) bool {
+	return markdown.IsSource(path, g.markdownExtensions)
+}
+
+// sourceExtensions returns the extensions of the markdown sources.
+func (g *Generator) sourceExtensions() []string {
+	if len(g.markdownExtensions) == 0 {
+		return markdown.DefaultExtensions
+	}
+	return g.markdownExtensions
+}
+
+// sourceName returns the file name of the markdown source at path without its extension.
+func sourceName(path string) string {
+	name := filepath.Base(path)
+	return strings.TrimSuffix(name, filepath.Ext(name))
 }
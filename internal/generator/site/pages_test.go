@@ -0,0 +1,53 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegration_MarkdownExtensions(t *testing.T) {
+	t.Run("markdown extension", func(t *testing.T) {
+		files := map[string]string{
+			"index.md":             "# Home\n\n[Hello](posts/hello.markdown)\n",
+			"posts/index.markdown": "# Posts\n\n{{list-child-articles}}\n",
+			"posts/hello.markdown": "# Hello\n\n[Back home](../index.md)\n",
+		}
+
+		gen, buildDir := newIntegrationTestGenerator(t, files)
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+
+		home, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(home), `<a href="posts/hello.html">Hello</a>`)
+
+		posts, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(posts), `<a href="hello.html">Hello</a>`)
+
+		assert.FileExists(t, filepath.Join(buildDir, "posts", "hello.html"))
+	})
+
+	t.Run("configured extensions", func(t *testing.T) {
+		files := map[string]string{
+			"index.md":             "# Home\n\n[Hello](posts/hello.mdown)\n",
+			"posts/index.md":       "# Posts",
+			"posts/hello.mdown":    "# Hello",
+			"posts/notes.markdown": "# Notes",
+		}
+
+		gen, buildDir := newIntegrationTestGenerator(t, files,
+			WithMarkdownExtensions(".md", ".mdown"), WithNonMarkdownFiles(NonMarkdownCopy))
+		assert.NoError(t, gen.Generate())
+
+		home, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(home), `<a href="posts/hello.html">Hello</a>`)
+		assert.FileExists(t, filepath.Join(buildDir, "posts", "hello.html"))
+		assert.FileExists(t, filepath.Join(buildDir, "posts", "notes.markdown"))
+		assert.NoFileExists(t, filepath.Join(buildDir, "posts", "notes.html"))
+	})
+}
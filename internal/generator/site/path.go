@@ -2,12 +2,17 @@ package site
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
 )
 
 type newPathResolver struct {
 	oldPathDirectory, newPathDirectory string
+	// mount is the directory of newPathDirectory the paths of oldPathDirectory are output under
+	mount string
+	// strategy maps paths relative to oldPathDirectory to paths relative to newPathDirectory, they are kept when nil
+	strategy PathStrategy
 }
 
 func NewPathResolver(oldPathDirectory, newPathDirectory string) newPathResolver {
@@ -31,6 +36,10 @@ func (np newPathResolver) GetNewPath(oldPath, fromPath string) (string, error) {
 		return "", fmt.Errorf("oldPath %q is not inside oldPathDirectory %q", oldPath, np.oldPathDirectory)
 	}
 
+	oldPathRelToOldPathDir = filepath.Join(np.mount, oldPathRelToOldPathDir)
+	if np.strategy != nil {
+		oldPathRelToOldPathDir = np.strategy.OutputPath(oldPathRelToOldPathDir)
+	}
 	newPathFromRootDir := filepath.Join(np.newPathDirectory, oldPathRelToOldPathDir)
 
 	// new path relative to fromPath (from fromPath directory)
@@ -41,3 +50,19 @@ func (np newPathResolver) GetNewPath(oldPath, fromPath string) (string, error) {
 
 	return result, nil
 }
+
+// GetRootPath returns the root-relative path of the output of the content file at rootPath,
+// a path from the root of the site content, e.g. /posts/first.md is output at /posts/first.html.
+// Markdown files are output as .html files, following the path strategy.
+func (np newPathResolver) GetRootPath(rootPath string) (string, error) {
+	relPath := strings.TrimPrefix(path.Clean(rootPath), "/")
+	if relPath == "" {
+		return "", fmt.Errorf("rootPath %q is not a file of the content directory", rootPath)
+	}
+
+	strategy := np.strategy
+	if strategy == nil {
+		strategy = MirrorPathStrategy{}
+	}
+	return "/" + filepath.ToSlash(strategy.OutputPath(filepath.FromSlash(relPath))), nil
+}
@@ -85,3 +85,33 @@ func TestGetNewPath(t *testing.T) {
 		})
 	}
 }
+
+func TestGetRootPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		rootPath string
+		strategy PathStrategy
+		want     string
+		wantErr  bool
+	}{
+		{name: "root page", rootPath: "/about.md", want: "/about.html"},
+		{name: "nested page", rootPath: "/posts/first.md", want: "/posts/first.html"},
+		{name: "cleaned path", rootPath: "/posts/../about.md", want: "/about.html"},
+		{name: "path strategy", rootPath: "/posts/first.md", strategy: LegacyPathStrategy{}, want: "/post/first.html"},
+		{name: "content root", rootPath: "/", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := NewPathResolver("content", "build")
+			resolver.strategy = tt.strategy
+			got, err := resolver.GetRootPath(tt.rootPath)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetRootPath(%q) error = %v, wantErr %v", tt.rootPath, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GetRootPath(%q) = %q, want %q", tt.rootPath, got, tt.want)
+			}
+		})
+	}
+}
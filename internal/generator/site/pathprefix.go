@@ -0,0 +1,58 @@
+package site
+
+import (
+	"regexp"
+	"strings"
+)
+
+// prefixedAttributeRe matches the href, src and srcset attributes of a page, the URLs prefixed with the path prefix.
+var prefixedAttributeRe = regexp.MustCompile(`(\s(?:href|src|srcset)=")([^"]*)(")`)
+
+// normalizePathPrefix returns prefix with a leading slash and without trailing slash, e.g. /blog for blog/,
+// or an empty string for the site root.
+func normalizePathPrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if prefix == "" {
+		return ""
+	}
+	return "/" + prefix
+}
+
+// siteURL returns the URL of the site root: the base URL followed by the path prefix,
+// root-relative when no base URL is configured.
+func (g *Generator) siteURL() string {
+	return g.baseURL + g.pathPrefix
+}
+
+// pathPrefixRewriter returns a function prefixing the root-relative references of a page with the path prefix,
+// e.g. /assets/photo.png as /blog/assets/photo.png, so that they resolve when the site is served under it.
+// It returns nil when no path prefix is configured.
+func (g *Generator) pathPrefixRewriter() func(content string) string {
+	if g.pathPrefix == "" {
+		return nil
+	}
+
+	return func(content string) string {
+		return prefixedAttributeRe.ReplaceAllStringFunc(content, func(attr string) string {
+			m := prefixedAttributeRe.FindStringSubmatch(attr)
+			if !strings.HasSuffix(m[1], `srcset="`) {
+				return m[1] + g.prefixedURL(m[2]) + m[3]
+			}
+			// srcset lists candidates separated by commas, each a URL followed by an optional descriptor
+			candidates := strings.Split(m[2], ",")
+			for i, candidate := range candidates {
+				trimmed := strings.TrimLeft(candidate, " ")
+				candidates[i] = candidate[:len(candidate)-len(trimmed)] + g.prefixedURL(trimmed)
+			}
+			return m[1] + strings.Join(candidates, ",") + m[3]
+		})
+	}
+}
+
+// prefixedURL returns ref with the path prefix when it is root-relative, e.g. /styles.css, or ref itself.
+func (g *Generator) prefixedURL(ref string) string {
+	if !strings.HasPrefix(ref, "/") || strings.HasPrefix(ref, "//") {
+		return ref
+	}
+	return g.pathPrefix + ref
+}
@@ -0,0 +1,72 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizePathPrefix(t *testing.T) {
+	for prefix, want := range map[string]string{
+		"":        "",
+		"/":       "",
+		"blog":    "/blog",
+		"/blog/":  "/blog",
+		"a/b/":    "/a/b",
+		"/blog":   "/blog",
+		"//blog/": "/blog",
+	} {
+		assert.Equal(t, want, normalizePathPrefix(prefix), prefix)
+	}
+}
+
+func TestPathPrefixRewriter(t *testing.T) {
+	g := &Generator{pathPrefix: "/blog"}
+	content := `<link href="/styles.css" rel="stylesheet"><a href="first.html">First</a><a href="//cdn.example.com/x.js">CDN</a>` +
+		`<a href="https://example.com/">Out</a><source srcset="/assets/a.webp 1x, /assets/a@2x.webp 2x"><a href="#top">Top</a>`
+	want := `<link href="/blog/styles.css" rel="stylesheet"><a href="first.html">First</a><a href="//cdn.example.com/x.js">CDN</a>` +
+		`<a href="https://example.com/">Out</a><source srcset="/blog/assets/a.webp 1x, /blog/assets/a@2x.webp 2x"><a href="#top">Top</a>`
+	assert.Equal(t, want, g.pathPrefixRewriter()(content))
+
+	assert.Nil(t, (&Generator{}).pathPrefixRewriter())
+}
+
+func TestIntegration_PathPrefix(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Home\n\n![Photo](/assets/images/photo.png)\n\n[First](/posts/first.md)\n",
+		"posts/index.md": "# Posts\n\n[First](first.md)\n",
+		"posts/first.md": "# First\n",
+	},
+		WithPathPrefix("blog/"),
+		WithBaseURL("https://example.com"),
+		WithSitemap(true),
+		WithTemplate(`<html><head><link href="/styles.css" rel="stylesheet">{{canonical}}</head><body>{{navigation}}{{content}}</body></html>`),
+		WithFailOnUnusedAssets(true),
+	)
+	assert.NoError(t, os.MkdirAll(filepath.Join(gen.assetsDir, "images"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(gen.assetsDir, "images", "photo.png"), []byte("photo"), 0644))
+	assert.NoError(t, gen.Generate())
+
+	home, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(home), `<link href="/blog/styles.css" rel="stylesheet">`)
+	assert.Contains(t, string(home), `<img src="/blog/assets/images/photo.png" alt="Photo"`)
+	assert.Contains(t, string(home), `<a href="/blog/posts/first.html">First</a>`)
+	assert.Contains(t, string(home), `<link rel="canonical" href="https://example.com/blog/">`)
+
+	// Relative references are left untouched
+	posts, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(posts), `<a href="first.html">First</a>`)
+
+	sitemap, err := os.ReadFile(filepath.Join(buildDir, "sitemap.xml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(sitemap), "<loc>https://example.com/blog/posts/first.html</loc>")
+
+	// The prefixed references resolve from the build directory
+	assert.NoError(t, os.WriteFile(filepath.Join(buildDir, "styles.css"), []byte("body{}"), 0644))
+	assert.NoError(t, gen.Validate())
+	assert.NoError(t, gen.ValidateBuild(buildDir))
+}
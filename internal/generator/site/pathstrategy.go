@@ -0,0 +1,174 @@
+package site
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+type (
+	// PathStrategy maps the path of a content file, relative to the content directory,
+	// to the path of its output relative to the build directory.
+	// Markdown pages are output as .html files, a path already ending in .html is mapped as its markdown source.
+	PathStrategy interface {
+		OutputPath(relPath string) string
+	}
+
+	// MirrorPathStrategy outputs files at the same path as in the content directory, markdown pages as .html files.
+	MirrorPathStrategy struct{}
+
+	// LegacyPathStrategy outputs files as MirrorPathStrategy, except for home.md output as index.html
+	// and the posts directory output in a singular post directory.
+	LegacyPathStrategy struct{}
+
+	// CleanURLPathStrategy outputs files as Base, MirrorPathStrategy when nil, except for the pages other than
+	// the index pages output in a directory of their own, e.g. posts/hello.html as posts/hello/index.html,
+	// so that they are served at clean URLs such as /posts/hello/.
+	CleanURLPathStrategy struct {
+		Base PathStrategy
+	}
+
+	// HomePagePathStrategy outputs Home, a markdown file at the root of the content directory, as index.html
+	// and the other root files at the same path as MirrorPathStrategy. Files of subdirectories are output as Base,
+	// MirrorPathStrategy when nil.
+	HomePagePathStrategy struct {
+		Base PathStrategy
+		Home string
+	}
+
+	// LowercasePathStrategy outputs files as Base, MirrorPathStrategy when nil, at lowercase paths,
+	// e.g. Notes/About.md as notes/about.html.
+	LowercasePathStrategy struct {
+		Base PathStrategy
+	}
+)
+
+func (MirrorPathStrategy) OutputPath(relPath string) string {
+	if strings.HasSuffix(relPath, ".md") {
+		return strings.TrimSuffix(relPath, ".md") + ".html"
+	}
+	return relPath
+}
+
+func (LegacyPathStrategy) OutputPath(relPath string) string {
+	outPath := filepath.ToSlash(MirrorPathStrategy{}.OutputPath(relPath))
+	if outPath == "home.html" {
+		return "index.html"
+	}
+	if rest, ok := strings.CutPrefix(outPath, "posts/"); ok {
+		outPath = "post/" + rest
+	}
+	return filepath.FromSlash(outPath)
+}
+
+func (s CleanURLPathStrategy) OutputPath(relPath string) string {
+	base := s.Base
+	if base == nil {
+		base = MirrorPathStrategy{}
+	}
+	outPath := base.OutputPath(relPath)
+	if filepath.Ext(outPath) != ".html" || filepath.Base(outPath) == "index.html" {
+		return outPath
+	}
+	return filepath.Join(strings.TrimSuffix(outPath, ".html"), "index.html")
+}
+
+func (s HomePagePathStrategy) OutputPath(relPath string) string {
+	if filepath.Dir(relPath) != "." {
+		base := s.Base
+		if base == nil {
+			base = MirrorPathStrategy{}
+		}
+		return base.OutputPath(relPath)
+	}
+	outPath := MirrorPathStrategy{}.OutputPath(relPath)
+	if outPath == (MirrorPathStrategy{}).OutputPath(s.Home) {
+		return "index.html"
+	}
+	return outPath
+}
+
+func (s LowercasePathStrategy) OutputPath(relPath string) string {
+	base := s.Base
+	if base == nil {
+		base = MirrorPathStrategy{}
+	}
+	return strings.ToLower(base.OutputPath(relPath))
+}
+
+// strategy returns the path strategy of the generator, with the home page and output at clean URLs and lowercase paths when enabled.
+func (g *Generator) strategy() PathStrategy {
+	strategy := g.pathStrategy
+	if g.homePage != "" {
+		strategy = HomePagePathStrategy{Base: strategy, Home: g.homePage}
+	}
+	if g.cleanURLs {
+		strategy = CleanURLPathStrategy{Base: strategy}
+	}
+	if g.lowercaseOutputPaths {
+		strategy = LowercasePathStrategy{Base: strategy}
+	}
+	return strategy
+}
+
+// isSectionIndex reports whether the page generated at htmlPath is the index page of the content section.
+func (g *Generator) isSectionIndex(htmlPath, contentSection string) bool {
+	return filepath.Clean(htmlPath) == filepath.Join(g.buildDir, g.outputSection(contentSection), "index.html")
+}
+
+// checkHomePage ensures the home page, if any, is a markdown file at the root of the content directory.
+// Its markdown extension is normalized to .md, as the path strategies output the .md sources.
+func (g *Generator) checkHomePage() error {
+	if g.homePage == "" {
+		return nil
+	}
+	if !g.isMarkdown(g.homePage) || filepath.Dir(filepath.Clean(g.homePage)) != "." {
+		return fmt.Errorf("invalid home page %q: not a markdown file at the root of the content directory", g.homePage)
+	}
+	g.homePage = strings.TrimSuffix(filepath.Clean(g.homePage), filepath.Ext(g.homePage)) + ".md"
+	return nil
+}
+
+// outputPath returns the path in the build directory of the content file at relPath, relative to the content directory.
+func (g *Generator) outputPath(relPath string) string {
+	if g.isMarkdown(relPath) {
+		// The path strategies output the .md sources
+		relPath = strings.TrimSuffix(relPath, filepath.Ext(relPath)) + ".md"
+	}
+	return filepath.Join(g.buildDir, g.strategy().OutputPath(relPath))
+}
+
+// outputSection returns the directory of the build directory holding the pages of a content section.
+func (g *Generator) outputSection(contentSection string) string {
+	dir := filepath.Dir(g.strategy().OutputPath(filepath.Join(contentSection, "index.md")))
+	if dir == "." {
+		return ""
+	}
+	return filepath.ToSlash(dir)
+}
+
+// sectionIndexCandidates are the markdown files which may be output as the index page of a section.
+var sectionIndexCandidates = []string{"index.md", "home.md"}
+
+// sectionIndexSource returns the path of the markdown file output as the index page of a content section,
+// or the path of its index.md when there is none.
+func (g *Generator) sectionIndexSource(contentSection string) (string, bool) {
+	indexPath := filepath.Join(g.outputSection(contentSection), "index.html")
+	candidates := sectionIndexCandidates
+	if contentSection == "" && g.homePage != "" {
+		candidates = []string{g.homePage}
+	}
+	for _, name := range candidates {
+		relPath := filepath.Join(contentSection, name)
+		if g.strategy().OutputPath(relPath) != indexPath {
+			continue
+		}
+		for _, ext := range g.sourceExtensions() {
+			if path, ok := g.contentSource(strings.TrimSuffix(relPath, ".md") + ext); ok {
+				return path, true
+			}
+		}
+	}
+	path, _ := g.contentSource(filepath.Join(contentSection, "index.md"))
+	return path, false
+}
@@ -0,0 +1,266 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPathStrategy_OutputPath(t *testing.T) {
+	tests := []struct {
+		relPath    string
+		wantMirror string
+		wantLegacy string
+	}{
+		{relPath: "index.md", wantMirror: "index.html", wantLegacy: "index.html"},
+		{relPath: "home.md", wantMirror: "home.html", wantLegacy: "index.html"},
+		{relPath: "about/index.md", wantMirror: "about/index.html", wantLegacy: "about/index.html"},
+		{relPath: "posts/index.md", wantMirror: "posts/index.html", wantLegacy: "post/index.html"},
+		{relPath: "posts/hello.md", wantMirror: "posts/hello.html", wantLegacy: "post/hello.html"},
+		{relPath: "posts/hello.html", wantMirror: "posts/hello.html", wantLegacy: "post/hello.html"},
+		{relPath: "posts/photo.png", wantMirror: "posts/photo.png", wantLegacy: "post/photo.png"},
+		{relPath: "postscript/a.md", wantMirror: "postscript/a.html", wantLegacy: "postscript/a.html"},
+		{relPath: "about/home.md", wantMirror: "about/home.html", wantLegacy: "about/home.html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relPath, func(t *testing.T) {
+			assert.Equal(t, tt.wantMirror, MirrorPathStrategy{}.OutputPath(tt.relPath))
+			assert.Equal(t, tt.wantLegacy, LegacyPathStrategy{}.OutputPath(tt.relPath))
+		})
+	}
+}
+
+func TestCleanURLPathStrategy_OutputPath(t *testing.T) {
+	tests := []struct {
+		relPath    string
+		wantMirror string
+		wantLegacy string
+	}{
+		{relPath: "index.md", wantMirror: "index.html", wantLegacy: "index.html"},
+		{relPath: "home.md", wantMirror: "home/index.html", wantLegacy: "index.html"},
+		{relPath: "about.md", wantMirror: "about/index.html", wantLegacy: "about/index.html"},
+		{relPath: "posts/index.md", wantMirror: "posts/index.html", wantLegacy: "post/index.html"},
+		{relPath: "posts/hello.md", wantMirror: "posts/hello/index.html", wantLegacy: "post/hello/index.html"},
+		{relPath: "posts/photo.png", wantMirror: "posts/photo.png", wantLegacy: "post/photo.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relPath, func(t *testing.T) {
+			assert.Equal(t, tt.wantMirror, CleanURLPathStrategy{Base: MirrorPathStrategy{}}.OutputPath(tt.relPath))
+			assert.Equal(t, tt.wantLegacy, CleanURLPathStrategy{Base: LegacyPathStrategy{}}.OutputPath(tt.relPath))
+		})
+	}
+}
+
+func TestCleanURL(t *testing.T) {
+	g := &Generator{baseURL: "https://example.com"}
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{ref: "index.html", want: "./"},
+		{ref: "../index.html#top", want: "../#top"},
+		{ref: "hello/index.html?page=2", want: "hello/?page=2"},
+		{ref: "/posts/index.html", want: "/posts/"},
+		{ref: "https://example.com/posts/index.html", want: "https://example.com/posts/"},
+		{ref: "https://other.com/index.html", want: "https://other.com/index.html"},
+		{ref: "hello.html", want: "hello.html"},
+		{ref: "style.css", want: "style.css"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			assert.Equal(t, tt.want, g.cleanURL(tt.ref))
+		})
+	}
+}
+
+func TestIntegration_CleanURLs(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":       "# Home\n\n[Hello](posts/hello.md)\n",
+		"posts/index.md": "# Posts\n\n[Hello](hello.md)\n",
+		"posts/hello.md": "# Hello\n\n[Back home](../index.md)\n\n[Posts](index.md)\n",
+	}, WithCleanURLs(true), WithBaseURL("https://example.com"), WithSitemap(true))
+
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate())
+
+	home, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(home), `<a href="posts/hello/">Hello</a>`)
+	assert.Contains(t, string(home), `<a href="posts/" class="hover:underline">Posts</a>`)
+
+	posts, err := os.ReadFile(filepath.Join(buildDir, "posts", "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(posts), `<a href="hello/">Hello</a>`)
+
+	hello, err := os.ReadFile(filepath.Join(buildDir, "posts", "hello", "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(hello), `<a href="../../">Back home</a>`)
+	assert.Contains(t, string(hello), `<a href="../">Posts</a>`)
+	assert.Contains(t, string(hello), `<a href="../../posts/" aria-current="true" class="font-semibold underline">Posts</a>`)
+	assert.NoFileExists(t, filepath.Join(buildDir, "posts", "hello.html"))
+
+	sitemap, err := os.ReadFile(filepath.Join(buildDir, "sitemap.xml"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(sitemap), "<loc>https://example.com/posts/hello/</loc>")
+}
+
+func TestIntegration_LegacyPathStrategy(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"home.md":        "# Home\n\n[Hello](posts/hello.md)\n",
+		"posts/index.md": "# Posts\n\n[Hello](hello.md)\n",
+		"posts/hello.md": "# Hello\n\n[Back home](../home.md)\n",
+	}, WithPathStrategy(LegacyPathStrategy{}))
+
+	assert.NoError(t, gen.Generate())
+	assert.NoError(t, gen.Validate())
+
+	home, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(home), `<a href="post/hello.html">Hello</a>`)
+	assert.Contains(t, string(home), `<a href="post/index.html" class="hover:underline">Posts</a>`)
+
+	hello, err := os.ReadFile(filepath.Join(buildDir, "post", "hello.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(hello), `<a href="../index.html">Back home</a>`)
+	assert.Contains(t, string(hello), `<a href="../post/index.html" aria-current="true" class="font-semibold underline">Posts</a>`)
+
+	assert.FileExists(t, filepath.Join(buildDir, "post", "index.html"))
+	assert.NoDirExists(t, filepath.Join(buildDir, "posts"))
+}
+
+func TestGenerate_DuplicateOutputPaths(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"home.md":  "# Home\n",
+		"index.md": "# Index\n",
+	}, WithPathStrategy(LegacyPathStrategy{}))
+
+	err := gen.Generate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), filepath.Join(buildDir, "index.html")+" is output from several sources: ")
+	assert.Contains(t, err.Error(), filepath.Join(gen.contentDir, "home.md"))
+	assert.Contains(t, err.Error(), filepath.Join(gen.contentDir, "index.md"))
+
+	// Nothing is written before the conflict is reported
+	assert.NoFileExists(t, filepath.Join(buildDir, "index.html"))
+}
+
+func TestLowercasePathStrategy_OutputPath(t *testing.T) {
+	assert.Equal(t, "notes/about.html", LowercasePathStrategy{}.OutputPath("Notes/About.md"))
+	assert.Equal(t, "post/photo.png", LowercasePathStrategy{Base: LegacyPathStrategy{}}.OutputPath("posts/Photo.PNG"))
+	assert.Equal(t, "notes/about/index.html",
+		LowercasePathStrategy{Base: CleanURLPathStrategy{}}.OutputPath("Notes/About.md"))
+}
+
+func TestGenerate_CaseOnlyOutputPaths(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home\n",
+		"About.md": "# About\n",
+		"about.md": "# about\n",
+	})
+
+	err := gen.Generate()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "output at paths differing only in case: ")
+	assert.Contains(t, err.Error(), filepath.Join(gen.contentDir, "About.md"))
+	assert.Contains(t, err.Error(), filepath.Join(gen.contentDir, "about.md"))
+	assert.NoFileExists(t, filepath.Join(buildDir, "about.html"))
+
+	t.Run("lowercase output paths", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, map[string]string{
+			"index.md": "# Home\n",
+			"About.md": "# About\n",
+			"about.md": "# about\n",
+		}, WithLowercaseOutputPaths(true))
+
+		err := gen.Generate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), " is output from several sources: ")
+	})
+}
+
+func TestIntegration_LowercaseOutputPaths(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"index.md":             "# Home\n\n[About](Notes/About-Me.md)\n",
+		"Notes/index.md":       "# Notes\n",
+		"Notes/About-Me.md":    "# About me\n\n[Home](../index.md)\n",
+		"Notes/Diagram-01.txt": "diagram",
+	}, WithLowercaseOutputPaths(true))
+
+	assert.NoError(t, gen.Generate())
+
+	home, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(home), `<a href="notes/about-me.html">About</a>`)
+	assert.Contains(t, string(home), `href="notes/index.html"`)
+
+	assert.FileExists(t, filepath.Join(buildDir, "notes", "about-me.html"))
+	assert.FileExists(t, filepath.Join(buildDir, "notes", "diagram-01.txt"))
+	assert.NoFileExists(t, filepath.Join(buildDir, "Notes", "About-Me.html"))
+}
+
+func TestHomePagePathStrategy_OutputPath(t *testing.T) {
+	tests := []struct {
+		relPath    string
+		wantMirror string
+		wantLegacy string
+	}{
+		{relPath: "landing.md", wantMirror: "index.html", wantLegacy: "index.html"},
+		{relPath: "landing.html", wantMirror: "index.html", wantLegacy: "index.html"},
+		{relPath: "home.md", wantMirror: "home.html", wantLegacy: "home.html"},
+		{relPath: "about.md", wantMirror: "about.html", wantLegacy: "about.html"},
+		{relPath: "posts/landing.md", wantMirror: "posts/landing.html", wantLegacy: "post/landing.html"},
+		{relPath: "posts/index.md", wantMirror: "posts/index.html", wantLegacy: "post/index.html"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.relPath, func(t *testing.T) {
+			assert.Equal(t, tt.wantMirror, HomePagePathStrategy{Home: "landing.md"}.OutputPath(tt.relPath))
+			assert.Equal(t, tt.wantLegacy, HomePagePathStrategy{Base: LegacyPathStrategy{}, Home: "landing.md"}.OutputPath(tt.relPath))
+		})
+	}
+}
+
+func TestIntegration_HomePage(t *testing.T) {
+	gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+		"landing.md":     "# Welcome\n\n[Posts](posts/index.md)\n",
+		"home.md":        "# Home sweet home\n\n[Back](landing.md)\n",
+		"posts/index.md": "# Posts\n",
+	}, WithHomePage("landing.md"), WithPathStrategy(LegacyPathStrategy{}))
+	assert.NoError(t, gen.Generate())
+
+	index, err := os.ReadFile(filepath.Join(buildDir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), "<title>Welcome</title>")
+	assert.Contains(t, string(index), `<a href="index.html" aria-current="page" class="font-semibold underline">Welcome</a>`)
+
+	home, err := os.ReadFile(filepath.Join(buildDir, "home.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(home), `<a href="index.html">Back</a>`)
+	assert.NoFileExists(t, filepath.Join(buildDir, "landing.html"))
+
+	t.Run("index.md is not the home page", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, map[string]string{
+			"landing.md": "# Welcome\n",
+			"index.md":   "# Index\n",
+		}, WithHomePage("landing.md"))
+		err := gen.Generate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), " is output from several sources: ")
+	})
+}
+
+func TestNewGenerator_InvalidHomePage(t *testing.T) {
+	for _, relPath := range []string{"posts/landing.md", "landing.txt"} {
+		_, err := NewGenerator(WithHomePage(relPath))
+		assert.ErrorContains(t, err, "invalid home page")
+	}
+
+	g, err := NewGenerator(WithHomePage("landing.markdown"))
+	assert.NoError(t, err)
+	assert.Equal(t, "landing.md", g.homePage)
+}
@@ -0,0 +1,100 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/validation"
+)
+
+// BuildReport groups the failures of the last validation of the site by file and by validator,
+// to summarize a failed build instead of listing every failure.
+type BuildReport struct {
+	// Failures are the validation failures, in the order they were reported.
+	// Failures not reported by a validator, such as an unreadable page, have no file nor validator.
+	Failures []validation.Failure
+}
+
+// newBuildReport returns the report of the failures joined in err.
+func newBuildReport(err error) BuildReport {
+	var r BuildReport
+	r.collect(err)
+	return r
+}
+
+// collect adds the failures joined in err to the report.
+func (r *BuildReport) collect(err error) {
+	if err == nil {
+		return
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, e := range joined.Unwrap() {
+			r.collect(e)
+		}
+		return
+	}
+	var f validation.Failure
+	if !errors.As(err, &f) {
+		f = validation.Failure{Err: err}
+	}
+	r.Failures = append(r.Failures, f)
+}
+
+// Report returns the report of the last Validate or ValidateBuild, which is empty when it succeeded.
+func (g *Generator) Report() BuildReport {
+	return g.report
+}
+
+// ByFile returns the failures grouped by the path of their page.
+func (r BuildReport) ByFile() map[string][]validation.Failure {
+	return r.groupBy(func(f validation.Failure) string { return f.File })
+}
+
+// ByValidator returns the failures grouped by the name of their validator, e.g. "link".
+func (r BuildReport) ByValidator() map[string][]validation.Failure {
+	return r.groupBy(func(f validation.Failure) string { return f.Validator })
+}
+
+func (r BuildReport) groupBy(key func(validation.Failure) string) map[string][]validation.Failure {
+	groups := make(map[string][]validation.Failure)
+	for _, f := range r.Failures {
+		groups[key(f)] = append(groups[key(f)], f)
+	}
+	return groups
+}
+
+// Summary returns a line per validator, sorted by name after the failures of no validator, counting its failures and the files they are in,
+// e.g. "3 link failures across 2 files". It is empty when there is no failure.
+func (r BuildReport) Summary() string {
+	byValidator := r.ByValidator()
+	names := make([]string, 0, len(byValidator))
+	for name := range byValidator {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	lines := make([]string, 0, len(names))
+	for _, name := range names {
+		failures := byValidator[name]
+		files := make(map[string]bool)
+		for _, f := range failures {
+			files[f.File] = true
+		}
+		label := name
+		if label == "" {
+			label = "other"
+		}
+		lines = append(lines, fmt.Sprintf("%s across %s", plural(len(failures), label+" failure"), plural(len(files), "file")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// plural returns n followed by noun, with an s when n is not 1.
+func plural(n int, noun string) string {
+	if n == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
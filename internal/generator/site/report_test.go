@@ -0,0 +1,50 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation"
+)
+
+func TestNewBuildReport(t *testing.T) {
+	failure := func(file, validator string) error {
+		return validation.Failure{File: file, Validator: validator, Err: fmt.Errorf("%s: %s failure", file, validator)}
+	}
+	err := errors.Join(
+		errors.Join(failure("a.html", "link"), failure("a.html", "link"), failure("a.html", "id")),
+		errors.Join(failure("b.html", "link")),
+		errors.New("walking build: permission denied"),
+	)
+
+	r := newBuildReport(err)
+	assert.Len(t, r.Failures, 5)
+	assert.Len(t, r.ByFile()["a.html"], 3)
+	assert.Len(t, r.ByFile()["b.html"], 1)
+	assert.Len(t, r.ByValidator()["link"], 3)
+	assert.Len(t, r.ByValidator()["id"], 1)
+	assert.Equal(t, "1 other failure across 1 file\n1 id failure across 1 file\n3 link failures across 2 files", r.Summary())
+
+	assert.Empty(t, newBuildReport(nil).Failures)
+	assert.Empty(t, newBuildReport(nil).Summary())
+}
+
+func TestIntegration_BuildReport(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n\n[Missing](missing.md)\n\n[Gone](gone.md)\n",
+		"posts/index.md": "# Posts\n\n[Missing](missing.md)\n",
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files)
+	assert.NoError(t, gen.Generate())
+	assert.Error(t, gen.Validate())
+
+	r := gen.Report()
+	assert.Len(t, r.ByValidator()["link"], 3)
+	assert.Len(t, r.ByFile()[filepath.Join(buildDir, "index.html")], 2)
+	assert.Len(t, r.ByFile()[filepath.Join(buildDir, "posts", "index.html")], 1)
+	assert.Equal(t, "3 link failures across 2 files", r.Summary())
+}
@@ -0,0 +1,34 @@
+package site
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// defaultRobotsRules lets every crawler in, used when robots.txt is enabled without rules.
+const defaultRobotsRules = "User-agent: *\nAllow: /"
+
+// generateRobots writes robots.txt at the build root with the configured rules.
+// The sitemap is referenced when it is generated and a base URL is configured, as crawlers expect an absolute URL.
+func (g *Generator) generateRobots() error {
+	if !g.robots {
+		return nil
+	}
+
+	rules := strings.TrimSpace(g.robotsRules)
+	if rules == "" {
+		rules = defaultRobotsRules
+	}
+	content := rules + "\n"
+	if g.sitemap && g.baseURL != "" {
+		content += fmt.Sprintf("\nSitemap: %s/sitemap.xml\n", g.siteURL())
+	}
+
+	robotsPath := filepath.Join(g.buildDir, "robots.txt")
+	if err := g.writeOutput(robotsPath, []byte(content)); err != nil {
+		return fmt.Errorf("writing %s: %w", robotsPath, err)
+	}
+	g.logger.Info("generated robots.txt", "path", robotsPath)
+	return nil
+}
@@ -0,0 +1,50 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateRobots(t *testing.T) {
+	files := map[string]string{
+		"index.md": "# Home\n",
+	}
+
+	t.Run("rules and sitemap", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files,
+			WithBaseURL("https://example.org/"), WithSitemap(true), WithRobots("User-agent: *\nDisallow: /drafts/"))
+		assert.NoError(t, gen.Generate())
+
+		data, err := os.ReadFile(filepath.Join(buildDir, "robots.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, "User-agent: *\nDisallow: /drafts/\n\nSitemap: https://example.org/sitemap.xml\n", string(data))
+	})
+
+	t.Run("default rules without sitemap", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithBaseURL("https://example.org/"), WithRobots(""))
+		assert.NoError(t, gen.Generate())
+
+		data, err := os.ReadFile(filepath.Join(buildDir, "robots.txt"))
+		assert.NoError(t, err)
+		assert.Equal(t, "User-agent: *\nAllow: /\n", string(data))
+	})
+
+	t.Run("no sitemap line without base url", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithSitemap(true), WithRobots(""))
+		assert.NoError(t, gen.Generate())
+
+		data, err := os.ReadFile(filepath.Join(buildDir, "robots.txt"))
+		assert.NoError(t, err)
+		assert.NotContains(t, string(data), "Sitemap:")
+	})
+
+	t.Run("no robots.txt by default", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithSitemap(true))
+		assert.NoError(t, gen.Generate())
+
+		assert.NoFileExists(t, filepath.Join(buildDir, "robots.txt"))
+	})
+}
@@ -0,0 +1,97 @@
+package site
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/title"
+	"github.com/tjnvr/blog/internal/generator/page/htmltext"
+)
+
+// searchExcerptLength is the maximum number of characters of the page text kept in the search index.
+const searchExcerptLength = 300
+
+// searchIgnoredElementsRe matches the elements of a page which are not part of its text content.
+var searchIgnoredElementsRe = regexp.MustCompile(`(?is)<head[^>]*>.*?</head>|<script[^>]*>.*?</script>|<style[^>]*>.*?</style>|<header[^>]*>.*?</header>|<nav[^>]*>.*?</nav>|<footer[^>]*>.*?</footer>|<h1[^>]*>.*?</h1>`)
+
+// searchMainRe matches the main content of a page, such as <main id="main-content"> in the page template.
+var searchMainRe = regexp.MustCompile(`(?is)<main[^>]*>(.*?)</main>`)
+
+// searchEntry is a page of the search index
+type searchEntry struct {
+	URL     string `json:"url"`
+	Title   string `json:"title"`
+	Excerpt string `json:"excerpt"`
+}
+
+// generateSearchIndex writes search-index.json at the build root with one entry per generated page.
+func (g *Generator) generateSearchIndex() error {
+	if !g.searchIndex {
+		return nil
+	}
+
+	entries, err := g.searchEntries()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("encoding search index: %w", err)
+	}
+
+	indexPath := filepath.Join(g.buildDir, "search-index.json")
+	if err := g.writeOutput(indexPath, data); err != nil {
+		return fmt.Errorf("writing %s: %w", indexPath, err)
+	}
+	g.logger.Info("generated search index", "path", indexPath)
+	return nil
+}
+
+// searchEntries assembles the search index from the generated pages content.
+// Drafts and ignored files are not generated and thus not indexed.
+func (g *Generator) searchEntries() ([]searchEntry, error) {
+	entries := make([]searchEntry, 0, len(g.pages))
+	for _, p := range g.pages {
+		if p.unlisted {
+			continue
+		}
+		content, err := g.fs.ReadFile(p.destinationHTMLPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p.destinationHTMLPath, err)
+		}
+
+		pageTitle, _ := title.NewSubstituer().Resolve(string(content))
+		entries = append(entries, searchEntry{
+			URL:     g.pageLocation(p.destinationHTMLPath),
+			Title:   html.UnescapeString(pageTitle),
+			Excerpt: searchExcerpt(string(content), searchExcerptLength),
+		})
+	}
+	return entries, nil
+}
+
+// searchExcerpt returns the plain text of the main content of an HTML page, or of its body without <main> element,
+// whitespace collapsed, truncated to maxLength characters.
+func searchExcerpt(content string, maxLength int) string {
+	text := htmltext.StripTags(mainContent(content), " ")
+	text = strings.Join(strings.Fields(text), " ")
+
+	if runes := []rune(text); len(runes) > maxLength {
+		text = strings.TrimSpace(string(runes[:maxLength]))
+	}
+	return text
+}
+
+// mainContent returns the HTML of the main content of a page, or of the page without <main> element,
+// without the elements which are not part of its text content such as the navigation.
+func mainContent(content string) string {
+	if m := searchMainRe.FindStringSubmatch(content); m != nil {
+		content = m[1]
+	}
+	return searchIgnoredElementsRe.ReplaceAllString(content, " ")
+}
@@ -0,0 +1,88 @@
+package site
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateSearchIndex(t *testing.T) {
+	t.Run("one entry per generated page", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, map[string]string{
+			"index.md":       "# Home\n\nWelcome   to my\nblog &amp; notes.\n",
+			"posts/index.md": "# Posts\n",
+			"posts/first.md": "# First\n\nFirst post.\n",
+			"posts/draft.md": "---\ndraft: true\n---\n# Draft\n",
+			"_notes/todo.md": "# Todo\n",
+		}, WithBaseURL("https://example.org"), WithSearchIndex(true), WithIgnore("_notes"))
+
+		assert.NoError(t, gen.Generate())
+
+		data, err := os.ReadFile(filepath.Join(buildDir, "search-index.json"))
+		assert.NoError(t, err)
+
+		var entries []searchEntry
+		assert.NoError(t, json.Unmarshal(data, &entries))
+
+		urls := make([]string, 0, len(entries))
+		for _, e := range entries {
+			urls = append(urls, e.URL)
+		}
+		assert.ElementsMatch(t, []string{
+			"https://example.org/",
+			"https://example.org/posts/",
+			"https://example.org/posts/first.html",
+		}, urls)
+
+		for _, e := range entries {
+			if e.URL == "https://example.org/" {
+				assert.Equal(t, "Home", e.Title)
+				assert.Equal(t, "Welcome to my blog & notes.", e.Excerpt)
+			}
+		}
+	})
+
+	t.Run("not written by default", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, map[string]string{"index.md": "# Home\n"})
+		assert.NoError(t, gen.Generate())
+		assert.NoFileExists(t, filepath.Join(buildDir, "search-index.json"))
+	})
+}
+
+func TestSearchExcerpt(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		maxLength int
+		want      string
+	}{
+		{
+			name:      "strips tags and collapses whitespace",
+			content:   "<p>Hello\n  <em>world</em></p>\n<p>again</p>",
+			maxLength: 100,
+			want:      "Hello world again",
+		},
+		{
+			name:      "skips head, scripts, navigation and title",
+			content:   `<head><title>T</title></head><body><nav><a href="/">Home</a></nav><h1 id="t">T</h1><script>x()</script><p>Text</p></body>`,
+			maxLength: 100,
+			want:      "Text",
+		},
+		{
+			name:      "truncates long text",
+			content:   "<p>" + strings.Repeat("a", 20) + "</p>",
+			maxLength: 10,
+			want:      strings.Repeat("a", 10),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, searchExcerpt(tt.content, tt.maxLength))
+		})
+	}
+}
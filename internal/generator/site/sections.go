@@ -1,6 +1,7 @@
 package site
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,8 +10,12 @@ import (
 	"github.com/tjnvr/blog/internal/generator/section"
 )
 
+// listSections lists the sections of every content root. Sections found in several roots are listed once.
+// Sections whose index page is a draft are not listed, unless drafts are included.
+// Sections are sorted alphabetically, the home section first, whatever the order the directories are walked in;
+// the navigation configuration reorders them.
 func (g *Generator) listSections() error {
-	return g.fs.Walk(g.contentDir, func(path string, info os.FileInfo, err error) error {
+	err := g.walkContent(func(root contentRoot, path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -19,19 +24,38 @@ func (g *Generator) listSections() error {
 			return nil
 		}
 
-		relPath, err := filepath.Rel(g.contentDir, path)
+		pathRelToRoot, err := filepath.Rel(root.dir, path)
 		if err != nil {
 			return err
 		}
 
-		// Only process top-level directories (and the root itself)
+		if g.isIgnored(pathRelToRoot) {
+			return nil
+		}
+
+		relPath := filepath.ToSlash(filepath.Join(root.mount, pathRelToRoot))
+		if section.Contains(g.sections, relPath) || (relPath == "." && section.Contains(g.sections, "")) {
+			return nil
+		}
+
+		// Nested directories are sections of their parent section when they have an index page
 		if strings.Contains(relPath, "/") {
+			indexPath, ok := g.sectionIndexSource(relPath)
+			if !ok || g.isDraftSection(indexPath) {
+				return nil
+			}
+			nested := section.Section{
+				DirName:     relPath,
+				DisplayName: g.extractSectionTitle(indexPath, filepath.Base(relPath)),
+			}
+			g.sections = addNestedSection(g.sections, nested)
 			return nil
 		}
 
 		// Root content directory: home section has an empty DirName
 		if relPath == "." {
-			displayName := g.extractSectionTitle(filepath.Join(path, "index.md"), "home")
+			indexPath, _ := g.sectionIndexSource("")
+			displayName := g.extractSectionTitle(indexPath, "home")
 			g.sections = append(g.sections, section.Section{
 				DirName:     "",
 				DisplayName: displayName,
@@ -40,13 +64,91 @@ func (g *Generator) listSections() error {
 		}
 
 		// Sub-section: read display name from # title in section's index.md
-		displayName := g.extractSectionTitle(filepath.Join(path, "index.md"), relPath)
+		indexPath, ok := g.sectionIndexSource(relPath)
+		if ok && g.isDraftSection(indexPath) {
+			return nil
+		}
+		displayName := g.extractSectionTitle(indexPath, relPath)
 		g.sections = append(g.sections, section.Section{
 			DirName:     relPath,
 			DisplayName: displayName,
 		})
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	section.Sort(g.sections)
+	return nil
+}
+
+// isDraftSection reports whether the section index page at indexPath is a draft left out of the build.
+func (g *Generator) isDraftSection(indexPath string) bool {
+	return !g.includeDrafts && g.isDraft(indexPath)
+}
+
+// addNestedSection returns sections with nested added to the children of its parent section.
+// sections are returned unchanged when the parent is not a section, e.g. a directory without index page.
+func addNestedSection(sections []section.Section, nested section.Section) []section.Section {
+	parent := filepath.Dir(nested.DirName)
+	for i := range sections {
+		if sections[i].DirName == parent {
+			sections[i].Children = append(sections[i].Children, nested)
+			return sections
+		}
+		if strings.HasPrefix(parent, sections[i].DirName+"/") {
+			sections[i].Children = addNestedSection(sections[i].Children, nested)
+			return sections
+		}
+	}
+	return sections
+}
+
+// applyNavConfig renames and reorders the listed sections following the navigation configuration.
+func (g *Generator) applyNavConfig() error {
+	cfg := g.navConfig
+	if g.navConfigFile != "" {
+		data, err := g.fs.ReadFile(g.navConfigFile)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", g.navConfigFile, err)
+		}
+		if cfg, err = section.ParseNavConfig(data); err != nil {
+			return fmt.Errorf("%s: %w", g.navConfigFile, err)
+		}
+	}
+
+	sections, unknown := cfg.Apply(g.sections)
+	for _, name := range unknown {
+		if err := g.warn("navigation order references unknown section %q", name); err != nil {
+			return err
+		}
+	}
+	g.sections = sections
+
+	for _, name := range g.navExcluded {
+		if !section.Contains(g.sections, name) {
+			if err := g.warn("navigation exclusion references unknown section %q", name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkSectionIndexes ensures every navigable section has an index page,
+// so that navigation links do not point to missing pages. Nested sections always have one.
+func (g *Generator) checkSectionIndexes() error {
+	errs := make([]error, 0)
+	for _, s := range g.sections {
+		if indexMDPath, ok := g.sectionIndexSource(s.DirName); !ok {
+			name := s.DirName
+			if name == "" {
+				name = "home"
+			}
+			errs = append(errs, fmt.Errorf("section %q has no index page (expected %s)", name, indexMDPath))
+		}
+	}
+	return errors.Join(errs...)
 }
 
 // extractSectionTitle reads the # title from the index.md of a section directory.
@@ -78,3 +180,26 @@ func extractSection(contentDir, filePath string) (string, error) {
 	}
 	return section, nil
 }
+
+// sectionDepth returns the nesting depth of a section: 0 for the home section, 1 for "posts", 2 for "blog/2024".
+func sectionDepth(section string) int {
+	if section == "" {
+		return 0
+	}
+	return strings.Count(section, "/") + 1
+}
+
+// sectionTemplate returns the template configured for section or its closest parent section,
+// or the site template, "" to use the embedded page template. The home template only applies to root pages.
+func (g *Generator) sectionTemplate(section string) string {
+	for {
+		if tmpl, ok := g.sectionTemplates[section]; ok {
+			return tmpl
+		}
+		i := strings.LastIndex(section, "/")
+		if i < 0 {
+			return g.template
+		}
+		section = section[:i]
+	}
+}
@@ -0,0 +1,46 @@
+package site
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+)
+
+type (
+	urlSet struct {
+		XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+		URLs    []sitemapURL `xml:"url"`
+	}
+
+	sitemapURL struct {
+		Loc string `xml:"loc"`
+	}
+)
+
+// generateSitemap writes sitemap.xml at the build root with one entry per generated page.
+// Assets and scripts are not pages and are not listed.
+func (g *Generator) generateSitemap() error {
+	if !g.sitemap {
+		return nil
+	}
+
+	set := urlSet{URLs: make([]sitemapURL, 0, len(g.pages))}
+	for _, p := range g.pages {
+		if p.unlisted {
+			continue
+		}
+		set.URLs = append(set.URLs, sitemapURL{Loc: g.pageLocation(p.destinationHTMLPath)})
+	}
+
+	data, err := xml.MarshalIndent(set, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding sitemap: %w", err)
+	}
+
+	sitemapPath := filepath.Join(g.buildDir, "sitemap.xml")
+	if err := g.writeOutput(sitemapPath, append([]byte(xml.Header), data...)); err != nil {
+		return fmt.Errorf("writing %s: %w", sitemapPath, err)
+	}
+	g.logger.Info("generated sitemap", "path", sitemapPath)
+	return nil
+}
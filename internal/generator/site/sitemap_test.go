@@ -0,0 +1,93 @@
+package site
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerateSitemap(t *testing.T) {
+	files := map[string]string{
+		"index.md":        "# Home\n",
+		"posts/index.md":  "# Posts\n",
+		"posts/first.md":  "# First\n",
+		"posts/second.md": "# Second\n",
+		"about/index.md":  "# About\n",
+	}
+
+	t.Run("every generated page appears exactly once", func(t *testing.T) {
+		contentDir, buildDir := setupTestContent(t, files)
+		assetsDir := t.TempDir()
+		_ = os.WriteFile(filepath.Join(assetsDir, "logo.svg"), []byte("<svg></svg>"), 0644)
+		scriptsDir := t.TempDir()
+		_ = os.WriteFile(filepath.Join(scriptsDir, "app.js"), []byte("var x = 1;"), 0644)
+
+		gen := createTestGenerator(contentDir, buildDir).
+			withAssetsDir(assetsDir).
+			withScriptsDir(scriptsDir)
+		WithBaseURL("https://example.org/")(gen)
+		WithSitemap(true)(gen)
+
+		if err := gen.Generate(); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		data, err := os.ReadFile(filepath.Join(buildDir, "sitemap.xml"))
+		if err != nil {
+			t.Fatalf("sitemap.xml should be written: %v", err)
+		}
+
+		var set urlSet
+		if err := xml.Unmarshal(data, &set); err != nil {
+			t.Fatalf("sitemap.xml is not valid XML: %v", err)
+		}
+
+		counts := make(map[string]int)
+		for _, u := range set.URLs {
+			counts[u.Loc]++
+		}
+
+		want := []string{
+			"https://example.org/",
+			"https://example.org/posts/",
+			"https://example.org/posts/first.html",
+			"https://example.org/posts/second.html",
+			"https://example.org/about/",
+		}
+		if len(set.URLs) != len(want) {
+			t.Errorf("expected %d entries, got %d: %+v", len(want), len(set.URLs), set.URLs)
+		}
+		for _, loc := range want {
+			if counts[loc] != 1 {
+				t.Errorf("%s should appear exactly once, got %d", loc, counts[loc])
+			}
+		}
+	})
+
+	t.Run("root-relative locations without base URL", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, map[string]string{"index.md": "# Home\n"}, WithSitemap(true))
+		if err := gen.Generate(); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+
+		data, _ := os.ReadFile(filepath.Join(buildDir, "sitemap.xml"))
+		var set urlSet
+		if err := xml.Unmarshal(data, &set); err != nil {
+			t.Fatalf("sitemap.xml is not valid XML: %v", err)
+		}
+		if len(set.URLs) != 1 || set.URLs[0].Loc != "/" {
+			t.Errorf("expected a single / entry, got %+v", set.URLs)
+		}
+	})
+
+	t.Run("no sitemap by default", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, map[string]string{"index.md": "# Home\n"})
+		if err := gen.Generate(); err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(buildDir, "sitemap.xml")); err == nil {
+			t.Error("sitemap.xml should not be written without WithSitemap")
+		}
+	})
+}
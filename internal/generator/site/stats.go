@@ -0,0 +1,107 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/readingtime"
+	"github.com/tjnvr/blog/internal/generator/page/htmltext"
+)
+
+var (
+	// statsImageRe and statsLinkRe match the images and the links of the main content of a page
+	statsImageRe = regexp.MustCompile(`<img\s`)
+	statsLinkRe  = regexp.MustCompile(`<a\s[^>]*href="`)
+)
+
+type (
+	// PageStats counts the words, images and links of the main content of a generated page,
+	// leaving out its title, navigation, header and footer.
+	PageStats struct {
+		// Path is the path of the generated page
+		Path   string
+		Words  int
+		Images int
+		Links  int
+	}
+
+	// SiteStats sums the statistics of the generated pages.
+	SiteStats struct {
+		Pages  []PageStats
+		Words  int
+		Images int
+		Links  int
+		// AverageReadingTime is the average time to read a page at readingtime.DefaultWordsPerMinute, to the second
+		AverageReadingTime time.Duration
+	}
+)
+
+// Stats returns the statistics of the pages generated by the last Generate, unlisted pages such as
+// the not-found page left out. It reads the pages back from the build directory.
+func (g *Generator) Stats() (SiteStats, error) {
+	var stats SiteStats
+	for _, p := range g.pages {
+		if p.unlisted {
+			continue
+		}
+		content, err := g.fs.ReadFile(p.destinationHTMLPath)
+		if err != nil {
+			return SiteStats{}, fmt.Errorf("reading %s: %w", p.destinationHTMLPath, err)
+		}
+
+		page := pageStats(p.destinationHTMLPath, string(content))
+		stats.Pages = append(stats.Pages, page)
+		stats.Words += page.Words
+		stats.Images += page.Images
+		stats.Links += page.Links
+	}
+
+	if len(stats.Pages) > 0 {
+		wordsPerPage := float64(stats.Words) / float64(len(stats.Pages))
+		stats.AverageReadingTime = time.Duration(wordsPerPage / readingtime.DefaultWordsPerMinute * float64(time.Minute)).Round(time.Second)
+	}
+	return stats, nil
+}
+
+// pageStats returns the statistics of the main content of the page generated at path.
+func pageStats(path, content string) PageStats {
+	main := mainContent(content)
+	text := htmltext.StripTags(main, " ")
+	return PageStats{
+		Path:   path,
+		Words:  len(strings.Fields(text)),
+		Images: len(statsImageRe.FindAllStringIndex(main, -1)),
+		Links:  len(statsLinkRe.FindAllStringIndex(main, -1)),
+	}
+}
+
+// checkWordCounts ensures the pages other than the section index pages have between the minimum and maximum
+// word counts when configured. A zero bound is not checked.
+func (g *Generator) checkWordCounts() error {
+	if g.minWords == 0 && g.maxWords == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0)
+	for _, p := range g.pages {
+		if p.unlisted || g.isSectionIndex(p.destinationHTMLPath, p.section) {
+			continue
+		}
+		content, err := g.fs.ReadFile(p.destinationHTMLPath)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", p.destinationHTMLPath, err)
+		}
+
+		words := pageStats(p.destinationHTMLPath, string(content)).Words
+		if g.minWords > 0 && words < g.minWords {
+			errs = append(errs, fmt.Errorf("%s has %d words, minimum is %d", p.destinationHTMLPath, words, g.minWords))
+		}
+		if g.maxWords > 0 && words > g.maxWords {
+			errs = append(errs, fmt.Errorf("%s has %d words, maximum is %d", p.destinationHTMLPath, words, g.maxWords))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,60 @@
+package site
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n\nWelcome to my blog.\n",
+		"posts/index.md": "# Posts\n\n[First](first.md)\n",
+		"posts/first.md": "# First\n\nOne two three four five six.\n\n![Photo](https://example.com/photo.png)\n\n[Go](https://go.dev) and [home](../index.md).\n",
+	}
+
+	gen, buildDir := newIntegrationTestGenerator(t, files)
+	assert.NoError(t, gen.Generate())
+
+	stats, err := gen.Stats()
+	assert.NoError(t, err)
+	assert.Equal(t, []PageStats{
+		{Path: filepath.Join(buildDir, "index.html"), Words: 4},
+		{Path: filepath.Join(buildDir, "posts", "first.html"), Words: 10, Images: 1, Links: 2},
+		{Path: filepath.Join(buildDir, "posts", "index.html"), Words: 1, Links: 1},
+	}, stats.Pages)
+	assert.Equal(t, 15, stats.Words)
+	assert.Equal(t, 1, stats.Images)
+	assert.Equal(t, 3, stats.Links)
+	assert.Equal(t, 2*time.Second, stats.AverageReadingTime)
+}
+
+func TestWithWordCountLimits(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n\nWelcome.\n",
+		"posts/index.md": "# Posts",
+		"posts/short.md": "# Short\n\nToo short.\n",
+		"posts/long.md":  "# Long\n\nOne two three four five six seven eight.\n",
+	}
+
+	t.Run("within limits", func(t *testing.T) {
+		gen, _ := newIntegrationTestGenerator(t, files, WithWordCountLimits(2, 8))
+		assert.NoError(t, gen.Generate())
+	})
+
+	t.Run("outside limits", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithWordCountLimits(3, 5))
+		err := gen.Generate()
+		assert.ErrorContains(t, err, filepath.Join(buildDir, "posts", "short.html")+" has 2 words, minimum is 3")
+		assert.ErrorContains(t, err, filepath.Join(buildDir, "posts", "long.html")+" has 8 words, maximum is 5")
+		assert.NotContains(t, err.Error(), "index.html")
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithWordCountLimits(3, 5), WithDryRun(true))
+		assert.ErrorContains(t, gen.Generate(), filepath.Join(buildDir, "posts", "short.html")+" has 2 words, minimum is 3")
+		assert.NoFileExists(t, filepath.Join(buildDir, "posts", "short.html"))
+	})
+}
@@ -0,0 +1,153 @@
+package site
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/markdown"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/frontmatter"
+	"github.com/tjnvr/blog/internal/generator/page/markdown/metadata"
+)
+
+// tagsSection is the directory of the generated tag pages, relative to the content and build directories.
+const tagsSection = "tags"
+
+type (
+	// tag gathers the pages whose front matter carries a tag
+	tag struct {
+		name  string
+		slug  string
+		pages []taggedPage
+	}
+
+	taggedPage struct {
+		title string
+		// relPath is the path of the page source relative to the content of the site, under the mount of its root
+		relPath   string
+		createdAt string
+	}
+)
+
+// addTagPages registers the generators of the tags index and of one page per tag, if enabled.
+// Nothing is generated when no page has tags.
+func (g *Generator) addTagPages() error {
+	if !g.tagPages {
+		return nil
+	}
+
+	tags, err := g.collectTags()
+	if err != nil || len(tags) == 0 {
+		return err
+	}
+
+	if existing, ok := g.contentSource(tagsSection); ok {
+		return g.warn("%s exists, tag pages are not generated", existing)
+	}
+	tagsDir, ok := g.tagsDir()
+	if !ok {
+		return g.warn("no content directory is output at the build root, tag pages are not generated")
+	}
+
+	var index strings.Builder
+	index.WriteString("# Tags\n\n")
+	for _, t := range tags {
+		fmt.Fprintf(&index, "- [%s](%s.md) (%d)\n", t.name, t.slug, len(t.pages))
+		g.addPage(filepath.Join(tagsDir, t.slug+".md"), g.outputPath(filepath.Join(tagsSection, t.slug+".md")), tagsSection, tagPageSource(t))
+	}
+	g.addPage(filepath.Join(tagsDir, "index.md"), g.outputPath(filepath.Join(tagsSection, "index.md")), tagsSection, []byte(index.String()))
+	return nil
+}
+
+// collectTags returns the tags of the generated pages sorted by slug, with their pages most recent first.
+// Tags with the same slug are merged under the first name found.
+func (g *Generator) collectTags() ([]tag, error) {
+	bySlug := make(map[string]*tag)
+	for _, p := range g.pages {
+		source, err := g.fs.ReadFile(p.sourceMDPath)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p.sourceMDPath, err)
+		}
+		fm, body, err := frontmatter.Parse(source)
+		if err != nil || len(fm.Tags) == 0 {
+			continue
+		}
+
+		relPath, err := g.rootOf(p.sourceMDPath).relPath(p.sourceMDPath)
+		if err != nil {
+			return nil, err
+		}
+		tp := taggedPage{
+			title:     fm.Title,
+			relPath:   relPath,
+			createdAt: metadata.Extract(source).CreationDate,
+		}
+		if tp.title == "" {
+			tp.title = markdownTitle(body, sourceName(p.sourceMDPath))
+		}
+
+		for _, name := range fm.Tags {
+			slug := markdown.Slugify(name)
+			if slug == "" {
+				continue
+			}
+			t, ok := bySlug[slug]
+			if !ok {
+				t = &tag{name: strings.TrimSpace(name), slug: slug}
+				bySlug[slug] = t
+			}
+			t.pages = append(t.pages, tp)
+		}
+	}
+
+	tags := make([]tag, 0, len(bySlug))
+	for _, t := range bySlug {
+		sort.SliceStable(t.pages, func(i, j int) bool {
+			return t.pages[i].createdAt > t.pages[j].createdAt
+		})
+		tags = append(tags, *t)
+	}
+	sort.Slice(tags, func(i, j int) bool { return tags[i].slug < tags[j].slug })
+	return tags, nil
+}
+
+// tagsDir returns the directory of the tag pages in the first content root output at the build root,
+// so that they are rooted in a content root like the other pages, or false when every root is mounted elsewhere.
+func (g *Generator) tagsDir() (string, bool) {
+	for _, r := range g.contentRoots() {
+		if dir, ok := r.sourcePath(tagsSection); ok {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// tagPageSource returns the markdown of the page listing the pages of t, linking them relatively to the tags section,
+// as if the content roots were merged.
+func tagPageSource(t tag) []byte {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", t.name)
+	for _, p := range t.pages {
+		link, err := filepath.Rel(tagsSection, p.relPath)
+		if err != nil {
+			continue
+		}
+		if p.createdAt != "" {
+			fmt.Fprintf(&sb, "- [%s](%s) · *%s*\n", p.title, filepath.ToSlash(link), p.createdAt)
+		} else {
+			fmt.Fprintf(&sb, "- [%s](%s)\n", p.title, filepath.ToSlash(link))
+		}
+	}
+	return []byte(sb.String())
+}
+
+// markdownTitle returns the first "# " title of source, or fallback when there is none.
+func markdownTitle(source []byte, fallback string) string {
+	for line := range strings.SplitSeq(string(source), "\n") {
+		if after, ok := strings.CutPrefix(line, "# "); ok {
+			return strings.TrimSpace(after)
+		}
+	}
+	return fallback
+}
@@ -0,0 +1,84 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIntegration_TagPages(t *testing.T) {
+	files := map[string]string{
+		"index.md":        "# Home\n",
+		"posts/index.md":  "# Posts\n",
+		"posts/first.md":  "---\ntags: [Go, Testing]\n---\n# First Post\n",
+		"posts/second.md": "---\ntitle: Second\ntags: [go]\n---\n# Second Post\n",
+		"posts/third.md":  "# Untagged\n",
+	}
+
+	t.Run("tag index and one page per tag", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithTagPages(true))
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+
+		index, err := os.ReadFile(filepath.Join(buildDir, "tags", "index.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(index), `<title>Tags</title>`)
+		assert.Contains(t, string(index), `<a href="go.html">Go</a> (2)`)
+		assert.Contains(t, string(index), `<a href="testing.html">Testing</a> (1)`)
+		assert.Contains(t, string(index), `<nav`)
+
+		goPage, err := os.ReadFile(filepath.Join(buildDir, "tags", "go.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(goPage), `<title>Go</title>`)
+		assert.Contains(t, string(goPage), `<a href="../posts/first.html">First Post</a>`)
+		assert.Contains(t, string(goPage), `<a href="../posts/second.html">Second</a>`)
+		assert.NotContains(t, string(goPage), "Untagged")
+
+		testingPage, err := os.ReadFile(filepath.Join(buildDir, "tags", "testing.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(testingPage), `<a href="../posts/first.html">First Post</a>`)
+		assert.NotContains(t, string(testingPage), "second.html")
+
+		entries, err := os.ReadDir(filepath.Join(buildDir, "tags"))
+		assert.NoError(t, err)
+		assert.Len(t, entries, 3)
+	})
+
+	t.Run("several content directories", func(t *testing.T) {
+		blogDir, buildDir := setupTestContent(t, map[string]string{
+			"index.md":       "# Home\n",
+			"posts/index.md": "# Posts\n",
+			"posts/first.md": "---\ntags: [Go]\n---\n# First Post\n",
+		})
+		docsDir, _ := setupTestContent(t, map[string]string{
+			"index.md":   "# Docs\n",
+			"install.md": "---\ntags: [go]\n---\n# Install\n",
+		})
+
+		gen, _ := newIntegrationTestGenerator(t, map[string]string{}, WithTagPages(true),
+			WithContentDirs(blogDir, docsDir), WithContentMount(docsDir, "docs"), WithSkipURLValidation(true))
+		gen.withBuildDir(buildDir)
+		assert.NoError(t, gen.Generate())
+		assert.NoError(t, gen.Validate())
+
+		goPage, err := os.ReadFile(filepath.Join(buildDir, "tags", "go.html"))
+		assert.NoError(t, err)
+		assert.Contains(t, string(goPage), `<a href="../posts/first.html">First Post</a>`)
+		assert.Contains(t, string(goPage), `<a href="../docs/install.html">Install</a>`)
+		assert.FileExists(t, filepath.Join(buildDir, "tags", "index.html"))
+	})
+
+	t.Run("nothing generated without tags", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, map[string]string{"index.md": "# Home\n"}, WithTagPages(true))
+		assert.NoError(t, gen.Generate())
+		assert.NoDirExists(t, filepath.Join(buildDir, "tags"))
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files)
+		assert.NoError(t, gen.Generate())
+		assert.NoDirExists(t, filepath.Join(buildDir, "tags"))
+	})
+}
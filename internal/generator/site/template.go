@@ -0,0 +1,82 @@
+package site
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/sitevars"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/class"
+	"github.com/tjnvr/blog/internal/generator/page/markdown"
+)
+
+// loadTemplate reads the page template file, if any, so that its edits are picked up by every build.
+func (g *Generator) loadTemplate() error {
+	if g.templateFile == "" {
+		return nil
+	}
+
+	data, err := g.fs.ReadFile(g.templateFile)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", g.templateFile, err)
+	}
+	g.template = string(data)
+	return nil
+}
+
+// loadClassAllowlist gathers the classes allowed in the generated pages, from the options, the classes of the elements
+// the converter adds and the safelist file if any.
+// The allowed classes are nil when classes are not validated.
+func (g *Generator) loadClassAllowlist() error {
+	g.allowedClasses = nil
+	if g.classAllowlist == nil && g.classAllowlistFile == "" {
+		return nil
+	}
+
+	g.allowedClasses = append([]string{}, g.classAllowlist...)
+	headingAnchorClass := g.headingAnchorClass
+	if headingAnchorClass == "" {
+		headingAnchorClass = markdown.DefaultHeadingAnchorClass
+	}
+	for _, classes := range []string{g.tableWrapper, g.linkIcon, g.navActiveClass, headingAnchorClass} {
+		g.allowedClasses = append(g.allowedClasses, strings.Fields(classes)...)
+	}
+	if g.codeBlocks != nil {
+		for _, classes := range g.codeBlocks.Classes() {
+			g.allowedClasses = append(g.allowedClasses, strings.Fields(classes)...)
+		}
+	}
+	if g.classAllowlistFile == "" {
+		return nil
+	}
+
+	data, err := g.fs.ReadFile(g.classAllowlistFile)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", g.classAllowlistFile, err)
+	}
+	g.allowedClasses = append(g.allowedClasses, class.ParseAllowlist(data)...)
+	return nil
+}
+
+// loadSiteVariables gathers the site variables from the options and the site variables file if any,
+// whose variables override the options ones.
+func (g *Generator) loadSiteVariables() error {
+	g.resolvedSiteVars = maps.Clone(g.siteVariables)
+	if g.siteVariablesFile == "" {
+		return nil
+	}
+
+	data, err := g.fs.ReadFile(g.siteVariablesFile)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", g.siteVariablesFile, err)
+	}
+	vars, err := sitevars.Parse(data)
+	if err != nil {
+		return fmt.Errorf("reading %s: %w", g.siteVariablesFile, err)
+	}
+	if g.resolvedSiteVars == nil {
+		g.resolvedSiteVars = make(map[string]string, len(vars))
+	}
+	maps.Copy(g.resolvedSiteVars, vars)
+	return nil
+}
@@ -0,0 +1,94 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// srcsetAttributeRe matches the srcset attributes of generated pages, e.g. the sources of the rewritten pictures.
+var srcsetAttributeRe = regexp.MustCompile(`\ssrcset="([^"]*)"`)
+
+// checkUnusedAssets reports the assets copied to the build directory which no generated page references,
+// in href, src, content or srcset attributes. References from stylesheets are not followed.
+// Unused assets are warnings, or errors when failOnUnusedAssets is set.
+func (g *Generator) checkUnusedAssets() error {
+	if !g.unusedAssets && !g.failOnUnusedAssets {
+		return nil
+	}
+
+	referenced := make(map[string]bool)
+	for _, p := range g.pages {
+		content, err := g.fs.ReadFile(p.destinationHTMLPath)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", p.destinationHTMLPath, err)
+		}
+		pageDir := g.pageDir(p.destinationHTMLPath)
+		for _, target := range referencedURLs(string(content)) {
+			if i := strings.IndexAny(target, "?#"); i >= 0 {
+				target = target[:i]
+			}
+			if buildPath, ok := g.referencedBuildPath(target, pageDir); ok {
+				referenced[buildPath] = true
+			}
+		}
+	}
+
+	unused := make([]string, 0)
+	err := g.fs.Walk(g.assetsDir, func(p string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(g.assetsDir, p)
+		if err != nil {
+			return err
+		}
+		buildPath := path.Join(filepath.ToSlash(g.assetsOutDir), filepath.ToSlash(rel))
+		if renamed, ok := g.fingerprints[buildPath]; ok {
+			buildPath = renamed
+		}
+		if !referenced[buildPath] {
+			unused = append(unused, buildPath)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("listing assets: %w", err)
+	}
+	slices.Sort(unused)
+
+	errs := make([]error, 0)
+	for _, asset := range unused {
+		if g.failOnUnusedAssets {
+			errs = append(errs, fmt.Errorf("asset %s is not referenced by any page", asset))
+			continue
+		}
+		if err := g.warn("asset %s is not referenced by any page", asset); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// referencedURLs returns the URLs of the href, src and content attributes of content,
+// and of the candidates of its srcset attributes.
+func referencedURLs(content string) []string {
+	urls := make([]string, 0)
+	for _, m := range urlAttributeRe.FindAllStringSubmatch(content, -1) {
+		urls = append(urls, m[2])
+	}
+	for _, m := range srcsetAttributeRe.FindAllStringSubmatch(content, -1) {
+		// srcset lists candidates separated by commas, each a URL followed by an optional descriptor
+		for _, candidate := range strings.Split(m[1], ",") {
+			if fields := strings.Fields(candidate); len(fields) > 0 {
+				urls = append(urls, fields[0])
+			}
+		}
+	}
+	return urls
+}
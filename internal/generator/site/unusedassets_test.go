@@ -0,0 +1,85 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckUnusedAssets(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr bool
+		warned  []string
+	}{
+		{
+			name:   "disabled by default",
+			warned: []string{},
+		},
+		{
+			name:   "unused assets are warned",
+			opts:   []Option{WithUnusedAssets(true)},
+			warned: []string{"asset assets/images/orphan.png is not referenced by any page"},
+		},
+		{
+			name:    "unused assets fail the build",
+			opts:    []Option{WithFailOnUnusedAssets(true)},
+			wantErr: true,
+			warned:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rootDir := t.TempDir()
+			contentDir := filepath.Join(rootDir, "content", "markdown")
+			assetsDir := filepath.Join(rootDir, "content", "assets")
+			buildDir := filepath.Join(rootDir, "target", "build")
+
+			for path, content := range map[string]string{
+				"index.md":                    "# Home\n\n![Photo](../assets/images/photo.png)\n",
+				"../assets/images/photo.png":  "photo",
+				"../assets/images/orphan.png": "orphan",
+			} {
+				fullPath := filepath.Join(contentDir, path)
+				assert.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+				assert.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+			}
+
+			gen := createTestGenerator(contentDir, buildDir).
+				withAssetsDir(assetsDir).
+				withScriptsDir(filepath.Join(t.TempDir(), "empty-scripts"))
+			assert.NoError(t, os.MkdirAll(gen.scriptsDir, 0755))
+			for _, opt := range append(tt.opts, WithSkipURLValidation(true)) {
+				opt(gen)
+			}
+
+			err := gen.Generate()
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "asset assets/images/orphan.png is not referenced by any page")
+				assert.NotContains(t, err.Error(), "photo.png")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.ElementsMatch(t, tt.warned, gen.warnings)
+		})
+	}
+}
+
+func TestCheckUnusedAssets_PictureSources(t *testing.T) {
+	gen, _ := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home\n\n![Photo](/assets/photo.png)\n",
+	}, WithImageFormats(ImageFormatWebP), WithImageEncoder(fakeEncoder{}), WithFailOnUnusedAssets(true))
+	for name, content := range map[string]string{
+		"photo.png":  "photo",
+		"photo.webp": "hand-made",
+	} {
+		assert.NoError(t, os.WriteFile(filepath.Join(gen.assetsDir, name), []byte(content), 0644))
+	}
+
+	assert.NoError(t, gen.Generate())
+	assert.Empty(t, gen.warnings)
+}
@@ -0,0 +1,31 @@
+package site
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// pageURL returns the URL of a file generated in the build directory.
+// The URL is absolute when a base URL is configured, root-relative otherwise, under the path prefix either way.
+func (g *Generator) pageURL(htmlPath string) string {
+	rel, err := filepath.Rel(g.buildDir, htmlPath)
+	if err != nil {
+		rel = htmlPath
+	}
+	return g.siteURL() + "/" + filepath.ToSlash(rel)
+}
+
+// pageLocation returns the URL of a generated page, using the directory URL for index pages.
+func (g *Generator) pageLocation(htmlPath string) string {
+	return strings.TrimSuffix(g.pageURL(htmlPath), "index.html")
+}
+
+// siteHost returns the host of the base URL, or "" when no base URL is configured.
+func (g *Generator) siteHost() string {
+	u, err := url.Parse(g.baseURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
@@ -0,0 +1,83 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tjnvr/blog/internal/generator/page/html/substitution/bodyclass"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation"
+	"github.com/tjnvr/blog/internal/generator/page/html/validation/link"
+	"github.com/tjnvr/blog/internal/generator/section"
+)
+
+// ValidateBuild runs the page validators on the HTML files of a site already generated in buildDir,
+// without generating it. The sections checked by the navigation validator are read from the content directory.
+// Links to draft and ignored pages are not reported, as the pages excluded from the build are not known.
+func (g *Generator) ValidateBuild(buildDir string) error {
+	g.sections = make([]section.Section, 0)
+	if err := g.listSections(); err != nil {
+		return fmt.Errorf("failed to list site sections: %w", err)
+	}
+	if err := g.applyNavConfig(); err != nil {
+		return fmt.Errorf("failed to configure navigation: %w", err)
+	}
+	if err := g.loadClassAllowlist(); err != nil {
+		return fmt.Errorf("failed to load class allowlist: %w", err)
+	}
+	if g.allowedClasses != nil {
+		g.allowedClasses = append(g.allowedClasses, g.sectionBodyClasses(g.sections)...)
+	}
+
+	cfg := pageConfig{
+		linkCache:        link.NewCache(),
+		homeLabel:        g.homeLabel,
+		allowedClasses:   g.allowedClasses,
+		strictValidation: g.strictValidation,
+		logger:           g.logger,
+		externalTimeout:  g.externalTimeout,
+		pathPrefix:       g.pathPrefix,
+		maxImageSize:     g.maxImageSize,
+		validators:       g.validators,
+	}
+	validations := validation.NewRegistry(g.navSections(g.sections), g.skipURLValidation, cfg.validationOptions()...)
+
+	errs := make([]error, 0)
+	err := g.fs.Walk(buildDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || !strings.HasSuffix(path, ".html") {
+			return nil
+		}
+
+		content, err := g.fs.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("reading %s: %w", path, err)
+		}
+		if err := validations.Validate(path, buildDir, content); err != nil {
+			errs = append(errs, err)
+		}
+		return nil
+	})
+	if err != nil {
+		err = fmt.Errorf("walking %s: %w", buildDir, err)
+		g.report = newBuildReport(err)
+		return err
+	}
+
+	err = errors.Join(errs...)
+	g.report = newBuildReport(err)
+	return err
+}
+
+// sectionBodyClasses returns the {{body_class}} classes of the pages of sections and of their nested sections.
+func (g *Generator) sectionBodyClasses(sections []section.Section) []string {
+	var classes []string
+	for _, s := range sections {
+		classes = append(classes, bodyclass.Class(g.outputSection(s.DirName)))
+		classes = append(classes, g.sectionBodyClasses(s.Children)...)
+	}
+	return classes
+}
@@ -0,0 +1,47 @@
+package site
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerator_ValidateBuild(t *testing.T) {
+	files := map[string]string{
+		"index.md":       "# Home\n\n[First](posts/first.md)\n",
+		"posts/index.md": "# Posts",
+		"posts/first.md": "# First",
+	}
+
+	t.Run("valid build", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithSkipURLValidation(true))
+		assert.NoError(t, gen.Generate())
+
+		assert.NoError(t, gen.ValidateBuild(buildDir))
+	})
+
+	t.Run("broken link", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithSkipURLValidation(true))
+		assert.NoError(t, gen.Generate())
+		// The linked page has been removed from the build since it was generated
+		assert.NoError(t, os.Remove(filepath.Join(buildDir, "posts", "first.html")))
+
+		err := gen.ValidateBuild(buildDir)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "posts/first.html")
+	})
+
+	t.Run("without generating", func(t *testing.T) {
+		gen, buildDir := newIntegrationTestGenerator(t, files, WithSkipURLValidation(true))
+		assert.NoError(t, os.MkdirAll(buildDir, 0755))
+		page := `<html><body><nav role="navigation"><a href="index.html">Home</a><a href="posts/index.html">Posts</a></nav>` +
+			`<a href="missing.html">Missing</a></body></html>`
+		assert.NoError(t, os.WriteFile(filepath.Join(buildDir, "index.html"), []byte(page), 0644))
+
+		err := gen.ValidateBuild(buildDir)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "missing.html")
+	})
+}
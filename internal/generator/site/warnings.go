@@ -0,0 +1,18 @@
+package site
+
+import (
+	"errors"
+	"fmt"
+)
+
+// warn records a non fatal build issue.
+// In strict mode the issue is returned as an error instead.
+func (g *Generator) warn(format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+	if g.strict {
+		return errors.New(msg)
+	}
+	g.warnings = append(g.warnings, msg)
+	g.logger.Warn(msg)
+	return nil
+}
@@ -0,0 +1,123 @@
+package site
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// watchDebounce is how long the watcher waits after the last change before rebuilding,
+// so that a burst of editor save events triggers a single rebuild.
+const watchDebounce = 200 * time.Millisecond
+
+// Watch regenerates and validates the site whenever a file changes in the content, assets or scripts directories,
+// or in any of the extra paths (e.g. the styling configuration). It blocks until ctx is cancelled.
+func (g *Generator) Watch(ctx context.Context, extraPaths ...string) error {
+	return g.watch(ctx, watchDebounce, extraPaths, func() {
+		if err := g.Generate(); err != nil {
+			g.logger.Error("site generation failed", "error", err)
+			return
+		}
+		if err := g.Validate(); err != nil {
+			g.logger.Error("site validation failed", "error", err)
+			return
+		}
+		g.logger.Info("site regenerated")
+	})
+}
+
+// Serve serves the build directory on addr until ctx is cancelled.
+func (g *Generator) Serve(ctx context.Context, addr string) error {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           http.FileServer(http.Dir(g.buildDir)),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- server.ListenAndServe()
+	}()
+	g.logger.Info("serving site", "dir", g.buildDir, "addr", addr)
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		if err := server.Shutdown(context.Background()); err != nil {
+			return err
+		}
+		if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	}
+}
+
+func (g *Generator) watch(ctx context.Context, debounce time.Duration, extraPaths []string, build func()) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("creating watcher: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	paths := make([]string, 0)
+	for _, r := range g.contentRoots() {
+		paths = append(paths, r.dir)
+	}
+	paths = append(paths, g.assetsDir, g.scriptsDir)
+	paths = append(paths, extraPaths...)
+	for _, dir := range paths {
+		if err := watchTree(watcher, dir); err != nil {
+			return fmt.Errorf("watching %s: %w", dir, err)
+		}
+	}
+
+	timer := time.NewTimer(debounce)
+	timer.Stop()
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// New directories are not watched automatically
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					_ = watchTree(watcher, event.Name)
+				}
+			}
+			timer.Reset(debounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			g.logger.Error("watch failed", "error", err)
+		case <-timer.C:
+			build()
+		}
+	}
+}
+
+// watchTree adds root and all its subdirectories to the watcher.
+func watchTree(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		return watcher.Add(path)
+	})
+}
@@ -0,0 +1,89 @@
+package site
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatch_CoalescesBurstIntoSingleRebuild(t *testing.T) {
+	gen, _ := newIntegrationTestGenerator(t, map[string]string{
+		"index.md": "# Home\n",
+	})
+
+	var builds atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- gen.watch(ctx, 50*time.Millisecond, nil, func() { builds.Add(1) })
+	}()
+
+	// Let the watcher register the directories
+	time.Sleep(50 * time.Millisecond)
+
+	for i := range 5 {
+		_ = os.WriteFile(filepath.Join(gen.contentDir, "index.md"), []byte(fmt.Sprintf("# Home %d\n", i)), 0644)
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for builds.Load() == 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	time.Sleep(150 * time.Millisecond)
+
+	if got := builds.Load(); got != 1 {
+		t.Errorf("expected a single rebuild for a burst of changes, got %d", got)
+	}
+
+	t.Run("new directories are watched", func(t *testing.T) {
+		newDir := filepath.Join(gen.contentDir, "posts")
+		_ = os.MkdirAll(newDir, 0755)
+		time.Sleep(150 * time.Millisecond)
+		before := builds.Load()
+
+		_ = os.WriteFile(filepath.Join(newDir, "index.md"), []byte("# Posts\n"), 0644)
+		deadline := time.Now().Add(2 * time.Second)
+		for builds.Load() == before && time.Now().Before(deadline) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		if builds.Load() == before {
+			t.Error("a change in a new directory should trigger a rebuild")
+		}
+	})
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("watch() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("watch() should return once the context is cancelled")
+	}
+}
+
+func TestServe_StopsOnCancel(t *testing.T) {
+	gen, _ := newIntegrationTestGenerator(t, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- gen.Serve(ctx, "127.0.0.1:0")
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve() error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Serve() should return once the context is cancelled")
+	}
+}
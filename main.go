@@ -1,17 +1,49 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"os/signal"
 
 	"github.com/tjnvr/blog/internal/generator/site"
 )
 
 func main() {
 	skipURLValidation := flag.Bool("skip-url-validation", false, "Skip external URL validation")
+	watch := flag.Bool("watch", false, "Regenerate the site when a source file changes")
+	serveAddr := flag.String("serve", "", "Serve the build directory on the given address (e.g. :8080), implies -watch")
+	templateFile := flag.String("template", "", "Project the pages in this HTML template instead of the embedded one")
+	verbose := flag.Bool("verbose", false, "Log the build steps, such as the generated feed and sitemap")
+	debug := flag.Bool("debug", false, "Log every generated and copied file")
+	dryRun := flag.Bool("dry-run", false, "List the files the build would write without writing them")
 	flag.Parse()
 
-	gen, err := site.NewGenerator(site.WithSkipURLValidation(*skipURLValidation))
+	// Watching reports each regeneration, which is logged at info level
+	level := slog.LevelWarn
+	if *verbose || *watch || *serveAddr != "" {
+		level = slog.LevelInfo
+	}
+	if *debug {
+		level = slog.LevelDebug
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+
+	gen, err := site.NewGenerator(
+		site.WithSkipURLValidation(*skipURLValidation),
+		site.WithBaseURL("https://tjanvier.org"),
+		site.WithFeed("posts", "rss.xml", site.FeedRSS),
+		site.WithSitemap(true),
+		site.WithTagPages(true),
+		site.WithExternalLinks(false),
+		site.WithLogger(logger),
+		site.WithTemplateFile(*templateFile),
+		site.WithDryRun(*dryRun),
+		site.WithConversionCache(*watch || *serveAddr != ""),
+	)
 	if err != nil {
 		log.Fatalf("Could not create the site generator: %v\n", err)
 	}
@@ -20,9 +52,38 @@ func main() {
 		log.Fatalf("Site generation error: %v\n", err)
 	}
 
+	// Validators check the links against the build directory, left untouched by a dry run
+	if *dryRun {
+		for _, path := range gen.PlannedFiles() {
+			fmt.Println(path)
+		}
+		return
+	}
+
 	if err := gen.Validate(); err != nil {
-		log.Fatalf("Site validation error: %v\n", err)
+		log.Printf("Site validation error: %v\n", err)
+		log.Fatalf("Site validation failed:\n%s\n", gen.Report().Summary())
 	}
 
 	log.Println("Site generated successfully !")
+
+	if !*watch && *serveAddr == "" {
+		return
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if *serveAddr != "" {
+		go func() {
+			if err := gen.Serve(ctx, *serveAddr); err != nil {
+				log.Printf("Server error: %v\n", err)
+				stop()
+			}
+		}()
+	}
+
+	if err := gen.Watch(ctx, "styles"); err != nil {
+		log.Fatalf("Watch error: %v\n", err)
+	}
 }